@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type requestAttributesKey struct{}
+
+type responseAttributesKey struct{}
+
+// ResponseAttributes holds the attributes returned by the server in the context of the responses
+// to the operations that used the [context.Context] returned by [WithResponseAttributes].
+type ResponseAttributes struct {
+	mutex      sync.RWMutex
+	attributes map[string]string
+}
+
+// WithRequestAttributes returns a copy of the parent [context.Context] which carries the specified attributes.
+// When the returned context is used for an operation against a gRPC v1 proxy, the attributes are sent to the
+// server in the context of the request, where they are available to server-side interceptors, for example to
+// audit by tenant or correlation id. Attributes already in the parent context are retained unless overridden.
+// The attributes are ignored when connected to a gRPC v0 proxy.
+//
+//	ctx = coherence.WithRequestAttributes(ctx, map[string]string{"tenant": "acme", "correlationId": id})
+//	_, err = namedMap.Put(ctx, 1, "one")
+func WithRequestAttributes(parent context.Context, attributes map[string]string) context.Context {
+	merged := make(map[string]string, len(attributes))
+	maps.Copy(merged, RequestAttributes(parent))
+	maps.Copy(merged, attributes)
+
+	return context.WithValue(parent, requestAttributesKey{}, merged)
+}
+
+// RequestAttributes returns the request attributes carried by the [context.Context], or nil if there are none.
+func RequestAttributes(ctx context.Context) map[string]string {
+	if attributes, ok := ctx.Value(requestAttributesKey{}).(map[string]string); ok {
+		return attributes
+	}
+	return nil
+}
+
+// WithResponseAttributes returns a copy of the parent [context.Context] and a [ResponseAttributes] which
+// captures the string attributes returned by the server in the context of the responses to any operations
+// that use the returned context.
+//
+//	ctx, attributes := coherence.WithResponseAttributes(ctx)
+//	_, err = namedMap.Get(ctx, 1)
+//	fmt.Println(attributes.Get())
+func WithResponseAttributes(parent context.Context) (context.Context, *ResponseAttributes) {
+	attributes := &ResponseAttributes{attributes: make(map[string]string)}
+	return context.WithValue(parent, responseAttributesKey{}, attributes), attributes
+}
+
+// Get returns a copy of the attributes received from the server.
+func (ra *ResponseAttributes) Get() map[string]string {
+	ra.mutex.RLock()
+	defer ra.mutex.RUnlock()
+
+	return maps.Clone(ra.attributes)
+}
+
+// Value returns the attribute with the specified name and true if the attribute was received from the server.
+func (ra *ResponseAttributes) Value(name string) (string, bool) {
+	ra.mutex.RLock()
+	defer ra.mutex.RUnlock()
+
+	value, ok := ra.attributes[name]
+	return value, ok
+}
+
+// applyRequestAttributes copies any request attributes carried by the context into the context of the request.
+func (s *Session) applyRequestAttributes(ctx context.Context, req *pb1.ProxyRequest) {
+	attributes := RequestAttributes(ctx)
+	if len(attributes) == 0 {
+		return
+	}
+
+	if req.Context == nil {
+		req.Context = make(map[string]*anypb.Any, len(attributes))
+	}
+
+	for k, v := range attributes {
+		value, err := anypb.New(wrapperspb.String(v))
+		if err != nil {
+			s.logAttrs(WARNING, requestAttrs(req.Id), "unable to add request attribute %s: %v", k, err)
+			continue
+		}
+		req.Context[k] = value
+	}
+}
+
+// responseAttributes returns the string attributes from the context of a response, ignoring any other attributes.
+func (s *Session) responseAttributes(id int64, responseContext map[string]*anypb.Any) map[string]string {
+	if len(responseContext) == 0 {
+		return nil
+	}
+
+	attributes := make(map[string]string, len(responseContext))
+	for k, v := range responseContext {
+		var value wrapperspb.StringValue
+		if err := v.UnmarshalTo(&value); err != nil {
+			s.logAttrs(DEBUG, requestAttrs(id), "ignoring response attribute %s of type %s", k, v.GetTypeUrl())
+			continue
+		}
+		attributes[k] = value.GetValue()
+	}
+	return attributes
+}
+
+// recordResponseAttributes records the attributes from the context of a response in the
+// [ResponseAttributes] carried by the context, if any.
+func recordResponseAttributes(ctx context.Context, attributes map[string]string) {
+	if len(attributes) == 0 {
+		return
+	}
+
+	ra, ok := ctx.Value(responseAttributesKey{}).(*ResponseAttributes)
+	if !ok {
+		return
+	}
+
+	ra.mutex.Lock()
+	defer ra.mutex.Unlock()
+
+	maps.Copy(ra.attributes, attributes)
+}
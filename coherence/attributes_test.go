@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"testing"
+
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestWithRequestAttributes(t *testing.T) {
+	ctx := context.Background()
+
+	if attrs := RequestAttributes(ctx); attrs != nil {
+		t.Fatalf("expected no request attributes, got %v", attrs)
+	}
+
+	ctx = WithRequestAttributes(ctx, map[string]string{"tenant": "acme", "correlationId": "1"})
+	ctx = WithRequestAttributes(ctx, map[string]string{"correlationId": "2"})
+
+	attrs := RequestAttributes(ctx)
+	if len(attrs) != 2 || attrs["tenant"] != "acme" || attrs["correlationId"] != "2" {
+		t.Fatalf("unexpected request attributes %v", attrs)
+	}
+
+	session := &Session{sessOpts: &SessionOptions{}}
+	req := &pb1.ProxyRequest{Id: 1}
+	session.applyRequestAttributes(ctx, req)
+
+	if len(req.Context) != 2 {
+		t.Fatalf("expected 2 entries in request context, got %v", req.Context)
+	}
+	var value wrapperspb.StringValue
+	if err := req.Context["tenant"].UnmarshalTo(&value); err != nil || value.GetValue() != "acme" {
+		t.Fatalf("expected tenant=acme in request context, got %v, %v", req.Context["tenant"], err)
+	}
+
+	// no attributes should leave the request context unset
+	req = &pb1.ProxyRequest{Id: 2}
+	session.applyRequestAttributes(context.Background(), req)
+	if req.Context != nil {
+		t.Fatalf("expected no request context, got %v", req.Context)
+	}
+}
+
+func TestWithResponseAttributes(t *testing.T) {
+	ctx, attrs := WithResponseAttributes(context.Background())
+
+	str, _ := anypb.New(wrapperspb.String("member-1"))
+	num, _ := anypb.New(wrapperspb.Int32(1))
+
+	session := &Session{sessOpts: &SessionOptions{}}
+	recordResponseAttributes(ctx, session.responseAttributes(1, map[string]*anypb.Any{"member": str, "count": num}))
+
+	if value, ok := attrs.Value("member"); !ok || value != "member-1" {
+		t.Fatalf("expected member=member-1, got %v, %v", value, ok)
+	}
+	if _, ok := attrs.Value("count"); ok {
+		t.Fatalf("expected non-string attribute to be ignored")
+	}
+	if len(attrs.Get()) != 1 {
+		t.Fatalf("expected 1 response attribute, got %v", attrs.Get())
+	}
+
+	// recording without a ResponseAttributes in the context is a no-op
+	recordResponseAttributes(context.Background(), map[string]string{"member": "member-1"})
+}
@@ -192,6 +192,8 @@ type NamedMap[K comparable, V any] interface {
 
 	getBaseClient() *baseClient[K, V]
 
+	getMapFunctions() mapFunctions[K, V]
+
 	// IsReady returns whether this NamedMap is ready to be used.
 	// An example of when this method would return false would
 	// be where a partitioned cache service that owns this cache has no
@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package capture_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence"
+	"github.com/oracle/coherence-go-client/v2/coherence/capture"
+	"github.com/oracle/coherence-go-client/v2/coherence/testing/fakeproxy"
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWriterAndReader(t *testing.T) {
+	var (
+		buffer  bytes.Buffer
+		writer  = capture.NewWriter(&buffer)
+		now     = time.Now()
+		records = []*capture.Record{
+			{Time: now, Stream: 1, Request: &pb1.ProxyRequest{Id: 1}},
+			{Time: now.Add(time.Millisecond), Stream: 1, Response: &pb1.ProxyResponse{Id: 1}},
+			{Time: now.Add(2 * time.Millisecond), Stream: 2, Response: &pb1.ProxyResponse{Id: 0}},
+		}
+	)
+
+	for _, r := range records {
+		if err := writer.Write(r); err != nil {
+			t.Fatalf("unable to write record: %v", err)
+		}
+	}
+	if err := writer.Write(&capture.Record{Stream: 1}); !errors.Is(err, capture.ErrInvalidRecord) {
+		t.Fatalf("expected ErrInvalidRecord, got %v", err)
+	}
+
+	data := buffer.Bytes()
+	read, err := capture.ReadAll(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("unable to read records: %v", err)
+	}
+	if len(read) != len(records) {
+		t.Fatalf("expected %d records, got %d", len(records), len(read))
+	}
+	for i, r := range read {
+		if !r.Time.Equal(records[i].Time) || r.Stream != records[i].Stream ||
+			!proto.Equal(r.Request, records[i].Request) || !proto.Equal(r.Response, records[i].Response) {
+			t.Fatalf("expected %v, got %v", records[i], r)
+		}
+	}
+
+	// a capture which was truncated while writing a record
+	_, err = capture.ReadAll(bytes.NewReader(data[:len(data)-1]))
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+// runOperations runs the operations which are recorded and replayed, returning the value read.
+func runOperations(t *testing.T, address string, options ...func(*coherence.SessionOptions)) string {
+	ctx := context.Background()
+
+	session, err := coherence.NewSession(ctx, append([]func(*coherence.SessionOptions){coherence.WithAddress(address),
+		coherence.WithPlainText(), coherence.WithRequestTimeout(5 * time.Second)}, options...)...)
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	defer session.Close()
+
+	namedMap, err := coherence.GetNamedMap[int, string](session, "capture")
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+
+	events := make(chan struct{}, 10)
+	listener := coherence.NewMapListener[int, string]().OnInserted(func(coherence.MapEvent[int, string]) {
+		events <- struct{}{}
+	})
+	if err = namedMap.AddListener(ctx, listener); err != nil {
+		t.Fatalf("unable to add listener: %v", err)
+	}
+
+	if _, err = namedMap.Put(ctx, 1, "one"); err != nil {
+		t.Fatalf("unable to put: %v", err)
+	}
+	select {
+	case <-events:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the event")
+	}
+
+	value, err := namedMap.Get(ctx, 1)
+	if err != nil || value == nil {
+		t.Fatalf("unable to get: %v, %v", value, err)
+	}
+
+	if err = namedMap.RemoveListener(ctx, listener); err != nil {
+		t.Fatalf("unable to remove listener: %v", err)
+	}
+	return *value
+}
+
+func startFakeProxy(t *testing.T) string {
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+	return address
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	var buffer bytes.Buffer
+	runOperations(t, startFakeProxy(t), coherence.WithCapture(&buffer))
+
+	records, err := capture.ReadAll(&buffer)
+	if err != nil {
+		t.Fatalf("unable to read capture: %v", err)
+	}
+
+	var requests, responses, events int
+	for _, r := range records {
+		switch {
+		case r.Request != nil:
+			requests++
+		case r.Response.GetId() == 0:
+			events++
+		default:
+			responses++
+		}
+	}
+	if requests == 0 || responses == 0 || events == 0 {
+		t.Fatalf("expected requests, responses and events to be recorded, got %d, %d and %d", requests, responses, events)
+	}
+
+	// the recorded responses are replayed to a new client performing the same operations
+	server := capture.NewReplayServer(records)
+	address, err := server.Start()
+	if err != nil {
+		t.Fatalf("unable to start replay server: %v", err)
+	}
+	defer server.Stop()
+
+	if value := runOperations(t, address); value != "one" {
+		t.Fatalf("expected the recorded value, got %s", value)
+	}
+	if mismatches := server.Mismatches(); len(mismatches) != 0 {
+		t.Fatalf("expected no mismatches, got %v", mismatches)
+	}
+
+	// the recorded responses are the same as those from a new proxy
+	mismatches, err := capture.Compare(context.Background(), startFakeProxy(t), records)
+	if err != nil {
+		t.Fatalf("unable to compare: %v", err)
+	}
+	if len(mismatches) != 0 {
+		t.Fatalf("expected no mismatches, got %v", mismatches)
+	}
+}
+
+func TestCaptureFile(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		dir     = t.TempDir()
+		address = startFakeProxy(t)
+	)
+	t.Setenv("COHERENCE_CAPTURE_FILE", filepath.Join(dir, "session.capture"))
+
+	// each session captures to its own file
+	var sessions []*coherence.Session
+	for i := 0; i < 2; i++ {
+		session, err := coherence.NewSession(ctx, coherence.WithAddress(address), coherence.WithPlainText(),
+			coherence.WithRequestTimeout(5*time.Second))
+		if err != nil {
+			t.Fatalf("unable to create session: %v", err)
+		}
+		namedMap, err := coherence.GetNamedMap[int, string](session, "capture")
+		if err != nil {
+			t.Fatalf("unable to get map: %v", err)
+		}
+		if _, err = namedMap.Put(ctx, i, "value"); err != nil {
+			t.Fatalf("unable to put: %v", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	for _, session := range sessions {
+		session.Close()
+
+		f, err := os.Open(filepath.Join(dir, "session-"+session.ID()+".capture"))
+		if err != nil {
+			t.Fatalf("expected a capture file for session %s: %v", session.ID(), err)
+		}
+		records, err := capture.ReadAll(f)
+		_ = f.Close()
+		if err != nil {
+			t.Fatalf("unable to read capture: %v", err)
+		}
+
+		var responses int
+		for _, r := range records {
+			if r.Response.GetComplete() != nil {
+				responses++
+			}
+		}
+		if responses == 0 {
+			t.Fatalf("expected the responses for session %s to be captured, got %v", session.ID(), records)
+		}
+	}
+
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package capture
+
+import (
+	"context"
+	"time"
+
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+)
+
+// compareTimeout is how long Compare waits for a recorded response or event which has not been received.
+const compareTimeout = 5 * time.Second
+
+// Compare replays the recorded requests against the gRPC v1 proxy at address, for example a fake proxy or a
+// different Coherence version, and returns the differences between the responses and events received and those
+// recorded. The streams are replayed one at a time, with each request sent once the responses to the previous
+// request have been received. Requests are sent as recorded, so the proxy must assign the same cache and queue
+// ids as the proxy which was recorded. Fields of the init responses which identify the proxy are not compared.
+//
+// If no dial options are specified the connection uses plain text.
+func Compare(ctx context.Context, address string, records []*Record, options ...grpc.DialOption) ([]Mismatch, error) {
+	if len(options) == 0 {
+		options = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+
+	conn, err := grpc.NewClient(address, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var mismatches []Mismatch
+	for _, recorded := range streams(records) {
+		m, err := compareStream(ctx, pb1.NewProxyServiceClient(conn), recorded)
+		if err != nil {
+			return mismatches, err
+		}
+		mismatches = append(mismatches, m...)
+	}
+	return mismatches, nil
+}
+
+// compareStream replays the requests of a recorded stream and compares the responses.
+func compareStream(ctx context.Context, client pb1.ProxyServiceClient, records []*Record) ([]Mismatch, error) {
+	var (
+		streamID  = records[0].Stream
+		requests  []*pb1.ProxyRequest
+		expected  = make(map[int64][]*pb1.ProxyResponse)
+		actual    = make(map[int64][]*pb1.ProxyResponse)
+		responses = make(chan *pb1.ProxyResponse)
+	)
+
+	for _, r := range records {
+		switch {
+		case r.isHeartbeat():
+		case r.Request != nil:
+			requests = append(requests, r.Request)
+		default:
+			expected[r.Response.GetId()] = append(expected[r.Response.GetId()], r.Response)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.SubChannel(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer close(responses)
+		for {
+			response, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case responses <- response:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// receive waits until the expected number of responses for the id have been received
+	receive := func(id int64) {
+		timeout := time.NewTimer(compareTimeout)
+		defer timeout.Stop()
+
+		for len(actual[id]) < len(expected[id]) {
+			select {
+			case response, ok := <-responses:
+				if !ok {
+					return
+				}
+				if response.GetHeartbeat() == nil {
+					actual[response.GetId()] = append(actual[response.GetId()], response)
+				}
+			case <-timeout.C:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+
+	for _, request := range requests {
+		if err = stream.Send(request); err != nil {
+			return nil, err
+		}
+		receive(request.GetId())
+	}
+	receive(0)
+	_ = stream.CloseSend()
+
+	var (
+		mismatches []Mismatch
+		ids        = []int64{0}
+	)
+	for _, request := range requests {
+		ids = append(ids, request.GetId())
+	}
+	for _, id := range ids {
+		want, got := expected[id], actual[id]
+		for i := 0; i < len(want) || i < len(got); i++ {
+			m := Mismatch{Stream: streamID, ID: id, Index: i}
+			if i < len(want) {
+				m.Expected = want[i]
+			}
+			if i < len(got) {
+				m.Actual = got[i]
+			}
+			if m.Expected == nil || m.Actual == nil || !proto.Equal(normalizeResponse(want[i]), normalizeResponse(got[i])) {
+				mismatches = append(mismatches, m)
+			}
+		}
+	}
+	return mismatches, ctx.Err()
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+/*
+Package capture provides the recording and replay of the requests and responses sent on the Coherence gRPC v1
+streams, so that protocol-level issues such as the ordering of events or paging cookies can be reproduced
+without a Coherence cluster.
+
+A capture is recorded by a [Session] created using the coherence.WithCapture option, or with the environment
+variable COHERENCE_CAPTURE_FILE set to the name of a file. It is a sequence of records, each of which is written as
+its length, encoded as a protobuf varint, followed by the record encoded as the following protobuf message:
+
+	message Record {
+	    int64 time = 1;                                  // the time in nanoseconds since the Unix epoch
+	    int64 stream = 2;                                // the stream, numbered from 1 in the order opened
+	    coherence.proxy.v1.ProxyRequest request = 3;     // set for a request sent by the client
+	    coherence.proxy.v1.ProxyResponse response = 4;   // set for a response received by the client
+	}
+
+This is the same framing as used by protodelim, so a capture can also be read by other tools.
+
+A capture can be read using [ReadAll] and then either replayed to a client using a [ReplayServer]:
+
+	f, err := os.Open("session.capture")
+	if err != nil {
+	    log.Fatal(err)
+	}
+	records, err := capture.ReadAll(f)
+	if err != nil {
+	    log.Fatal(err)
+	}
+
+	server := capture.NewReplayServer(records)
+	address, err := server.Start()
+	if err != nil {
+	    log.Fatal(err)
+	}
+	defer server.Stop()
+
+	session, err := coherence.NewSession(ctx, coherence.WithAddress(address), coherence.WithPlainText())
+
+	// perform the same operations as were recorded, then check the requests matched
+	for _, m := range server.Mismatches() {
+	    log.Println(m)
+	}
+
+or sent to a proxy using [Compare], which returns the differences between the recorded responses and those received.
+
+[Session]: https://pkg.go.dev/github.com/oracle/coherence-go-client/v2/coherence#Session
+*/
+package capture
@@ -0,0 +1,253 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package capture
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// the field numbers of a record
+const (
+	fieldTime     protowire.Number = 1
+	fieldStream   protowire.Number = 2
+	fieldRequest  protowire.Number = 3
+	fieldResponse protowire.Number = 4
+)
+
+// maxRecordSize is the maximum size of a record which will be read, to guard against corrupt files.
+const maxRecordSize = 256 * 1024 * 1024
+
+var (
+	// ErrInvalidRecord indicates that a record does not contain exactly one request or response.
+	ErrInvalidRecord = errors.New("a record must contain exactly one request or response")
+
+	// ErrRecordTooLarge indicates that the length of a record read is larger than the maximum supported,
+	// which usually means that the file is not a capture or has been corrupted.
+	ErrRecordTooLarge = errors.New("the record is too large")
+)
+
+// Record is a single request sent or response received on a gRPC v1 stream.
+type Record struct {
+	// Time is the time the message was sent or received.
+	Time time.Time
+
+	// Stream identifies the stream the message was sent or received on, streams are numbered
+	// from 1 in the order they were opened.
+	Stream int64
+
+	// Request is the request sent, nil for a response.
+	Request *pb1.ProxyRequest
+
+	// Response is the response received, nil for a request.
+	Response *pb1.ProxyResponse
+}
+
+// String returns a string representation of a [Record].
+func (r *Record) String() string {
+	if r.Request != nil {
+		return fmt.Sprintf("Record{time=%v, stream=%d, request=%v}", r.Time.Format(time.RFC3339Nano), r.Stream, r.Request)
+	}
+	return fmt.Sprintf("Record{time=%v, stream=%d, response=%v}", r.Time.Format(time.RFC3339Nano), r.Stream, r.Response)
+}
+
+// isHeartbeat returns true if the record is a heartbeat request or response.
+func (r *Record) isHeartbeat() bool {
+	return r.Request.GetHeartbeat() != nil || r.Response.GetHeartbeat() != nil
+}
+
+// marshal encodes the record as a protobuf message.
+func (r *Record) marshal() ([]byte, error) {
+	var (
+		message proto.Message
+		field   protowire.Number
+	)
+	switch {
+	case r.Request != nil && r.Response == nil:
+		message, field = r.Request, fieldRequest
+	case r.Response != nil && r.Request == nil:
+		message, field = r.Response, fieldResponse
+	default:
+		return nil, ErrInvalidRecord
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	b := protowire.AppendTag(nil, fieldTime, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(r.Time.UnixNano()))
+	b = protowire.AppendTag(b, fieldStream, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(r.Stream))
+	b = protowire.AppendTag(b, field, protowire.BytesType)
+	return protowire.AppendBytes(b, data), nil
+}
+
+// unmarshal decodes a record from a protobuf message, ignoring unknown fields.
+func (r *Record) unmarshal(b []byte) error {
+	for len(b) > 0 {
+		number, wireType, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch {
+		case (number == fieldTime || number == fieldStream) && wireType == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b)
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if number == fieldTime {
+				r.Time = time.Unix(0, int64(v))
+			} else {
+				r.Stream = int64(v)
+			}
+			n = m
+		case (number == fieldRequest || number == fieldResponse) && wireType == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b)
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if number == fieldRequest {
+				r.Request = &pb1.ProxyRequest{}
+				if err := proto.Unmarshal(v, r.Request); err != nil {
+					return err
+				}
+			} else {
+				r.Response = &pb1.ProxyResponse{}
+				if err := proto.Unmarshal(v, r.Response); err != nil {
+					return err
+				}
+			}
+			n = m
+		default:
+			n = protowire.ConsumeFieldValue(number, wireType, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+		}
+		b = b[n:]
+	}
+
+	if (r.Request == nil) == (r.Response == nil) {
+		return ErrInvalidRecord
+	}
+	return nil
+}
+
+// Writer writes records to a capture. It is safe for concurrent use.
+type Writer struct {
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+// NewWriter returns a [Writer] which writes records to w. Each record is written using a single call to w.Write,
+// so a capture which is interrupted contains all the records written before it was interrupted.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{writer: w}
+}
+
+// Write writes a record.
+func (w *Writer) Write(record *Record) error {
+	data, err := record.marshal()
+	if err != nil {
+		return err
+	}
+
+	b := protowire.AppendVarint(make([]byte, 0, len(data)+binary.MaxVarintLen64), uint64(len(data)))
+	b = append(b, data...)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	_, err = w.writer.Write(b)
+	return err
+}
+
+// Reader reads records from a capture.
+type Reader struct {
+	reader *bufio.Reader
+}
+
+// NewReader returns a [Reader] which reads records from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{reader: bufio.NewReader(r)}
+}
+
+// Read reads the next record, returning io.EOF when there are no more records.
+func (r *Reader) Read() (*Record, error) {
+	length, err := binary.ReadUvarint(r.reader)
+	if err != nil {
+		return nil, err
+	}
+	if length > maxRecordSize {
+		return nil, ErrRecordTooLarge
+	}
+
+	data := make([]byte, length)
+	if _, err = io.ReadFull(r.reader, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	record := &Record{}
+	if err = record.unmarshal(data); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ReadAll reads all the records from r.
+func ReadAll(r io.Reader) ([]*Record, error) {
+	var (
+		reader  = NewReader(r)
+		records []*Record
+	)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
+
+// streams groups the records by stream, in the order the streams were opened.
+func streams(records []*Record) [][]*Record {
+	var (
+		order   []int64
+		grouped = make(map[int64][]*Record)
+	)
+	for _, r := range records {
+		if _, ok := grouped[r.Stream]; !ok {
+			order = append(order, r.Stream)
+		}
+		grouped[r.Stream] = append(grouped[r.Stream], r)
+	}
+
+	result := make([][]*Record, 0, len(order))
+	for _, stream := range order {
+		result = append(result, grouped[stream])
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package capture
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/grpc"
+)
+
+// Recorder records the requests sent and responses received on gRPC v1 streams to a capture.
+type Recorder struct {
+	writer  *Writer
+	streams atomic.Int64
+	mutex   sync.Mutex
+	err     error
+	now     func() time.Time
+}
+
+// NewRecorder returns a [Recorder] which writes records to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{writer: NewWriter(w), now: time.Now}
+}
+
+// StreamInterceptor is a gRPC stream client interceptor which records the messages on gRPC v1 ProxyService
+// streams. Other calls are not recorded.
+func (r *Recorder) StreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil || method != pb1.ProxyService_SubChannel_FullMethodName {
+		return stream, err
+	}
+	return &recordingStream{ClientStream: stream, recorder: r, id: r.streams.Add(1)}, nil
+}
+
+// Err returns the first error which occurred writing a record, after which no more records are written.
+func (r *Recorder) Err() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.err
+}
+
+// record writes a record unless a previous write has failed.
+func (r *Recorder) record(record *Record) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.err == nil {
+		r.err = r.writer.Write(record)
+	}
+}
+
+// recordingStream is a gRPC stream which records the messages sent and received.
+type recordingStream struct {
+	grpc.ClientStream
+	recorder *Recorder
+	id       int64
+}
+
+func (s *recordingStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if request, ok := m.(*pb1.ProxyRequest); ok && err == nil {
+		s.recorder.record(&Record{Time: s.recorder.now(), Stream: s.id, Request: request})
+	}
+	return err
+}
+
+func (s *recordingStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if response, ok := m.(*pb1.ProxyResponse); ok && err == nil {
+		s.recorder.record(&Record{Time: s.recorder.now(), Stream: s.id, Response: response})
+	}
+	return err
+}
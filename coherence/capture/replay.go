@@ -0,0 +1,332 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package capture
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrNotStarted indicates that the [ReplayServer] has not been started.
+var ErrNotStarted = errors.New("the replay server has not been started")
+
+// Mismatch is a difference between a recorded message and the message sent or received during a replay.
+type Mismatch struct {
+	// Stream is the recorded stream.
+	Stream int64
+
+	// ID is the recorded id of the request, zero for an event.
+	ID int64
+
+	// Index is the index of the message, within the requests for a stream, the responses for a request
+	// or the events for a stream.
+	Index int
+
+	// Expected is the recorded message, nil if the message was not expected.
+	Expected proto.Message
+
+	// Actual is the message sent or received, nil if the message was expected but not sent or received.
+	Actual proto.Message
+}
+
+// String returns a string representation of a [Mismatch].
+func (m Mismatch) String() string {
+	return fmt.Sprintf("Mismatch{stream=%d, id=%d, index=%d, expected=%v, actual=%v}", m.Stream, m.ID, m.Index, m.Expected, m.Actual)
+}
+
+// ReplayServer is a gRPC v1 proxy which replays the responses from a capture to a client. Each stream opened
+// by a client is matched to the next recorded stream. Each request received is compared with the next recorded
+// request, ignoring request ids, client uuids and listener filter ids, and is answered with the responses which
+// were recorded after it, in the recorded order and with their ids, and the filter ids of events, translated to
+// those of the requests received. This
+// reproduces the ordering of responses and events, and responses such as paging cookies, without a cluster.
+// Heartbeats are answered rather than replayed.
+type ReplayServer struct {
+	pb1.UnimplementedProxyServiceServer
+
+	streams    [][]*Record
+	mutex      sync.Mutex
+	next       int
+	mismatches []Mismatch
+	grpcServer *grpc.Server
+	listener   net.Listener
+}
+
+// NewReplayServer returns a new [ReplayServer] which replays the records.
+func NewReplayServer(records []*Record) *ReplayServer {
+	return &ReplayServer{streams: streams(records)}
+}
+
+// Start starts the [ReplayServer] listening on a free localhost port and returns the address to connect to,
+// which can be passed to coherence.WithAddress along with coherence.WithPlainText.
+func (s *ReplayServer) Start() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+
+	s.Serve(listener)
+	return s.Address()
+}
+
+// Serve starts the [ReplayServer] serving requests received on the listener, for example a bufconn.Listener.
+func (s *ReplayServer) Serve(listener net.Listener) {
+	grpcServer := grpc.NewServer()
+	pb1.RegisterProxyServiceServer(grpcServer, s)
+
+	s.mutex.Lock()
+	s.grpcServer = grpcServer
+	s.listener = listener
+	s.mutex.Unlock()
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+}
+
+// Address returns the address the [ReplayServer] is listening on.
+func (s *ReplayServer) Address() (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.listener == nil {
+		return "", ErrNotStarted
+	}
+	return s.listener.Addr().String(), nil
+}
+
+// Stop stops the [ReplayServer].
+func (s *ReplayServer) Stop() {
+	s.mutex.Lock()
+	grpcServer := s.grpcServer
+	s.grpcServer = nil
+	s.listener = nil
+	s.mutex.Unlock()
+
+	if grpcServer != nil {
+		grpcServer.Stop()
+	}
+}
+
+// Mismatches returns the differences found between the recorded requests and the requests received.
+func (s *ReplayServer) Mismatches() []Mismatch {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]Mismatch(nil), s.mismatches...)
+}
+
+func (s *ReplayServer) mismatch(m Mismatch) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.mismatches = append(s.mismatches, m)
+}
+
+// SubChannel implements the ProxyService bidirectional channel by replaying the next recorded stream.
+func (s *ReplayServer) SubChannel(stream grpc.BidiStreamingServer[pb1.ProxyRequest, pb1.ProxyResponse]) error {
+	s.mutex.Lock()
+	if s.next >= len(s.streams) {
+		s.mutex.Unlock()
+		return status.Error(codes.FailedPrecondition, "there are no more recorded streams to replay")
+	}
+	replay := &replayStream{server: s, stream: stream, records: s.streams[s.next],
+		ids: make(map[int64]int64), filters: make(map[int64]int64)}
+	s.next++
+	s.mutex.Unlock()
+
+	return replay.run()
+}
+
+// replayStream replays a recorded stream.
+type replayStream struct {
+	server   *ReplayServer
+	stream   grpc.BidiStreamingServer[pb1.ProxyRequest, pb1.ProxyResponse]
+	records  []*Record
+	position int
+	requests int
+	ids      map[int64]int64 // the recorded request ids to the ids received
+	filters  map[int64]int64 // the recorded listener filter ids to the ids received
+}
+
+func (r *replayStream) run() error {
+	if err := r.sendResponses(); err != nil {
+		return err
+	}
+
+	for {
+		request, err := r.stream.Recv()
+		if err != nil {
+			return nil
+		}
+
+		if heartbeat := request.GetHeartbeat(); heartbeat != nil {
+			if heartbeat.GetAck() {
+				if err = r.stream.Send(&pb1.ProxyResponse{Response: &pb1.ProxyResponse_Heartbeat{Heartbeat: &pb1.HeartbeatMessage{}}}); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		expected := r.nextRequest()
+		if expected == nil {
+			r.server.mismatch(Mismatch{Stream: r.streamID(), Index: r.requests, Actual: request})
+			if err = r.stream.Send(&pb1.ProxyResponse{Id: request.GetId(), Response: &pb1.ProxyResponse_Error{
+				Error: &pb1.ErrorMessage{Message: "there are no more recorded requests to replay"}}}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !proto.Equal(normalizeRequest(expected), normalizeRequest(request)) {
+			r.server.mismatch(Mismatch{Stream: r.streamID(), ID: expected.GetId(), Index: r.requests, Expected: expected, Actual: request})
+		}
+		r.ids[expected.GetId()] = request.GetId()
+		if id := filterID(expected); id != 0 {
+			r.filters[id] = filterID(request)
+		}
+		r.requests++
+
+		if err = r.sendResponses(); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *replayStream) streamID() int64 {
+	return r.records[0].Stream
+}
+
+// nextRequest returns the next recorded request, skipping heartbeats, or nil if there are none.
+func (r *replayStream) nextRequest() *pb1.ProxyRequest {
+	for r.position < len(r.records) {
+		record := r.records[r.position]
+		r.position++
+		if record.Request != nil && !record.isHeartbeat() {
+			return record.Request
+		}
+	}
+	return nil
+}
+
+// sendResponses sends the recorded responses up to the next recorded request, skipping heartbeats.
+func (r *replayStream) sendResponses() error {
+	for ; r.position < len(r.records); r.position++ {
+		record := r.records[r.position]
+		if record.Request != nil && !record.isHeartbeat() {
+			return nil
+		}
+		if record.Response == nil || record.isHeartbeat() {
+			continue
+		}
+
+		response := record.Response
+		if id, ok := r.ids[response.GetId()]; ok && id != response.GetId() {
+			response = proto.Clone(response).(*pb1.ProxyResponse)
+			response.Id = id
+		}
+		response = translateEvent(response, r.filters)
+		if err := r.stream.Send(response); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeRequest returns a copy of a request without the fields which differ between runs of a client.
+func normalizeRequest(request *pb1.ProxyRequest) *pb1.ProxyRequest {
+	normalized := proto.Clone(request).(*pb1.ProxyRequest)
+	normalized.Id = 0
+	if init := normalized.GetInit(); init != nil {
+		init.ClientUuid = nil
+	}
+	if filterID(normalized) != 0 {
+		// filter ids are allocated by each client process
+		setFilterID(normalized, 0)
+	}
+	return normalized
+}
+
+// mapListenerRequest returns the cache request and listener request within a request, if it is a listener request.
+func mapListenerRequest(request *pb1.ProxyRequest) (*pb1.NamedCacheRequest, *pb1.MapListenerRequest, bool) {
+	var (
+		cacheRequest    pb1.NamedCacheRequest
+		listenerRequest pb1.MapListenerRequest
+	)
+	if request.GetMessage() == nil || request.GetMessage().UnmarshalTo(&cacheRequest) != nil ||
+		cacheRequest.GetType() != pb1.NamedCacheRequestType_MapListener ||
+		cacheRequest.GetMessage().UnmarshalTo(&listenerRequest) != nil {
+		return nil, nil, false
+	}
+	return &cacheRequest, &listenerRequest, true
+}
+
+// filterID returns the filter id of a listener request, or zero if it is not a filter listener request.
+func filterID(request *pb1.ProxyRequest) int64 {
+	_, listenerRequest, ok := mapListenerRequest(request)
+	if !ok {
+		return 0
+	}
+	return listenerRequest.GetFilterId()
+}
+
+// setFilterID sets the filter id of a listener request.
+func setFilterID(request *pb1.ProxyRequest, id int64) {
+	cacheRequest, listenerRequest, ok := mapListenerRequest(request)
+	if !ok {
+		return
+	}
+	listenerRequest.FilterId = id
+	if err := cacheRequest.GetMessage().MarshalFrom(listenerRequest); err != nil {
+		return
+	}
+	_ = request.GetMessage().MarshalFrom(cacheRequest)
+}
+
+// translateEvent returns the response with the filter ids of a map event translated to the ids received.
+func translateEvent(response *pb1.ProxyResponse, filters map[int64]int64) *pb1.ProxyResponse {
+	var (
+		cacheResponse pb1.NamedCacheResponse
+		event         pb1.MapEventMessage
+	)
+	if response.GetId() != 0 || response.GetMessage() == nil || len(filters) == 0 ||
+		response.GetMessage().UnmarshalTo(&cacheResponse) != nil ||
+		cacheResponse.GetType() != pb1.ResponseType_MapEvent ||
+		cacheResponse.GetMessage().UnmarshalTo(&event) != nil {
+		return response
+	}
+
+	for i, id := range event.FilterIds {
+		if translated, ok := filters[id]; ok {
+			event.FilterIds[i] = translated
+		}
+	}
+
+	translated := proto.Clone(response).(*pb1.ProxyResponse)
+	if cacheResponse.GetMessage().MarshalFrom(&event) != nil || translated.GetMessage().MarshalFrom(&cacheResponse) != nil {
+		return response
+	}
+	return translated
+}
+
+// normalizeResponse returns a copy of a response without the fields which differ between proxies.
+func normalizeResponse(response *pb1.ProxyResponse) *pb1.ProxyResponse {
+	normalized := proto.Clone(response).(*pb1.ProxyResponse)
+	if init := normalized.GetInit(); init != nil {
+		init.Uuid = nil
+		init.ProxyMemberId = 0
+		init.ProxyMemberUuid = nil
+	}
+	return normalized
+}
@@ -0,0 +1,317 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCircuitFailureRate      = 0.5
+	defaultCircuitMinimumRequests  = 10
+	defaultCircuitWindow           = 10 * time.Second
+	defaultCircuitOpenDuration     = 5 * time.Second
+	defaultCircuitHalfOpenRequests = 1
+)
+
+var (
+	// ErrCircuitOpen indicates that a request was not sent because the circuit breaker for the
+	// session or cache is open, or is half-open and already probing the cluster.
+	ErrCircuitOpen = errors.New("the circuit breaker is open")
+
+	// ErrInvalidCircuitBreakerPolicy indicates that the values specified for a [CircuitBreakerPolicy] are not valid.
+	ErrInvalidCircuitBreakerPolicy = errors.New("circuit breaker policy values must not be negative and failure rate must be at most 1")
+)
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed indicates that requests are sent as normal.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen indicates that requests fail immediately with [ErrCircuitOpen].
+	CircuitOpen
+
+	// CircuitHalfOpen indicates that a limited number of requests are sent to probe whether the cluster has recovered.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitBreakerPolicy controls when a circuit breaker opens and how it recovers. Once at least MinimumRequests
+// have completed within a Window and the fraction which failed, including those which timed out, reaches
+// FailureRate, the circuit opens and requests fail immediately with [ErrCircuitOpen]. After OpenDuration the
+// circuit becomes half-open and HalfOpenRequests probe requests are sent; if they all succeed the circuit
+// closes, otherwise it opens again.
+type CircuitBreakerPolicy struct {
+	// FailureRate is the fraction, between 0 and 1, of failed requests at which the circuit opens, defaults to 0.5.
+	FailureRate float64
+
+	// MinimumRequests is the number of requests within the window before the failure rate is evaluated, defaults to 10.
+	MinimumRequests int
+
+	// Window is the period over which the failure rate is measured, defaults to 10 seconds.
+	Window time.Duration
+
+	// OpenDuration is how long the circuit stays open before it becomes half-open, defaults to 5 seconds.
+	OpenDuration time.Duration
+
+	// HalfOpenRequests is the number of probe requests sent when the circuit is half-open, defaults to 1.
+	HalfOpenRequests int
+}
+
+// DefaultCircuitBreakerPolicy returns the [CircuitBreakerPolicy] with the default values.
+func DefaultCircuitBreakerPolicy() CircuitBreakerPolicy {
+	return CircuitBreakerPolicy{
+		FailureRate:      defaultCircuitFailureRate,
+		MinimumRequests:  defaultCircuitMinimumRequests,
+		Window:           defaultCircuitWindow,
+		OpenDuration:     defaultCircuitOpenDuration,
+		HalfOpenRequests: defaultCircuitHalfOpenRequests,
+	}
+}
+
+// WithCircuitBreaker returns a function to enable a circuit breaker, using the [CircuitBreakerPolicy], for all
+// requests sent by a [Session] connected to a gRPC v1 proxy. Any zero values are replaced with the defaults
+// from [DefaultCircuitBreakerPolicy]. Changes of state raise a [CircuitStateChanged] session lifecycle event.
+func WithCircuitBreaker(policy CircuitBreakerPolicy) func(sessionOptions *SessionOptions) {
+	return func(s *SessionOptions) {
+		s.CircuitBreakerPolicy = &policy
+	}
+}
+
+// WithCacheCircuitBreaker returns a function to enable a circuit breaker for the requests sent by a [NamedMap]
+// or [NamedCache]. The requests for the map or cache are then governed by this circuit breaker rather than any
+// circuit breaker set using [WithCircuitBreaker].
+func WithCacheCircuitBreaker(policy CircuitBreakerPolicy) func(cacheOptions *CacheOptions) {
+	return func(c *CacheOptions) {
+		c.CircuitBreakerPolicy = &policy
+	}
+}
+
+// validate validates the policy and applies defaults for any zero values.
+func (p *CircuitBreakerPolicy) validate() error {
+	if p.FailureRate < 0 || p.FailureRate > 1 || p.MinimumRequests < 0 || p.Window < 0 ||
+		p.OpenDuration < 0 || p.HalfOpenRequests < 0 {
+		return ErrInvalidCircuitBreakerPolicy
+	}
+
+	if p.FailureRate == 0 {
+		p.FailureRate = defaultCircuitFailureRate
+	}
+	if p.MinimumRequests == 0 {
+		p.MinimumRequests = defaultCircuitMinimumRequests
+	}
+	if p.Window == 0 {
+		p.Window = defaultCircuitWindow
+	}
+	if p.OpenDuration == 0 {
+		p.OpenDuration = defaultCircuitOpenDuration
+	}
+	if p.HalfOpenRequests == 0 {
+		p.HalfOpenRequests = defaultCircuitHalfOpenRequests
+	}
+
+	return nil
+}
+
+func (p CircuitBreakerPolicy) String() string {
+	return fmt.Sprintf("CircuitBreakerPolicy{failureRate=%v, minimumRequests=%d, window=%v, openDuration=%v, halfOpenRequests=%d}",
+		p.FailureRate, p.MinimumRequests, p.Window, p.OpenDuration, p.HalfOpenRequests)
+}
+
+// CircuitStateChangedEvent is the [SessionLifecycleEvent] raised with type [CircuitStateChanged] when
+// the state of a circuit breaker changes.
+type CircuitStateChangedEvent interface {
+	SessionLifecycleEvent
+
+	// Name returns the name of the [NamedMap] or [NamedCache] the circuit breaker is for, or an
+	// empty string if it is for the [Session].
+	Name() string
+
+	// State returns the new state.
+	State() CircuitState
+
+	// PreviousState returns the previous state.
+	PreviousState() CircuitState
+}
+
+type circuitStateChangedEvent struct {
+	sessionLifecycleEvent
+	name          string
+	state         CircuitState
+	previousState CircuitState
+}
+
+func newCircuitStateChangedEvent(session *Session, name string, state, previousState CircuitState) CircuitStateChangedEvent {
+	return &circuitStateChangedEvent{
+		sessionLifecycleEvent: sessionLifecycleEvent{source: session, eventType: CircuitStateChanged},
+		name:                  name,
+		state:                 state,
+		previousState:         previousState,
+	}
+}
+
+func (e *circuitStateChangedEvent) Name() string {
+	return e.name
+}
+
+func (e *circuitStateChangedEvent) State() CircuitState {
+	return e.state
+}
+
+func (e *circuitStateChangedEvent) PreviousState() CircuitState {
+	return e.previousState
+}
+
+func (e *circuitStateChangedEvent) String() string {
+	return fmt.Sprintf("CircuitStateChangedEvent{source=%v, name=%s, state=%v, previousState=%v}",
+		e.Source(), e.name, e.state, e.previousState)
+}
+
+// circuitBreaker tracks the results of requests and fails requests fast when too many have failed.
+type circuitBreaker struct {
+	session     *Session
+	name        string
+	policy      CircuitBreakerPolicy
+	now         func() time.Time
+	mutex       sync.Mutex
+	state       CircuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int // the probe requests admitted while half-open
+	successes   int // the probe requests which succeeded while half-open
+}
+
+// newCircuitBreaker returns a circuit breaker for the policy, or nil if the policy is nil.
+func newCircuitBreaker(session *Session, name string, policy *CircuitBreakerPolicy) *circuitBreaker {
+	if policy == nil {
+		return nil
+	}
+	return &circuitBreaker{session: session, name: name, policy: *policy, now: time.Now}
+}
+
+// allow returns nil if a request may be sent, or [ErrCircuitOpen] if it may not.
+func (cb *circuitBreaker) allow() error {
+	cb.mutex.Lock()
+	previous := cb.state
+
+	if cb.state == CircuitOpen {
+		if cb.now().Sub(cb.openedAt) < cb.policy.OpenDuration {
+			cb.mutex.Unlock()
+			return ErrCircuitOpen
+		}
+		cb.state = CircuitHalfOpen
+		cb.probes = 0
+		cb.successes = 0
+	}
+
+	var err error
+	if cb.state == CircuitHalfOpen {
+		if cb.probes < cb.policy.HalfOpenRequests {
+			cb.probes++
+		} else {
+			err = ErrCircuitOpen
+		}
+	}
+
+	state := cb.state
+	cb.mutex.Unlock()
+
+	cb.stateChanged(state, previous)
+	return err
+}
+
+// record records the result of a request which was allowed.
+func (cb *circuitBreaker) record(err error) {
+	failed := err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, ErrMaxInFlightExceeded)
+
+	cb.mutex.Lock()
+	previous := cb.state
+	now := cb.now()
+
+	switch cb.state {
+	case CircuitClosed:
+		if now.Sub(cb.windowStart) > cb.policy.Window {
+			cb.windowStart = now
+			cb.requests = 0
+			cb.failures = 0
+		}
+		cb.requests++
+		if failed {
+			cb.failures++
+		}
+		if cb.requests >= cb.policy.MinimumRequests &&
+			float64(cb.failures) >= cb.policy.FailureRate*float64(cb.requests) {
+			cb.open(now)
+		}
+	case CircuitHalfOpen:
+		if failed {
+			cb.open(now)
+		} else if cb.successes++; cb.successes >= cb.policy.HalfOpenRequests {
+			cb.state = CircuitClosed
+			cb.windowStart = now
+			cb.requests = 0
+			cb.failures = 0
+		}
+	case CircuitOpen:
+		// results of requests allowed before the circuit opened are ignored
+	}
+
+	state := cb.state
+	cb.mutex.Unlock()
+
+	cb.stateChanged(state, previous)
+}
+
+// open opens the circuit, the mutex must be held.
+func (cb *circuitBreaker) open(now time.Time) {
+	cb.state = CircuitOpen
+	cb.openedAt = now
+}
+
+// stateChanged logs and raises an event if the state has changed.
+func (cb *circuitBreaker) stateChanged(state, previous CircuitState) {
+	if state == previous {
+		return
+	}
+
+	if cb.name == "" {
+		cb.session.log(WARNING, "Session [%s] circuit breaker changed from %v to %v", cb.session.sessionID, previous, state)
+	} else {
+		cb.session.logAttrs(WARNING, cacheAttrs(cb.name), "circuit breaker for %s changed from %v to %v", cb.name, previous, state)
+	}
+
+	cb.session.dispatch(CircuitStateChanged, func() SessionLifecycleEvent {
+		return newCircuitStateChangedEvent(cb.session, cb.name, state, previous)
+	})
+}
+
+// currentState returns the current state.
+func (cb *circuitBreaker) currentState() CircuitState {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	return cb.state
+}
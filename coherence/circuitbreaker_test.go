@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerStates(t *testing.T) {
+	var (
+		now     = time.Now()
+		session = newTestSession()
+		events  []CircuitStateChangedEvent
+		policy  = CircuitBreakerPolicy{FailureRate: 0.5, MinimumRequests: 4, OpenDuration: time.Second, HalfOpenRequests: 2}
+	)
+
+	if err := policy.validate(); err != nil || policy.Window != defaultCircuitWindow {
+		t.Fatalf("expected defaults to be applied, got %v, %v", policy, err)
+	}
+
+	session.AddSessionLifecycleListener(NewSessionLifecycleListener().OnCircuitStateChanged(func(e SessionLifecycleEvent) {
+		events = append(events, e.(CircuitStateChangedEvent))
+	}))
+
+	cb := newCircuitBreaker(session, "orders", &policy)
+	cb.now = func() time.Time { return now }
+
+	// the failure rate is not evaluated until the minimum number of requests have completed
+	failure := context.DeadlineExceeded
+	for _, err := range []error{failure, failure, nil} {
+		if cb.allow() != nil {
+			t.Fatalf("expected requests to be allowed while closed")
+		}
+		cb.record(err)
+	}
+	if cb.currentState() != CircuitClosed {
+		t.Fatalf("expected circuit to be closed, got %v", cb.currentState())
+	}
+
+	cb.record(nil)
+	if cb.currentState() != CircuitOpen || !errors.Is(cb.allow(), ErrCircuitOpen) {
+		t.Fatalf("expected circuit to be open, got %v", cb.currentState())
+	}
+
+	// after the open duration a limited number of probes are allowed
+	now = now.Add(time.Second)
+	if cb.allow() != nil || cb.allow() != nil || !errors.Is(cb.allow(), ErrCircuitOpen) {
+		t.Fatalf("expected two probes to be allowed while half-open")
+	}
+
+	// a failed probe opens the circuit again
+	cb.record(failure)
+	if cb.currentState() != CircuitOpen {
+		t.Fatalf("expected circuit to re-open, got %v", cb.currentState())
+	}
+
+	now = now.Add(time.Second)
+	_, _ = cb.allow(), cb.allow()
+	cb.record(nil)
+	cb.record(nil)
+	if cb.currentState() != CircuitClosed {
+		t.Fatalf("expected circuit to close after successful probes, got %v", cb.currentState())
+	}
+
+	expected := []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitOpen, CircuitHalfOpen, CircuitClosed}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %d", len(expected), len(events))
+	}
+	for i, e := range events {
+		if e.State() != expected[i] || e.Name() != "orders" {
+			t.Fatalf("unexpected event %d: %v", i, e)
+		}
+	}
+}
+
+func TestCircuitBreakerOperations(t *testing.T) {
+	session := newTestSession()
+	policy := CircuitBreakerPolicy{MinimumRequests: 1, FailureRate: 1}
+	_ = policy.validate()
+	session.breaker = newCircuitBreaker(session, "", &policy)
+
+	// an operation is only allowed once, however many requests it sends
+	ctx, op := session.startOperation(context.Background(), "test", "Get", false)
+	for i := 0; i < 2; i++ {
+		if _, err := admitRequest(ctx); err != nil {
+			t.Fatalf("expected request to be admitted, got %v", err)
+		}
+	}
+	op.end(errors.New("failed"))
+
+	ctx, op = session.startOperation(context.Background(), "test", "Get", false)
+	if _, err := admitRequest(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	op.end(ErrCircuitOpen)
+
+	if _, err := NewSession(context.Background(), WithCircuitBreaker(CircuitBreakerPolicy{FailureRate: 2})); !errors.Is(err, ErrInvalidCircuitBreakerPolicy) {
+		t.Fatalf("expected ErrInvalidCircuitBreakerPolicy, got %v", err)
+	}
+}
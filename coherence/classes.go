@@ -0,0 +1,291 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	classProperty = "@class"
+	valueProperty = "value"
+)
+
+var (
+	// ErrInvalidClass indicates that a class cannot be registered using [RegisterClass],
+	// or that a value with a registered class could not be decoded.
+	ErrInvalidClass = errors.New("invalid class")
+
+	classes = newClassRegistry()
+)
+
+// classRegistry maps the @class of JSON serialized values to the Go types they are decoded into.
+type classRegistry struct {
+	mutex    sync.RWMutex
+	decoders map[string]*classDecoder
+}
+
+// classDecoder decodes a JSON object with a @class into a Go type.
+type classDecoder struct {
+	typ    reflect.Type
+	decode func(r *classRegistry, data []byte) (any, error)
+}
+
+func (r *classRegistry) get(class string) *classDecoder {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.decoders[class]
+}
+
+func (r *classRegistry) register(class string, decoder *classDecoder) error {
+	if class == "" {
+		return fmt.Errorf("%w: class must not be empty", ErrInvalidClass)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if existing, ok := r.decoders[class]; ok && existing.typ != decoder.typ {
+		return fmt.Errorf("%w: %s is already registered for %v", ErrInvalidClass, class, existing.typ)
+	}
+	r.decoders[class] = decoder
+	return nil
+}
+
+// RegisterClass registers the Go type T for values with the specified @class, so that when values are deserialized
+// into an interface type, such as for a NamedMap[string, any], they are decoded into T rather than a map[string]any.
+// This also applies to the elements of slices and maps within such values. To be decoded correctly by other clients
+// and the cluster, T should include the @class when serialized, for example using a field tagged `json:"@class"`.
+//
+//	type OrderCreated struct {
+//	    Class   string `json:"@class"`
+//	    OrderID string `json:"orderId"`
+//	}
+//
+//	err := coherence.RegisterClass[OrderCreated]("events.OrderCreated")
+//
+// The following Java types are registered by default: java.math.BigDecimal as *big.Rat, java.math.BigInteger
+// as *big.Int, java.util.UUID as uuid.UUID, java.util.ArrayList, LinkedList, HashSet and TreeSet as []any,
+// java.time.Instant, OffsetDateTime, ZonedDateTime, LocalDateTime, LocalDate and LocalTime as time.Time and
+// java.time.Duration as time.Duration. These are expected to hold their value in a "value" property.
+// This means that, for example, a java.math.BigDecimal read from a NamedMap[string, any] is a *big.Rat rather than
+// the map[string]any returned by earlier releases; values read into a concrete type are not affected.
+// Registering the same type for a class more than once has no effect.
+func RegisterClass[T any](class string) error {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	return classes.register(class, &classDecoder{
+		typ: typ,
+		decode: func(_ *classRegistry, data []byte) (any, error) {
+			result := new(T)
+			if err := json.Unmarshal(data, result); err != nil {
+				return nil, fmt.Errorf("%w: unable to decode %v: %v", ErrInvalidClass, typ, err)
+			}
+			return *result, nil
+		},
+	})
+}
+
+// newClassRegistry returns a registry containing the default Java classes.
+func newClassRegistry() *classRegistry {
+	r := &classRegistry{decoders: make(map[string]*classDecoder)}
+	registerJavaClass(r, decodeBigRat, "math.BigDec", "java.math.BigDecimal")
+	registerJavaClass(r, decodeBigInt, "math.BigInt", "java.math.BigInteger")
+	registerJavaClass(r, decodeUUID, "java.util.UUID")
+	registerJavaClass(r, decodeCollection, "java.util.ArrayList", "java.util.LinkedList", "java.util.HashSet", "java.util.TreeSet")
+	registerJavaClass(r, decodeTime(time.RFC3339Nano, "2006-01-02T15:04Z07:00"), "java.time.Instant", "java.time.OffsetDateTime", "java.time.ZonedDateTime")
+	registerJavaClass(r, decodeTime("2006-01-02T15:04:05.999999999", "2006-01-02T15:04"), "java.time.LocalDateTime")
+	registerJavaClass(r, decodeTime(time.DateOnly), "java.time.LocalDate")
+	registerJavaClass(r, decodeTime("15:04:05.999999999", "15:04"), "java.time.LocalTime")
+	registerJavaClass(r, decodeDuration, "java.time.Duration")
+	return r
+}
+
+// registerJavaClass registers a Java class which is decoded from its "value" property.
+func registerJavaClass[T any](r *classRegistry, decode func(r *classRegistry, value json.RawMessage) (T, error), names ...string) {
+	decoder := &classDecoder{
+		typ: reflect.TypeOf((*T)(nil)).Elem(),
+		decode: func(r *classRegistry, data []byte) (any, error) {
+			var object map[string]json.RawMessage
+			if err := json.Unmarshal(data, &object); err != nil {
+				return nil, err
+			}
+			value, ok := object[valueProperty]
+			if !ok {
+				return nil, fmt.Errorf("%w: missing %s for %s", ErrInvalidClass, valueProperty, object[classProperty])
+			}
+			return decode(r, value)
+		},
+	}
+	for _, name := range names {
+		_ = r.register(name, decoder)
+	}
+}
+
+// unquote returns the string value of a JSON string or number.
+func unquote(value json.RawMessage) string {
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		return s
+	}
+	return string(bytes.TrimSpace(value))
+}
+
+func decodeBigRat(_ *classRegistry, value json.RawMessage) (*big.Rat, error) {
+	result, ok := new(big.Rat).SetString(unquote(value))
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid BigDecimal %s", ErrInvalidClass, value)
+	}
+	return result, nil
+}
+
+func decodeBigInt(_ *classRegistry, value json.RawMessage) (*big.Int, error) {
+	result, ok := new(big.Int).SetString(unquote(value), 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid BigInteger %s", ErrInvalidClass, value)
+	}
+	return result, nil
+}
+
+func decodeUUID(_ *classRegistry, value json.RawMessage) (uuid.UUID, error) {
+	return uuid.Parse(unquote(value))
+}
+
+func decodeCollection(r *classRegistry, value json.RawMessage) ([]any, error) {
+	result, err := r.decodeValue(value)
+	if err != nil {
+		return nil, err
+	}
+	if elements, ok := result.([]any); ok {
+		return elements, nil
+	}
+	return nil, fmt.Errorf("%w: invalid collection %s", ErrInvalidClass, value)
+}
+
+func decodeTime(layouts ...string) func(r *classRegistry, value json.RawMessage) (time.Time, error) {
+	return func(_ *classRegistry, value json.RawMessage) (time.Time, error) {
+		// a ZonedDateTime may include the zone id following the offset, for example [Europe/Paris]
+		s, _, _ := strings.Cut(unquote(value), "[")
+
+		// Java omits the seconds when they are zero, for example 10:15 rather than 10:15:00
+		result, err := time.Parse(layouts[0], s)
+		for _, layout := range layouts[1:] {
+			if err == nil {
+				break
+			}
+			if t, err1 := time.Parse(layout, s); err1 == nil {
+				result, err = t, nil
+			}
+		}
+		return result, err
+	}
+}
+
+// decodeDuration decodes an ISO-8601 duration, as used by java.time.Duration, for example PT1H30M or PT-0.5S.
+func decodeDuration(_ *classRegistry, value json.RawMessage) (time.Duration, error) {
+	s := unquote(value)
+	invalid := fmt.Errorf("%w: invalid Duration %s", ErrInvalidClass, s)
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if len(s) < 2 || s[0] != 'P' {
+		return 0, invalid
+	}
+	days, clock, ok := strings.Cut(s[1:], "T")
+
+	var result time.Duration
+	if days != "" {
+		n, err := strconv.ParseInt(strings.TrimSuffix(days, "D"), 10, 64)
+		if err != nil || !strings.HasSuffix(days, "D") {
+			return 0, invalid
+		}
+		result += time.Duration(n) * 24 * time.Hour
+	}
+	if ok {
+		// Go durations use the same units and allow the fractional and negative seconds Java uses
+		d, err := time.ParseDuration(strings.ToLower(clock))
+		if err != nil {
+			return 0, invalid
+		}
+		result += d
+	}
+
+	if negative {
+		result = -result
+	}
+	return result, nil
+}
+
+// isInterface returns true if T is an interface type, such as any.
+func isInterface[T any]() bool {
+	return reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.Interface
+}
+
+// decodeValue decodes a JSON value into an interface, decoding objects with a registered @class into
+// their Go type, other objects into a map[string]any, arrays into a []any and other values as [json.Unmarshal].
+func (r *classRegistry) decodeValue(data []byte) (any, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: empty value", ErrInvalidClass)
+	}
+
+	switch data[0] {
+	case '{':
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil, err
+		}
+		if raw, ok := object[classProperty]; ok {
+			var class string
+			if json.Unmarshal(raw, &class) == nil {
+				if decoder := r.get(class); decoder != nil {
+					return decoder.decode(r, data)
+				}
+			}
+		}
+
+		result := make(map[string]any, len(object))
+		for name, raw := range object {
+			value, err := r.decodeValue(raw)
+			if err != nil {
+				return nil, err
+			}
+			result[name] = value
+		}
+		return result, nil
+
+	case '[':
+		var elements []json.RawMessage
+		if err := json.Unmarshal(data, &elements); err != nil {
+			return nil, err
+		}
+		result := make([]any, len(elements))
+		for i, raw := range elements {
+			value, err := r.decodeValue(raw)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = value
+		}
+		return result, nil
+
+	default:
+		var result any
+		err := json.Unmarshal(data, &result)
+		return result, err
+	}
+}
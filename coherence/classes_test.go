@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type orderEvent interface {
+	orderID() string
+}
+
+type orderCreated struct {
+	Class   string `json:"@class"`
+	OrderID string `json:"orderId"`
+	Amount  int    `json:"amount"`
+}
+
+func (o orderCreated) orderID() string { return o.OrderID }
+
+type orderShipped struct {
+	Class   string `json:"@class"`
+	OrderID string `json:"orderId"`
+	Carrier string `json:"carrier"`
+}
+
+func (o orderShipped) orderID() string { return o.OrderID }
+
+func registerClassTestTypes(t *testing.T) {
+	if err := RegisterClass[orderCreated]("events.OrderCreated"); err != nil {
+		t.Fatalf("unable to register class: %v", err)
+	}
+	if err := RegisterClass[orderShipped]("events.OrderShipped"); err != nil {
+		t.Fatalf("unable to register class: %v", err)
+	}
+}
+
+func deserializeJSON[T any](t *testing.T, json string) *T {
+	value, err := NewSerializer[T]("json").Deserialize(append([]byte{jsonSerializationPrefix}, json...))
+	if err != nil {
+		t.Fatalf("unable to deserialize %s: %v", json, err)
+	}
+	return value
+}
+
+func TestRegisterClass(t *testing.T) {
+	registerClassTestTypes(t)
+
+	if err := RegisterClass[orderShipped]("events.OrderCreated"); !errors.Is(err, ErrInvalidClass) {
+		t.Fatalf("expected ErrInvalidClass for a class registered to another type, got %v", err)
+	}
+	if err := RegisterClass[orderShipped](""); !errors.Is(err, ErrInvalidClass) {
+		t.Fatalf("expected ErrInvalidClass for an empty class, got %v", err)
+	}
+}
+
+func TestPolymorphicDeserialization(t *testing.T) {
+	registerClassTestTypes(t)
+
+	created := orderCreated{Class: "events.OrderCreated", OrderID: "1", Amount: 10}
+	shipped := orderShipped{Class: "events.OrderShipped", OrderID: "1", Carrier: "post"}
+
+	// values are decoded into the registered type for an any
+	if value := deserializeJSON[any](t, `{"@class":"events.OrderCreated","orderId":"1","amount":10}`); *value != created {
+		t.Fatalf("expected %v, got %#v", created, *value)
+	}
+
+	// and for an interface they implement
+	if value := deserializeJSON[orderEvent](t, `{"@class":"events.OrderShipped","orderId":"1","carrier":"post"}`); *value != shipped {
+		t.Fatalf("expected %v, got %#v", shipped, *value)
+	}
+	if _, err := NewSerializer[orderEvent]("json").Deserialize([]byte("\x15\"text\"")); !errors.Is(err, ErrInvalidClass) {
+		t.Fatalf("expected ErrInvalidClass, got %v", err)
+	}
+
+	// nested values and unregistered classes
+	value := deserializeJSON[any](t, `{"events":[{"@class":"events.OrderCreated","orderId":"1","amount":10}],"other":{"@class":"unknown","a":1}}`)
+	expected := map[string]any{
+		"events": []any{created},
+		"other":  map[string]any{"@class": "unknown", "a": float64(1)},
+	}
+	if !reflect.DeepEqual(*value, expected) {
+		t.Fatalf("expected %v, got %v", expected, *value)
+	}
+
+	// the static type is still used for types which are not interfaces
+	if value := deserializeJSON[orderCreated](t, `{"@class":"events.OrderCreated","orderId":"1","amount":10}`); *value != created {
+		t.Fatalf("expected %v, got %v", created, *value)
+	}
+}
+
+func TestJavaClasses(t *testing.T) {
+	decimal := deserializeJSON[any](t, `{"@class":"math.BigDec","value":"19.50"}`)
+	if r, ok := (*decimal).(*big.Rat); !ok || r.Cmp(big.NewRat(39, 2)) != 0 {
+		t.Fatalf("expected 19.5, got %#v", *decimal)
+	}
+
+	integer := deserializeJSON[any](t, `{"@class":"java.math.BigInteger","value":"123456789012345678901234567890"}`)
+	if i, ok := (*integer).(*big.Int); !ok || i.String() != "123456789012345678901234567890" {
+		t.Fatalf("expected a big.Int, got %#v", *integer)
+	}
+
+	id := uuid.New()
+	if value := deserializeJSON[any](t, `{"@class":"java.util.UUID","value":"`+id.String()+`"}`); *value != id {
+		t.Fatalf("expected %v, got %#v", id, *value)
+	}
+
+	list := deserializeJSON[any](t, `{"@class":"java.util.ArrayList","value":[1,"two",{"@class":"math.BigInt","value":3}]}`)
+	if elements, ok := (*list).([]any); !ok || len(elements) != 3 || elements[1] != "two" || elements[2].(*big.Int).Int64() != 3 {
+		t.Fatalf("expected a list, got %#v", *list)
+	}
+
+	instant := time.Date(2025, 3, 4, 5, 6, 7, 8, time.UTC)
+	if value := deserializeJSON[any](t, `{"@class":"java.time.Instant","value":"2025-03-04T05:06:07.000000008Z"}`); !(*value).(time.Time).Equal(instant) {
+		t.Fatalf("expected %v, got %v", instant, *value)
+	}
+	zoned := deserializeJSON[any](t, `{"@class":"java.time.ZonedDateTime","value":"2025-03-04T06:06:07.000000008+01:00[Europe/Paris]"}`)
+	if !(*zoned).(time.Time).Equal(instant) {
+		t.Fatalf("expected %v, got %v", instant, *zoned)
+	}
+	if value := deserializeJSON[any](t, `{"@class":"java.time.LocalDate","value":"2025-03-04"}`); !(*value).(time.Time).Equal(time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected a date, got %v", *value)
+	}
+
+	// Java omits the seconds when they are zero
+	times := map[string]time.Time{
+		`{"@class":"java.time.LocalTime","value":"10:15"}`:                       time.Date(0, 1, 1, 10, 15, 0, 0, time.UTC),
+		`{"@class":"java.time.LocalTime","value":"10:15:30.5"}`:                  time.Date(0, 1, 1, 10, 15, 30, 500000000, time.UTC),
+		`{"@class":"java.time.LocalDateTime","value":"2024-01-01T10:15"}`:        time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC),
+		`{"@class":"java.time.OffsetDateTime","value":"2024-01-01T11:15+01:00"}`: time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC),
+	}
+	for s, expected := range times {
+		if value := deserializeJSON[any](t, s); !(*value).(time.Time).Equal(expected) {
+			t.Fatalf("expected %v for %s, got %v", expected, s, *value)
+		}
+	}
+
+	durations := map[string]time.Duration{
+		"PT8H6M12.345S": 8*time.Hour + 6*time.Minute + 12345*time.Millisecond,
+		"PT-0.5S":       -500 * time.Millisecond,
+		"P2DT1H":        49 * time.Hour,
+		"-PT1M":         -time.Minute,
+	}
+	for s, expected := range durations {
+		if value := deserializeJSON[any](t, `{"@class":"java.time.Duration","value":"`+s+`"}`); *value != expected {
+			t.Fatalf("expected %v for %s, got %v", expected, s, *value)
+		}
+	}
+
+	if _, err := NewSerializer[any]("json").Deserialize([]byte("\x15{\"@class\":\"java.time.Duration\",\"value\":\"1h\"}")); !errors.Is(err, ErrInvalidClass) {
+		t.Fatalf("expected ErrInvalidClass, got %v", err)
+	}
+}
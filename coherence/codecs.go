@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrInvalidSerializer indicates that a [Serializer] cannot be registered, or that the [Serializer] passed
+	// to [WithKeySerializer] or [WithValueSerializer] does not match the key or value type of the cache.
+	ErrInvalidSerializer = errors.New("invalid serializer")
+
+	customSerializers = serializerRegistry{serializers: make(map[string]Serializer[any])}
+)
+
+// serializerRegistry holds the serializers registered using RegisterSerializer, by format.
+type serializerRegistry struct {
+	mutex       sync.RWMutex
+	serializers map[string]Serializer[any]
+}
+
+func (r *serializerRegistry) get(format string) Serializer[any] {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.serializers[format]
+}
+
+// RegisterSerializer registers a [Serializer] for a custom format. Once registered, [NewSerializer] returns
+// the serializer for the format, the format can be used with [WithFormat], and a [NamedMap] or [NamedCache]
+// created with a [WithValueSerializer] of the format also uses it for keys, filters, extractors, entry
+// processors and aggregators, and their results. The serializer must be able to serialize each of these,
+// and the proxy must be configured with a serializer of the same name which understands the data.
+// A gRPC v1 proxy uses the format of the session for all the caches of a session, as the format is only sent
+// when each stream is opened, so with a gRPC v1 proxy a registered format must be set using [WithFormat].
+//
+//	err := coherence.RegisterSerializer(myBinarySerializer{})
+//
+// The "json" and "pof" formats cannot be replaced, and "raw" is reserved for [GetRawNamedMap].
+func RegisterSerializer(serializer Serializer[any]) error {
+	if serializer == nil {
+		return fmt.Errorf("%w: serializer must not be nil", ErrInvalidSerializer)
+	}
+
+	format := serializer.Format()
+	if format == "" || format == defaultFormat || format == pofFormat || format == rawSerializerFormat {
+		return fmt.Errorf("%w: format %q cannot be registered", ErrInvalidSerializer, format)
+	}
+
+	customSerializers.mutex.Lock()
+	defer customSerializers.mutex.Unlock()
+	customSerializers.serializers[format] = serializer
+	return nil
+}
+
+// isKnownFormat returns true if the format is "json", "pof" or registered using RegisterSerializer.
+func isKnownFormat(format string) bool {
+	return format == defaultFormat || format == pofFormat || customSerializers.get(format) != nil
+}
+
+// WithKeySerializer returns a function to set the [Serializer] used for the keys of a [NamedMap] or [NamedCache],
+// instead of the serializer for the format of the [Session]. The type parameter must match the key type of the cache.
+//
+//	namedMap, err := coherence.GetNamedMap[string, Order](session, "orders",
+//	    coherence.WithKeySerializer[string](orderIDSerializer{}))
+func WithKeySerializer[K any](serializer Serializer[K]) func(cacheOptions *CacheOptions) {
+	return func(c *CacheOptions) {
+		c.keySerializer = serializer
+	}
+}
+
+// WithValueSerializer returns a function to set the [Serializer] used for the values of a [NamedMap] or [NamedCache],
+// instead of the serializer for the format of the [Session]. The type parameter must match the value type of the cache.
+// If the format of the serializer has been registered using [RegisterSerializer], the registered serializer is also
+// used for the keys, unless set using [WithKeySerializer], and for the filters, extractors, entry processors and
+// aggregators of the cache. As a gRPC v1 proxy uses the format of the session for all caches, [ErrInvalidSerializer]
+// is returned for a serializer with a different registered format when the session is connected to a gRPC v1 proxy.
+//
+//	namedMap, err := coherence.GetNamedMap[string, Order](session, "orders",
+//	    coherence.WithValueSerializer[Order](orderSerializer{}))
+func WithValueSerializer[V any](serializer Serializer[V]) func(cacheOptions *CacheOptions) {
+	return func(c *CacheOptions) {
+		c.valueSerializer = serializer
+	}
+}
+
+// validateSerializers ensures any serializers set using WithKeySerializer or WithValueSerializer
+// match the key and value types, and returns the format to use for the cache.
+func validateSerializers[K comparable, V any](format string, cOpts *CacheOptions) (string, error) {
+	if cOpts.keySerializer != nil {
+		if _, ok := cOpts.keySerializer.(Serializer[K]); !ok {
+			return "", fmt.Errorf("%w: %T cannot serialize keys of type %v", ErrInvalidSerializer, cOpts.keySerializer, typeName[K]())
+		}
+	}
+
+	if cOpts.valueSerializer != nil {
+		serializer, ok := cOpts.valueSerializer.(Serializer[V])
+		if !ok {
+			return "", fmt.Errorf("%w: %T cannot serialize values of type %v", ErrInvalidSerializer, cOpts.valueSerializer, typeName[V]())
+		}
+		if isKnownFormat(serializer.Format()) {
+			return serializer.Format(), nil
+		}
+	}
+
+	return format, nil
+}
+
+// validateStreamFormat ensures that the format of a cache is the format of the session when connected to a gRPC v1
+// proxy, as the format is set when each stream is opened rather than sent with each request as it is for gRPC v0.
+func validateStreamFormat(session *Session, sOpts *SessionOptions, format string) error {
+	if format != sOpts.Format && session.GetProtocolVersion() > 0 {
+		return fmt.Errorf("%w: format %q is not the session format %q, which a gRPC v1 proxy uses for all caches",
+			ErrInvalidSerializer, format, sOpts.Format)
+	}
+	return nil
+}
+
+// applySerializers replaces the serializers of a baseClient with any set using WithKeySerializer or WithValueSerializer.
+func (bc *baseClient[K, V]) applySerializers() {
+	if s, ok := bc.cacheOpts.keySerializer.(Serializer[K]); ok {
+		bc.keySerializer = s
+	}
+	if s, ok := bc.cacheOpts.valueSerializer.(Serializer[V]); ok {
+		bc.valueSerializer = s
+	}
+}
+
+func typeName[T any]() string {
+	return fmt.Sprintf("%T", (*T)(nil))[1:]
+}
+
+// customSerializer adapts a serializer registered using RegisterSerializer to the type T.
+type customSerializer[T any] struct {
+	serializer Serializer[any]
+}
+
+// Serialize serializes an object of type T and returns the []byte representation.
+func (s customSerializer[T]) Serialize(object T) ([]byte, error) {
+	return s.serializer.Serialize(object)
+}
+
+// Deserialize deserialized an object and returns the correct type of T.
+func (s customSerializer[T]) Deserialize(data []byte) (*T, error) {
+	var zeroValue T
+	value, err := s.serializer.Deserialize(data)
+	if err != nil {
+		return &zeroValue, err
+	}
+	if value == nil || *value == nil {
+		return nil, nil
+	}
+
+	result, ok := (*value).(T)
+	if !ok {
+		return &zeroValue, fmt.Errorf("%w: format %s returned %T which is not a %v", ErrInvalidSerializer,
+			s.serializer.Format(), *value, typeName[T]())
+	}
+	return &result, nil
+}
+
+// Format returns the format used for the serializer.
+func (s customSerializer[T]) Format() string {
+	return s.serializer.Format()
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/testing/fakeproxy"
+)
+
+const rawFormat = "raw-test"
+
+// rawStringSerializer serializes strings as their bytes following a prefix.
+type rawStringSerializer struct {
+	format string
+	calls  *atomic.Int32
+}
+
+func (s rawStringSerializer) Serialize(object string) ([]byte, error) {
+	s.calls.Add(1)
+	return append([]byte{99}, object...), nil
+}
+
+func (s rawStringSerializer) Deserialize(data []byte) (*string, error) {
+	s.calls.Add(1)
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if data[0] != 99 {
+		return nil, fmt.Errorf("invalid serialization prefix %v", data[0])
+	}
+	result := string(data[1:])
+	return &result, nil
+}
+
+func (s rawStringSerializer) Format() string {
+	return s.format
+}
+
+// rawAnySerializer is the registered serializer for rawFormat which only supports strings.
+type rawAnySerializer struct{}
+
+func (rawAnySerializer) Serialize(object any) ([]byte, error) {
+	s, ok := object.(string)
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %T", object)
+	}
+	return append([]byte{99}, s...), nil
+}
+
+func (rawAnySerializer) Deserialize(data []byte) (*any, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var result any = string(data[1:])
+	return &result, nil
+}
+
+func (rawAnySerializer) Format() string {
+	return rawFormat
+}
+
+func TestRegisterSerializer(t *testing.T) {
+	if err := RegisterSerializer(nil); !errors.Is(err, ErrInvalidSerializer) {
+		t.Fatalf("expected ErrInvalidSerializer, got %v", err)
+	}
+	if err := RegisterSerializer(NewSerializer[any]("json")); !errors.Is(err, ErrInvalidSerializer) {
+		t.Fatalf("expected ErrInvalidSerializer for json, got %v", err)
+	}
+	if err := RegisterSerializer(rawAnySerializer{}); err != nil {
+		t.Fatalf("unable to register serializer: %v", err)
+	}
+
+	if !isKnownFormat(rawFormat) || isKnownFormat("unknown") {
+		t.Fatalf("expected only %s to be a known format", rawFormat)
+	}
+
+	serializer := NewSerializer[string](rawFormat)
+	if serializer.Format() != rawFormat {
+		t.Fatalf("expected the registered serializer, got %s", serializer.Format())
+	}
+	data, err := serializer.Serialize("hello")
+	if err != nil {
+		t.Fatalf("unable to serialize: %v", err)
+	}
+	value, err := serializer.Deserialize(data)
+	if err != nil || value == nil || *value != "hello" {
+		t.Fatalf("expected hello, got %v, %v", value, err)
+	}
+
+	// the registered serializer returns a string which is not an int
+	if _, err = NewSerializer[int](rawFormat).Deserialize(data); !errors.Is(err, ErrInvalidSerializer) {
+		t.Fatalf("expected ErrInvalidSerializer, got %v", err)
+	}
+}
+
+func TestValidateSerializers(t *testing.T) {
+	calls := &atomic.Int32{}
+
+	options := &CacheOptions{}
+	WithKeySerializer[int](NewSerializer[int]("json"))(options)
+	if _, err := validateSerializers[string, string]("json", options); !errors.Is(err, ErrInvalidSerializer) {
+		t.Fatalf("expected ErrInvalidSerializer for a key serializer of the wrong type, got %v", err)
+	}
+
+	options = &CacheOptions{}
+	WithValueSerializer[string](rawStringSerializer{format: rawFormat, calls: calls})(options)
+	if _, err := validateSerializers[string, int]("json", options); !errors.Is(err, ErrInvalidSerializer) {
+		t.Fatalf("expected ErrInvalidSerializer for a value serializer of the wrong type, got %v", err)
+	}
+
+	// the format of the value serializer is only used once registered
+	customSerializers.mutex.Lock()
+	delete(customSerializers.serializers, rawFormat)
+	customSerializers.mutex.Unlock()
+	if format, err := validateSerializers[string, string]("json", options); err != nil || format != "json" {
+		t.Fatalf("expected json, got %s, %v", format, err)
+	}
+	if err := RegisterSerializer(rawAnySerializer{}); err != nil {
+		t.Fatalf("unable to register serializer: %v", err)
+	}
+	if format, err := validateSerializers[string, string]("json", options); err != nil || format != rawFormat {
+		t.Fatalf("expected %s, got %s, %v", rawFormat, format, err)
+	}
+}
+
+func TestCustomValueSerializer(t *testing.T) {
+	ctx := context.Background()
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	session, err := NewSession(ctx, WithAddress(address), WithPlainText(), WithRequestTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	// the format is not registered so keys use the session format
+	calls := &atomic.Int32{}
+	namedMap, err := GetNamedMap[int, string](session, "custom-values",
+		WithValueSerializer[string](rawStringSerializer{format: "raw-unregistered", calls: calls}))
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+
+	if _, err = namedMap.Put(ctx, 1, "one"); err != nil {
+		t.Fatalf("unable to put: %v", err)
+	}
+	value, err := namedMap.Get(ctx, 1)
+	if err != nil || value == nil || *value != "one" {
+		t.Fatalf("expected one, got %v, %v", value, err)
+	}
+	if calls.Load() < 2 {
+		t.Fatalf("expected the custom serializer to be used, got %d calls", calls.Load())
+	}
+
+	var results = make(map[int]string)
+	for e := range namedMap.GetAll(ctx, []int{1}) {
+		if e.Err != nil {
+			t.Fatalf("unable to get all: %v", e.Err)
+		}
+		results[e.Key] = e.Value
+	}
+	if !reflect.DeepEqual(results, map[int]string{1: "one"}) {
+		t.Fatalf("expected the entry to be returned, got %v", results)
+	}
+
+	// a registered format other than the session format cannot be used with a gRPC v1 proxy
+	if err = RegisterSerializer(rawAnySerializer{}); err != nil {
+		t.Fatalf("unable to register serializer: %v", err)
+	}
+	if _, err = GetNamedMap[int, string](session, "custom-values-registered",
+		WithValueSerializer[string](rawStringSerializer{format: rawFormat, calls: calls})); !errors.Is(err, ErrInvalidSerializer) {
+		t.Fatalf("expected ErrInvalidSerializer for a registered format, got %v", err)
+	}
+
+	// a serializer of the wrong type is rejected
+	if _, err = GetNamedMap[int, int](session, "custom-values-invalid",
+		WithValueSerializer[string](rawStringSerializer{format: rawFormat, calls: calls})); !errors.Is(err, ErrInvalidSerializer) {
+		t.Fatalf("expected ErrInvalidSerializer, got %v", err)
+	}
+}
@@ -87,7 +87,7 @@ func TestGet(ctx context.Context, session *Session, cache string, key []byte) (*
 
 // TestGetAll is only exported for integration tests, not for general use.
 func TestGetAll(ctx context.Context, session *Session, cache string, keys [][]byte) (<-chan BinaryKeyAndValue, error) {
-	return session.v1StreamManagerCache.getAll(ctx, cache, keys)
+	return session.v1StreamManagerCache.getAll(ctx, cache, keys, &pooledBuffer{})
 }
 
 // TestKeyAndValuePage is only exported for integration tests, not for general use.
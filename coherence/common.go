@@ -10,7 +10,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/oracle/coherence-go-client/v2/coherence/aggregators"
 	"github.com/oracle/coherence-go-client/v2/coherence/extractors"
 	"github.com/oracle/coherence-go-client/v2/coherence/filters"
 	"github.com/oracle/coherence-go-client/v2/coherence/processors"
@@ -50,6 +49,9 @@ const (
 	// envResolverDebug enables randomization of addresses returned by resolver
 	envResolverRandomize = "COHERENCE_RESOLVER_RANDOMIZE"
 
+	// envCaptureFile is the file to record gRPC v1 requests and responses to.
+	envCaptureFile = "COHERENCE_CAPTURE_FILE"
+
 	// the Coherence log level: 1 -> 5 (ERROR -> ALL)
 	envLogLevel = "COHERENCE_LOG_LEVEL"
 
@@ -73,6 +75,9 @@ var (
 
 	// ErrShutdown indicates the gRPC channel has been shutdown.
 	ErrShutdown = errors.New("gRPC channel has been shutdown")
+
+	// ErrShuttingDown indicates that the session is shutting down and is not accepting new requests.
+	ErrShuttingDown = errors.New("the session is shutting down and is not accepting new requests")
 )
 
 // InvalidationStrategyType described the type if invalidation strategies for near cache.
@@ -101,12 +106,37 @@ type baseClient[K comparable, V any] struct {
 	filterListenersV1    map[filters.Filter]*listenerGroupV1[K, V]
 	filterIDToGroupV1    map[int64]*listenerGroupV1[K, V]
 	lifecycleListenersV1 []*MapLifecycleListener[K, V]
+
+	limiter *inFlightLimiter // set if the cache overrides the session in-flight limit
+	breaker *circuitBreaker  // set if the cache overrides the session circuit breaker
 }
 
 // CacheOptions holds various cache options.
 type CacheOptions struct {
 	DefaultExpiry    time.Duration
 	NearCacheOptions *NearCacheOptions
+
+	// MaxInFlight is the maximum number of concurrent requests for the cache, set using [WithCacheMaxInFlight].
+	MaxInFlight int
+
+	// MaxInFlightPolicy is the policy applied to requests once MaxInFlight has been reached.
+	MaxInFlightPolicy MaxInFlightPolicy
+
+	// CircuitBreakerPolicy enables a circuit breaker for the cache, set using [WithCacheCircuitBreaker].
+	CircuitBreakerPolicy *CircuitBreakerPolicy
+
+	// RetryPolicy controls how idempotent read operations are retried, set using [WithCacheRetryPolicy].
+	RetryPolicy *RetryPolicy
+
+	// ValueCompression enables compression of values, set using [WithValueCompression].
+	ValueCompression *ValueCompression
+
+	// ValueEncryption enables encryption of values, set using [WithValueEncryption] or [WithFieldEncryption].
+	ValueEncryption *ValueEncryption
+
+	// keySerializer and valueSerializer are set using [WithKeySerializer] and [WithValueSerializer].
+	keySerializer   any
+	valueSerializer any
 }
 
 // NearCacheOptions defines options when creating a near cache.
@@ -147,13 +177,93 @@ func WithNearCache(options *NearCacheOptions) func(cacheOptions *CacheOptions) {
 	}
 }
 
+// mapFunctions is implemented by the clients for a [NamedMap] or [NamedCache] to run the operations which are exposed
+// as functions rather than methods, as they have type parameters of their own. The results of entry processors and
+// aggregators are returned serialized, and are deserialized by the functions into their result type.
+type mapFunctions[K comparable, V any] interface {
+	invokeKey(ctx context.Context, key K, proc processors.Processor) ([]byte, error)
+	invokeAll(ctx context.Context, keys []K, filter filters.Filter, proc processors.Processor, results entryResults[K])
+	aggregate(ctx context.Context, keys []K, filter filters.Filter, aggr any) ([]byte, error)
+	entrySet(ctx context.Context, filter filters.Filter, comparator any) <-chan *StreamedEntry[K, V]
+	addIndex(ctx context.Context, extractor any, sorted bool, comparator any) error
+	removeIndex(ctx context.Context, extractor any) error
+}
+
+// entryResults receives the serialized results of an entry processor, which are sent, deserialized into the
+// result type of the function which invoked the entry processor, on the channel returned by the function.
+type entryResults[K comparable] interface {
+	// send sends the result for a key, or the error, returning the error sent, if any, after which
+	// no more results are sent.
+	send(ctx context.Context, key *K, value []byte, err error) error
+
+	// close closes the channel once all the results have been sent.
+	close()
+}
+
+// streamedResults is the [entryResults] for a channel of [StreamedEntry] with results of type R.
+type streamedResults[K comparable, R any] struct {
+	ch         chan *StreamedEntry[K, R]
+	serializer Serializer[R]
+}
+
+func newStreamedResults[K comparable, R any](format string) *streamedResults[K, R] {
+	return &streamedResults[K, R]{ch: make(chan *StreamedEntry[K, R]), serializer: NewSerializer[R](format)}
+}
+
+func (r *streamedResults[K, R]) send(ctx context.Context, key *K, value []byte, err error) error {
+	var result *R
+	if err == nil {
+		result, err = r.serializer.Deserialize(value)
+	}
+	if err != nil {
+		sendStreamed(ctx, r.ch, &StreamedEntry[K, R]{Err: err})
+		return err
+	}
+
+	sendStreamed(ctx, r.ch, makeStreamedEntry(key, result, nil))
+	return nil
+}
+
+func (r *streamedResults[K, R]) close() {
+	close(r.ch)
+}
+
+func (bc *baseClient[K, V]) invokeKey(ctx context.Context, key K, proc processors.Processor) ([]byte, error) {
+	return executeInvoke(ctx, bc, key, proc)
+}
+
+func (bc *baseClient[K, V]) invokeAll(ctx context.Context, keys []K, filter filters.Filter, proc processors.Processor, results entryResults[K]) {
+	executeInvokeAllFilterOrKeys(ctx, bc, filter, keys, proc, results)
+}
+
+func (bc *baseClient[K, V]) aggregate(ctx context.Context, keys []K, filter filters.Filter, aggr any) ([]byte, error) {
+	return retryRead(ctx, bc, func() ([]byte, error) {
+		return executeAggregate(ctx, bc, keys, filter, aggr)
+	})
+}
+
+func (bc *baseClient[K, V]) entrySet(ctx context.Context, filter filters.Filter, comparator any) <-chan *StreamedEntry[K, V] {
+	return retryStream(ctx, bc, func() <-chan *StreamedEntry[K, V] {
+		return executeEntrySetFilter(ctx, bc, filter, comparator)
+	})
+}
+
+func (bc *baseClient[K, V]) addIndex(ctx context.Context, extractor any, sorted bool, comparator any) error {
+	return executeAddIndex(ctx, bc, extractor, sorted, comparator)
+}
+
+func (bc *baseClient[K, V]) removeIndex(ctx context.Context, extractor any) error {
+	return executeRemoveIndex(ctx, bc, extractor)
+}
+
 // executeClear executes the clear operation against a baseClient.
-func executeClear[K comparable, V any](ctx context.Context, bc *baseClient[K, V]) error {
-	var (
-		err       = bc.ensureClientConnection()
-		nearCache = bc.nearCache
-	)
+func executeClear[K comparable, V any](ctx context.Context, bc *baseClient[K, V]) (err error) {
+	ctx, op := bc.startOperation(ctx, "Clear")
+	defer func() { op.end(err) }()
 
+	var nearCache = bc.nearCache
+
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return err
 	}
@@ -164,7 +274,7 @@ func executeClear[K comparable, V any](ctx context.Context, bc *baseClient[K, V]
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		err = bc.session.v1StreamManagerCache.clearCache(newCtx, bc.name)
+		err = bc.session.cacheStream(bc.name).clearCache(newCtx, bc.name)
 	} else {
 		clearRequest := pb.ClearRequest{Cache: bc.name, Scope: bc.sessionOpts.Scope}
 
@@ -196,14 +306,17 @@ func unregisterLifecycleListener[K comparable, V any](baseClient *baseClient[K,
 }
 
 // executeAddIndex executes the add index operation against a baseClient.
-func executeAddIndex[K comparable, V, T, E any](ctx context.Context, bc *baseClient[K, V], extractor extractors.ValueExtractor[T, E], sorted bool, comparator extractors.Comparator[E]) error {
+func executeAddIndex[K comparable, V any](ctx context.Context, bc *baseClient[K, V], extractor any, sorted bool, comparator any) (err error) {
+	ctx, op := bc.startOperation(ctx, "AddIndex")
+	defer func() { op.end(err) }()
+
 	var (
 		extractorSerializer = NewSerializer[any](bc.format)
 		binExtractor        []byte
 		binComparator       []byte
-		err                 = bc.ensureClientConnection()
 	)
 
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return err
 	}
@@ -224,7 +337,7 @@ func executeAddIndex[K comparable, V, T, E any](ctx context.Context, bc *baseCli
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		return bc.session.v1StreamManagerCache.addIndex(newCtx, bc.name, binExtractor, &sorted, binComparator)
+		return bc.session.cacheStream(bc.name).addIndex(newCtx, bc.name, binExtractor, &sorted, binComparator)
 	}
 
 	addIndexRequest := pb.AddIndexRequest{
@@ -234,13 +347,16 @@ func executeAddIndex[K comparable, V, T, E any](ctx context.Context, bc *baseCli
 }
 
 // executeRemoveIndex executes the remove index operation against a baseClient.
-func executeRemoveIndex[K comparable, V, T, E any](ctx context.Context, bc *baseClient[K, V], extractor extractors.ValueExtractor[T, E]) error {
+func executeRemoveIndex[K comparable, V any](ctx context.Context, bc *baseClient[K, V], extractor any) (err error) {
+	ctx, op := bc.startOperation(ctx, "RemoveIndex")
+	defer func() { op.end(err) }()
+
 	var (
 		extractorSerializer = NewSerializer[any](bc.format)
 		binExtractor        []byte
-		err                 = bc.ensureClientConnection()
 	)
 
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return err
 	}
@@ -256,7 +372,7 @@ func executeRemoveIndex[K comparable, V, T, E any](ctx context.Context, bc *base
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		return bc.session.v1StreamManagerCache.removeIndex(newCtx, bc.name, binExtractor)
+		return bc.session.cacheStream(bc.name).removeIndex(newCtx, bc.name, binExtractor)
 	}
 
 	removeIndexRequest := pb.RemoveIndexRequest{
@@ -267,11 +383,13 @@ func executeRemoveIndex[K comparable, V, T, E any](ctx context.Context, bc *base
 }
 
 // executeTruncate executes the truncate operation against a baseClient.
-func executeTruncate[K comparable, V any](ctx context.Context, bc *baseClient[K, V]) error {
-	var (
-		err       = bc.ensureClientConnection()
-		nearCache = bc.nearCache
-	)
+func executeTruncate[K comparable, V any](ctx context.Context, bc *baseClient[K, V]) (err error) {
+	ctx, op := bc.startOperation(ctx, "Truncate")
+	defer func() { op.end(err) }()
+
+	var nearCache = bc.nearCache
+
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return err
 	}
@@ -282,7 +400,7 @@ func executeTruncate[K comparable, V any](ctx context.Context, bc *baseClient[K,
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		err = bc.session.v1StreamManagerCache.truncateCache(newCtx, bc.name)
+		err = bc.session.cacheListenerStream(bc.name).truncateCache(newCtx, bc.name)
 		if err != nil {
 			return err
 		}
@@ -301,8 +419,11 @@ func executeTruncate[K comparable, V any](ctx context.Context, bc *baseClient[K,
 }
 
 // executeDestroy executes the destroy operation against a baseClient.
-func executeDestroy[K comparable, V any](ctx context.Context, bc *baseClient[K, V], nm NamedMap[K, V]) error {
-	err := bc.ensureClientConnection()
+func executeDestroy[K comparable, V any](ctx context.Context, bc *baseClient[K, V], nm NamedMap[K, V]) (err error) {
+	ctx, op := bc.startOperation(ctx, "Destroy")
+	defer func() { op.end(err) }()
+
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return err
 	}
@@ -313,7 +434,7 @@ func executeDestroy[K comparable, V any](ctx context.Context, bc *baseClient[K,
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		err = bc.session.v1StreamManagerCache.destroyCache(newCtx, bc.name)
+		err = bc.session.cacheListenerStream(bc.name).destroyCache(newCtx, bc.name)
 		if err != nil {
 			return err
 		}
@@ -356,14 +477,17 @@ func executeRelease[K comparable, V any](bc *baseClient[K, V], nm NamedMap[K, V]
 }
 
 // executeContainsKey executes the containsKey operation against a baseClient.
-func executeContainsKey[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K) (bool, error) {
+func executeContainsKey[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K) (_ bool, err error) {
+	ctx, op := bc.startOperation(ctx, "ContainsKey")
+	defer func() { op.end(err) }()
+
 	var (
 		result    *wrapperspb.BoolValue
-		err       = bc.ensureClientConnection()
 		binKey    []byte
 		nearCache = bc.nearCache
 	)
 
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return false, err
 	}
@@ -388,7 +512,7 @@ func executeContainsKey[K comparable, V any](ctx context.Context, bc *baseClient
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		return bc.session.v1StreamManagerCache.containsKey(newCtx, bc.name, binKey)
+		return bc.session.cacheStream(bc.name).containsKey(newCtx, bc.name, binKey)
 	}
 
 	containsKeyRequest := pb.ContainsKeyRequest{Cache: bc.name, Key: binKey, Format: bc.format, Scope: bc.sessionOpts.Scope}
@@ -402,12 +526,20 @@ func executeContainsKey[K comparable, V any](ctx context.Context, bc *baseClient
 }
 
 // executeContainsKey executes the containsValue operation against a baseClient.
-func executeContainsValue[K comparable, V any](ctx context.Context, bc *baseClient[K, V], value V) (bool, error) {
+func executeContainsValue[K comparable, V any](ctx context.Context, bc *baseClient[K, V], value V) (_ bool, err error) {
+	ctx, op := bc.startOperation(ctx, "ContainsValue")
+	defer func() { op.end(err) }()
+
+	if err = bc.checkValueComparison(); err != nil {
+		return false, err
+	}
+
 	var (
 		result   *wrapperspb.BoolValue
-		err      = bc.ensureClientConnection()
 		binValue []byte
 	)
+
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return false, err
 	}
@@ -423,7 +555,7 @@ func executeContainsValue[K comparable, V any](ctx context.Context, bc *baseClie
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		return bc.session.v1StreamManagerCache.containsValue(newCtx, bc.name, binValue)
+		return bc.session.cacheStream(bc.name).containsValue(newCtx, bc.name, binValue)
 	}
 	containsValueRequest := pb.ContainsValueRequest{Cache: bc.name, Value: binValue, Format: bc.format, Scope: bc.sessionOpts.Scope}
 
@@ -436,13 +568,21 @@ func executeContainsValue[K comparable, V any](ctx context.Context, bc *baseClie
 }
 
 // executeContainsEntry executes the containsEntry operation against a baseClient.
-func executeContainsEntry[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K, value V) (bool, error) {
+func executeContainsEntry[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K, value V) (_ bool, err error) {
+	ctx, op := bc.startOperation(ctx, "ContainsEntry")
+	defer func() { op.end(err) }()
+
+	if err = bc.checkValueComparison(); err != nil {
+		return false, err
+	}
+
 	var (
 		result   *wrapperspb.BoolValue
-		err      = bc.ensureClientConnection()
 		binKey   []byte
 		binValue []byte
 	)
+
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return false, err
 	}
@@ -463,7 +603,7 @@ func executeContainsEntry[K comparable, V any](ctx context.Context, bc *baseClie
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		return bc.session.v1StreamManagerCache.containsEntry(newCtx, bc.name, binKey, binValue)
+		return bc.session.cacheStream(bc.name).containsEntry(newCtx, bc.name, binKey, binValue)
 	}
 
 	containsEntryRequest := pb.ContainsEntryRequest{Cache: bc.name, Key: binKey, Value: binValue, Format: bc.format, Scope: bc.sessionOpts.Scope}
@@ -476,12 +616,16 @@ func executeContainsEntry[K comparable, V any](ctx context.Context, bc *baseClie
 }
 
 // executeIsEmpty executes the IsEmpty operation against a baseClient.
-func executeIsEmpty[K comparable, V any](ctx context.Context, bc *baseClient[K, V]) (bool, error) {
+func executeIsEmpty[K comparable, V any](ctx context.Context, bc *baseClient[K, V]) (_ bool, err error) {
+	ctx, op := bc.startOperation(ctx, "IsEmpty")
+	defer func() { op.end(err) }()
+
 	var (
 		result  *wrapperspb.BoolValue
-		err     = bc.ensureClientConnection()
 		isEmpty bool
 	)
+
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return false, err
 	}
@@ -492,7 +636,7 @@ func executeIsEmpty[K comparable, V any](ctx context.Context, bc *baseClient[K,
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		isEmpty, err = bc.session.v1StreamManagerCache.isEmpty(newCtx, bc.name)
+		isEmpty, err = bc.session.cacheStream(bc.name).isEmpty(newCtx, bc.name)
 		if err != nil {
 			return false, err
 		}
@@ -510,16 +654,19 @@ func executeIsEmpty[K comparable, V any](ctx context.Context, bc *baseClient[K,
 }
 
 // executeGet executes the Get operation against a baseClient.
-func executeGet[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K) (*V, error) {
+func executeGet[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K) (_ *V, err error) {
+	ctx, op := bc.startOperation(ctx, "Get")
+	defer func() { op.end(err) }()
+
 	var (
 		result      *pb.OptionalValue
 		resultBytes *[]byte
 		binKey      []byte
-		err         = bc.ensureClientConnection()
 		zeroValue   *V
 		nearCache   = bc.nearCache
 	)
 
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return zeroValue, err
 	}
@@ -538,7 +685,11 @@ func executeGet[K comparable, V any](ctx context.Context, bc *baseClient[K, V],
 		}
 	}
 
-	binKey, err = bc.keySerializer.Serialize(key)
+	// the key is only needed until the request is complete, so it is serialized into a pooled buffer
+	buffer := getBuffer()
+	defer buffer.release()
+
+	binKey, err = serializeTo(buffer, bc.keySerializer, key)
 
 	if err != nil {
 		return zeroValue, err
@@ -555,7 +706,7 @@ func executeGet[K comparable, V any](ctx context.Context, bc *baseClient[K, V],
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		resultBytes, err = bc.session.v1StreamManagerCache.get(newCtx, bc.name, binKey)
+		resultBytes, err = bc.session.cacheStream(bc.name).get(newCtx, bc.name, binKey)
 		if err != nil {
 			return zeroValue, err
 		}
@@ -588,9 +739,11 @@ func executeGet[K comparable, V any](ctx context.Context, bc *baseClient[K, V],
 
 // executeGet executes the GetAll operation against a baseClient.
 func executeGetAll[K comparable, V any](ctx context.Context, bc *baseClient[K, V], keys []K) <-chan *StreamedEntry[K, V] {
+	ctx, op := bc.startOperation(ctx, "GetAll")
+
 	var (
 		err              = bc.ensureClientConnection()
-		binKeys          = make([][]byte, 0)
+		binKeys          [][]byte
 		ch               = make(chan *StreamedEntry[K, V])
 		nearCache        = bc.nearCache
 		nearCacheEntries = make(map[K]*V) // entries found in near cache
@@ -598,8 +751,7 @@ func executeGetAll[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 	)
 
 	if err != nil {
-		ch <- &StreamedEntry[K, V]{Err: err}
-		return ch
+		return failedStream(op, &StreamedEntry[K, V]{Err: err})
 	}
 
 	newCtx, cancel := bc.session.ensureContext(ctx)
@@ -630,21 +782,25 @@ func executeGetAll[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 		}
 	}
 
-	// serialize the array of keys
-	binKeys, err = serializeKeys[K](bc.keySerializer, finalKeys)
+	// serialize the array of keys into a pooled buffer, which is released once all the entries have been received
+	buffer := getBuffer()
+	binKeys, err = serializeKeysTo[K](buffer, bc.keySerializer, finalKeys)
 	if err != nil {
-		ch <- &StreamedEntry[K, V]{Err: err}
-		return ch
+		buffer.release()
+		return failedStream(op, &StreamedEntry[K, V]{Err: err})
 	}
 
 	go func() {
+		defer op.endStream(ctx)()
+
 		defer func() {
 			// catch panic of closed channel read in rare circumstances
 			if r := recover(); r != nil {
-				logMessage(WARNING, panicWarning, r)
+				bc.session.log(WARNING, panicWarning, r)
 				return
 			}
 		}()
+		defer buffer.release()
 
 		if cancel != nil {
 			defer cancel()
@@ -652,7 +808,7 @@ func executeGetAll[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 
 		// if we have any entries in the near cache entries then stream these first
 		for k, v := range nearCacheEntries {
-			ch <- &StreamedEntry[K, V]{Key: k, Value: *v}
+			sendStreamed(ctx, ch, &StreamedEntry[K, V]{Key: k, Value: *v})
 		}
 
 		// if we can get all keys from near cache then return immediately
@@ -662,7 +818,7 @@ func executeGetAll[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 		}
 
 		if bc.session.GetProtocolVersion() > 0 {
-			executeGetAllV1(ctx, bc, binKeys, ch)
+			executeGetAllV1(ctx, bc, binKeys, buffer, ch)
 			close(ch)
 			return
 		}
@@ -676,7 +832,7 @@ func executeGetAll[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 
 		getAllClient, err1 := bc.client.GetAll(newCtx, &request)
 		if err1 != nil {
-			ch <- &StreamedEntry[K, V]{Err: err1}
+			sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: err1})
 			close(ch)
 			return
 		}
@@ -690,19 +846,19 @@ func executeGetAll[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 				close(ch)
 				return
 			} else if err1 != nil {
-				ch <- &StreamedEntry[K, V]{Err: err1}
+				sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: err1})
 				close(ch)
 				return
 			}
 
 			// deserialize key and value
 			if key, err1 = bc.keySerializer.Deserialize(response.Key); err1 != nil {
-				ch <- &StreamedEntry[K, V]{Err: err1}
+				sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: err1})
 				close(ch)
 				return
 			}
 			if value, err1 = bc.valueSerializer.Deserialize(response.Value); err1 != nil {
-				ch <- &StreamedEntry[K, V]{Err: err1}
+				sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: err1})
 				close(ch)
 				return
 			}
@@ -712,19 +868,19 @@ func executeGetAll[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 				nearCache.Put(*key, *value)
 			}
 
-			ch <- makeStreamedEntry[K, V](key, value, nil)
+			sendStreamed(ctx, ch, makeStreamedEntry[K, V](key, value, nil))
 		}
 	}()
 
 	return ch
 }
 
-// executeGetAllV1 executes a getAll() when connected to v1 gRPC proxy.
-func executeGetAllV1[K comparable, V any](ctx context.Context, bc *baseClient[K, V], binKeys [][]byte, ch chan *StreamedEntry[K, V]) {
+// executeGetAllV1 executes a getAll() when connected to v1 gRPC proxy, marshaling the request into the buffer.
+func executeGetAllV1[K comparable, V any](ctx context.Context, bc *baseClient[K, V], binKeys [][]byte, buffer *pooledBuffer, ch chan *StreamedEntry[K, V]) {
 	nearCache := bc.nearCache
-	chGetAll, err := bc.session.v1StreamManagerCache.getAll(ctx, bc.name, binKeys)
+	chGetAll, err := bc.session.cacheStream(bc.name).getAll(ctx, bc.name, binKeys, buffer)
 	if err != nil {
-		ch <- &StreamedEntry[K, V]{Err: err}
+		sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: err})
 		close(ch)
 	}
 
@@ -735,19 +891,19 @@ func executeGetAllV1[K comparable, V any](ctx context.Context, bc *baseClient[K,
 
 	for v := range chGetAll {
 		if v.Err != nil {
-			ch <- &StreamedEntry[K, V]{Err: v.Err}
+			sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: v.Err})
 			close(ch)
 			return
 		}
 
 		// deserialize key and value
 		if key, err = bc.keySerializer.Deserialize(v.Key); err != nil {
-			ch <- &StreamedEntry[K, V]{Err: err}
+			sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: err})
 			close(ch)
 			return
 		}
 		if value, err = bc.valueSerializer.Deserialize(v.Value); err != nil {
-			ch <- &StreamedEntry[K, V]{Err: err}
+			sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: err})
 			close(ch)
 			return
 		}
@@ -757,73 +913,69 @@ func executeGetAllV1[K comparable, V any](ctx context.Context, bc *baseClient[K,
 			nearCache.Put(*key, *value)
 		}
 
-		ch <- makeStreamedEntry[K, V](key, value, nil)
+		sendStreamed(ctx, ch, makeStreamedEntry[K, V](key, value, nil))
 	}
 }
 
 // executeInvokeAllFilterOrKeysV1 executes an invokeAll() when connected to v1 gRPC proxy.
-func executeInvokeAllFilterOrKeysV1[K comparable, V any, R any](ctx context.Context, bc *baseClient[K, V], agent []byte, binKeys [][]byte, binFilter []byte, ch chan *StreamedEntry[K, R]) {
+func executeInvokeAllFilterOrKeysV1[K comparable, V any](ctx context.Context, bc *baseClient[K, V], agent []byte, binKeys [][]byte, binFilter []byte, results entryResults[K]) {
 	keysOrFilter := ensureKeysOrFilterGrpcV1(binKeys, binFilter)
-	chInvoke, err := bc.session.v1StreamManagerCache.invoke(ctx, bc.name, agent, keysOrFilter)
-
+	chInvoke, err := bc.session.cacheStream(bc.name).invoke(ctx, bc.name, agent, keysOrFilter)
 	if err != nil {
-		ch <- &StreamedEntry[K, R]{Err: err}
-		close(ch)
+		_ = results.send(ctx, nil, nil, err)
+		return
 	}
 
-	getStreamedEntry[K, V, R](bc, ch, chInvoke)
-}
-
-// executeInvokeAllFilterOrKeysV1 executes an invokeAll() when connected to v1 gRPC proxy.
-func executeInvokeAllFilterOrKeysV1Value[K comparable, V any, R any](ctx context.Context, bc *baseClient[K, V], agent []byte, binKeys [][]byte, binFilter []byte, ch chan *StreamedValue[R]) {
-	keysOrFilter := ensureKeysOrFilterGrpcV1(binKeys, binFilter)
-	chInvoke, err := bc.session.v1StreamManagerCache.invoke(ctx, bc.name, agent, keysOrFilter)
+	for v := range chInvoke {
+		if v.Err != nil {
+			_ = results.send(ctx, nil, nil, v.Err)
+			return
+		}
 
-	if err != nil {
-		ch <- &StreamedValue[R]{Err: err}
-		close(ch)
+		key, err1 := bc.keySerializer.Deserialize(v.Key)
+		if results.send(ctx, key, v.Value, err1) != nil {
+			return
+		}
 	}
-
-	getStreamedValuesWithEntry[K, V, R](bc, ch, chInvoke)
 }
 
 // executeInvokeAllFilterOrKeysV1 executes an invokeAll() when connected to v1 gRPC proxy.
 func executeInvokeEntrySetFilterV1[K comparable, V any](ctx context.Context, bc *baseClient[K, V], binFilter []byte, binComparator []byte, ch chan *StreamedEntry[K, V]) {
-	chInvoke, err := bc.session.v1StreamManagerCache.entrySetFilter(ctx, bc.name, binFilter, binComparator)
+	chInvoke, err := bc.session.cacheStream(bc.name).entrySetFilter(ctx, bc.name, binFilter, binComparator)
 
 	if err != nil {
-		ch <- &StreamedEntry[K, V]{Err: err}
+		sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: err})
 		close(ch)
 	}
 
-	getStreamedEntries[K, V](bc, ch, chInvoke)
+	getStreamedEntries[K, V](ctx, bc, ch, chInvoke)
 }
 
 // executeKeySetFilterV1 executes an keySet with filter when connected to v1 gRPC proxy.
 func executeKeySetFilterV1[K comparable, V any](ctx context.Context, bc *baseClient[K, V], binFilter []byte, ch chan *StreamedKey[K]) {
-	chInvoke, err := bc.session.v1StreamManagerCache.keySetFilter(ctx, bc.name, binFilter)
+	chInvoke, err := bc.session.cacheStream(bc.name).keySetFilter(ctx, bc.name, binFilter)
 
 	if err != nil {
-		ch <- &StreamedKey[K]{Err: err}
+		sendStreamed(ctx, ch, &StreamedKey[K]{Err: err})
 		close(ch)
 	}
 
-	getStreamedKeys[K, V](bc, ch, chInvoke)
+	getStreamedKeys[K, V](ctx, bc, ch, chInvoke)
 }
 
 // executeValuesFilterV1 executes an values with filter when connected to v1 gRPC proxy.
 func executeValuesFilterV1[K comparable, V any](ctx context.Context, bc *baseClient[K, V], binFilter []byte, binComparator []byte, ch chan *StreamedValue[V]) {
-	chInvoke, err := bc.session.v1StreamManagerCache.valuesFilter(ctx, bc.name, binFilter, binComparator)
+	chInvoke, err := bc.session.cacheStream(bc.name).valuesFilter(ctx, bc.name, binFilter, binComparator)
 
 	if err != nil {
-		ch <- &StreamedValue[V]{Err: err}
+		sendStreamed(ctx, ch, &StreamedValue[V]{Err: err})
 		close(ch)
 	}
 
-	getStreamedValuesWithValue[K, V, V](bc, ch, chInvoke)
+	getStreamedValuesWithValue[K, V, V](ctx, bc, ch, chInvoke)
 }
 
-func getStreamedEntries[K comparable, V any](bc *baseClient[K, V], chResponse chan *StreamedEntry[K, V], ch <-chan BinaryKeyAndValue) {
+func getStreamedEntries[K comparable, V any](ctx context.Context, bc *baseClient[K, V], chResponse chan *StreamedEntry[K, V], ch <-chan BinaryKeyAndValue) {
 	var (
 		key   *K
 		value *V
@@ -832,28 +984,28 @@ func getStreamedEntries[K comparable, V any](bc *baseClient[K, V], chResponse ch
 
 	for v := range ch {
 		if v.Err != nil {
-			chResponse <- &StreamedEntry[K, V]{Err: v.Err}
+			sendStreamed(ctx, chResponse, &StreamedEntry[K, V]{Err: v.Err})
 			close(chResponse)
 			return
 		}
 
 		// deserialize key and value
 		if key, err = bc.keySerializer.Deserialize(v.Key); err != nil {
-			chResponse <- &StreamedEntry[K, V]{Err: err}
+			sendStreamed(ctx, chResponse, &StreamedEntry[K, V]{Err: err})
 			close(chResponse)
 			return
 		}
 		if value, err = bc.valueSerializer.Deserialize(v.Value); err != nil {
-			chResponse <- &StreamedEntry[K, V]{Err: err}
+			sendStreamed(ctx, chResponse, &StreamedEntry[K, V]{Err: err})
 			close(chResponse)
 			return
 		}
 
-		chResponse <- makeStreamedEntry[K, V](key, value, nil)
+		sendStreamed(ctx, chResponse, makeStreamedEntry[K, V](key, value, nil))
 	}
 }
 
-func getStreamedKeys[K comparable, V any](bc *baseClient[K, V], chResponse chan *StreamedKey[K], ch <-chan BinaryKey) {
+func getStreamedKeys[K comparable, V any](ctx context.Context, bc *baseClient[K, V], chResponse chan *StreamedKey[K], ch <-chan BinaryKey) {
 	var (
 		key *K
 		err error
@@ -861,49 +1013,18 @@ func getStreamedKeys[K comparable, V any](bc *baseClient[K, V], chResponse chan
 
 	for v := range ch {
 		if v.Err != nil {
-			chResponse <- &StreamedKey[K]{Err: v.Err}
+			sendStreamed(ctx, chResponse, &StreamedKey[K]{Err: v.Err})
 			close(chResponse)
 			return
 		}
 		// deserialize key
 		if key, err = bc.keySerializer.Deserialize(v.Key); err != nil {
-			chResponse <- &StreamedKey[K]{Err: err}
-			close(chResponse)
-			return
-		}
-
-		chResponse <- &StreamedKey[K]{Key: *key}
-	}
-}
-
-// getStreamedValues returns the binary key and value for an entry processor, but will just return the key
-func getStreamedEntry[K comparable, V any, R any](bc *baseClient[K, V], chResponse chan *StreamedEntry[K, R], ch <-chan BinaryKeyAndValue) {
-	var (
-		key   *K
-		value *R
-		err   error
-	)
-
-	for v := range ch {
-		if v.Err != nil {
-			chResponse <- &StreamedEntry[K, R]{Err: v.Err}
-			return
-		}
-
-		// deserialize key and value
-		resultSerializer := NewSerializer[R](bc.format)
-		if value, err = resultSerializer.Deserialize(v.Value); err != nil {
-			chResponse <- &StreamedEntry[K, R]{Err: err}
-			return
-		}
-
-		if key, err = bc.keySerializer.Deserialize(v.Key); err != nil {
-			chResponse <- &StreamedEntry[K, R]{Err: err}
+			sendStreamed(ctx, chResponse, &StreamedKey[K]{Err: err})
 			close(chResponse)
 			return
 		}
 
-		chResponse <- makeStreamedEntry[K, R](key, value, err)
+		sendStreamed(ctx, chResponse, &StreamedKey[K]{Key: *key})
 	}
 }
 
@@ -918,36 +1039,7 @@ func makeStreamedEntry[K comparable, V any](key *K, value *V, err error) *Stream
 	return &streamedEntry
 }
 
-func getStreamedValuesWithEntry[K comparable, V any, R any](bc *baseClient[K, V], chResponse chan *StreamedValue[R], ch <-chan BinaryKeyAndValue) {
-	var (
-		value *R
-		err   error
-	)
-
-	for v := range ch {
-		if v.Err != nil {
-			chResponse <- &StreamedValue[R]{Err: v.Err}
-			close(chResponse)
-			return
-		}
-
-		// deserialize the result only
-		resultSerializer := NewSerializer[R](bc.format)
-		if value, err = resultSerializer.Deserialize(v.Value); err != nil {
-			chResponse <- &StreamedValue[R]{Err: err}
-			close(chResponse)
-			return
-		}
-
-		if value == nil {
-			chResponse <- &StreamedValue[R]{IsValueEmpty: true}
-		} else {
-			chResponse <- &StreamedValue[R]{Value: *value}
-		}
-	}
-}
-
-func getStreamedValuesWithValue[K comparable, V any, R any](bc *baseClient[K, V], chResponse chan *StreamedValue[R], ch <-chan BinaryValue) {
+func getStreamedValuesWithValue[K comparable, V any, R any](ctx context.Context, bc *baseClient[K, V], chResponse chan *StreamedValue[R], ch <-chan BinaryValue) {
 	var (
 		value *R
 		err   error
@@ -955,7 +1047,7 @@ func getStreamedValuesWithValue[K comparable, V any, R any](bc *baseClient[K, V]
 
 	for v := range ch {
 		if v.Err != nil {
-			chResponse <- &StreamedValue[R]{Err: v.Err}
+			sendStreamed(ctx, chResponse, &StreamedValue[R]{Err: v.Err})
 			close(chResponse)
 			return
 		}
@@ -963,15 +1055,15 @@ func getStreamedValuesWithValue[K comparable, V any, R any](bc *baseClient[K, V]
 		// deserialize the result only
 		resultSerializer := NewSerializer[R](bc.format)
 		if value, err = resultSerializer.Deserialize(v.Value); err != nil {
-			chResponse <- &StreamedValue[R]{Err: err}
+			sendStreamed(ctx, chResponse, &StreamedValue[R]{Err: err})
 			close(chResponse)
 			return
 		}
 
 		if value == nil {
-			chResponse <- &StreamedValue[R]{IsValueEmpty: true}
+			sendStreamed(ctx, chResponse, &StreamedValue[R]{IsValueEmpty: true})
 		} else {
-			chResponse <- &StreamedValue[R]{Value: *value}
+			sendStreamed(ctx, chResponse, &StreamedValue[R]{Value: *value})
 		}
 	}
 }
@@ -996,19 +1088,22 @@ func executeGetOrDefault[K comparable, V any](ctx context.Context, bc *baseClien
 	return finalResult, nil
 }
 
-func executeAggregate[K comparable, V, R any](ctx context.Context, bc *baseClient[K, V], keys []K, filter filters.Filter, aggr aggregators.Aggregator[R]) (*R, error) {
+// executeAggregate executes the Aggregate operation against a baseClient, returning the serialized result.
+func executeAggregate[K comparable, V any](ctx context.Context, bc *baseClient[K, V], keys []K, filter filters.Filter, aggr any) (_ []byte, err error) {
+	ctx, op := bc.startOperation(ctx, "Aggregate")
+	defer func() { op.end(err) }()
+
 	var (
-		err              = bc.ensureClientConnection()
-		binKeys          = make([][]byte, 0)
-		binFilter        = make([]byte, 0)
-		binAggregator    []byte
-		result           *wrapperspb.BytesValue
-		resultSerializer = NewSerializer[R](bc.format)
-		zeroValue        *R
-		keysLen          = len(keys)
+		binKeys       = make([][]byte, 0)
+		binFilter     = make([]byte, 0)
+		binAggregator []byte
+		result        *wrapperspb.BytesValue
+		keysLen       = len(keys)
 	)
+
+	err = bc.ensureClientConnection()
 	if err != nil {
-		return zeroValue, err
+		return nil, err
 	}
 
 	newCtx, cancel := bc.session.ensureContext(ctx)
@@ -1019,7 +1114,7 @@ func executeAggregate[K comparable, V, R any](ctx context.Context, bc *baseClien
 	aggregatorSerializer := NewSerializer[any](bc.format)
 	binAggregator, err = aggregatorSerializer.Serialize(aggr)
 	if err != nil {
-		return zeroValue, err
+		return nil, err
 	}
 
 	if keys != nil && keysLen > 0 {
@@ -1029,22 +1124,22 @@ func executeAggregate[K comparable, V, R any](ctx context.Context, bc *baseClien
 		for i, key := range keys {
 			binKeys[i], err = bc.keySerializer.Serialize(key)
 			if err != nil {
-				return zeroValue, err
+				return nil, err
 			}
 		}
 	} else if filter != nil {
 		// filter was specified
 		binFilter, err = NewSerializer[any](bc.format).Serialize(filter)
 		if err != nil {
-			return zeroValue, err
+			return nil, err
 		}
 	}
 	// else keys and filter are empty
 
 	if bc.session.GetProtocolVersion() > 0 {
-		res, err1 := bc.session.v1StreamManagerCache.aggregate(newCtx, bc.name, binAggregator, ensureKeysOrFilterGrpcV1(binKeys, binFilter))
+		res, err1 := bc.session.cacheStream(bc.name).aggregate(newCtx, bc.name, binAggregator, ensureKeysOrFilterGrpcV1(binKeys, binFilter))
 		if err1 != nil {
-			return zeroValue, err1
+			return nil, err1
 		}
 
 		result = ensureBytesValue(res)
@@ -1055,11 +1150,11 @@ func executeAggregate[K comparable, V, R any](ctx context.Context, bc *baseClien
 
 		result, err = bc.client.Aggregate(newCtx, &request)
 		if err != nil {
-			return zeroValue, err
+			return nil, err
 		}
 	}
 
-	return resultSerializer.Deserialize(result.Value)
+	return result.Value, nil
 }
 
 func ensureKeysOrFilterGrpcV1(binKeys [][]byte, binFilter []byte) *pb1.KeysOrFilter {
@@ -1088,18 +1183,20 @@ func ensureKeyOrFilterGrpcV1(binKey []byte, binFilter []byte) *pb1.KeyOrFilter {
 	return keyOrFilter
 }
 
-// executeInvoke executes the Invoke operation against a baseClient.
-func executeInvoke[K comparable, V any, R any](ctx context.Context, bc *baseClient[K, V], key K, proc processors.Processor) (*R, error) {
+// executeInvoke executes the Invoke operation against a baseClient, returning the serialized result.
+func executeInvoke[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K, proc processors.Processor) (_ []byte, err error) {
+	ctx, op := bc.startOperation(ctx, "Invoke")
+	defer func() { op.end(err) }()
+
 	var (
-		err              = bc.ensureClientConnection()
-		binKey           []byte
-		binProcessor     []byte
-		result           *wrapperspb.BytesValue
-		resultSerializer = NewSerializer[R](bc.format)
-		zeroValue        *R
+		binKey       []byte
+		binProcessor []byte
+		result       *wrapperspb.BytesValue
 	)
+
+	err = bc.ensureClientConnection()
 	if err != nil {
-		return zeroValue, err
+		return nil, err
 	}
 
 	newCtx, cancel := bc.session.ensureContext(ctx)
@@ -1109,40 +1206,27 @@ func executeInvoke[K comparable, V any, R any](ctx context.Context, bc *baseClie
 
 	binKey, err = bc.keySerializer.Serialize(key)
 	if err != nil {
-		return zeroValue, err
+		return nil, err
 	}
 
 	procSerializer := NewSerializer[any](bc.format)
 	binProcessor, err = procSerializer.Serialize(proc)
 	if err != nil {
-		return zeroValue, err
+		return nil, err
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		binKeys := make([][]byte, 1)
-		binKeys[0] = binKey
-
-		// create a channel from which we will get the first value
-		ch := make(chan *StreamedValue[R])
-
-		go func() {
-			defer close(ch)
-			executeInvokeAllFilterOrKeysV1Value(ctx, bc, binProcessor, binKeys, emptyByte, ch)
-		}()
+		ch, err1 := bc.session.cacheStream(bc.name).invoke(ctx, bc.name, binProcessor, ensureKeysOrFilterGrpcV1([][]byte{binKey}, emptyByte))
+		if err1 != nil {
+			return nil, err1
+		}
 
+		// the result is the first value, and there is no result if the channel has closed
 		v, ok := <-ch
 		if !ok {
-			// channel has closed, no data to return
-			return nil, nil
-		}
-
-		if v.Err != nil {
-			return zeroValue, v.Err
-		}
-		if v.IsValueEmpty {
 			return nil, nil
 		}
-		return &v.Value, nil
+		return v.Value, v.Err
 	}
 
 	// gRPC v0
@@ -1151,119 +1235,91 @@ func executeInvoke[K comparable, V any, R any](ctx context.Context, bc *baseClie
 
 	result, err = bc.client.Invoke(newCtx, &request)
 	if err != nil {
-		return zeroValue, err
+		return nil, err
 	}
 
-	return resultSerializer.Deserialize(result.Value)
+	return result.Value, nil
 }
 
-// executeInvokeAll executes the InvokeAll operation with filter or keys, against a baseClient.
-func executeInvokeAllFilterOrKeys[K comparable, V any, R any](ctx context.Context, bc *baseClient[K, V], fltr filters.Filter, keys []K, proc processors.Processor) <-chan *StreamedEntry[K, R] {
-	var (
-		err          = bc.ensureClientConnection()
-		binFilter    = make([]byte, 0)
-		binProcessor = make([]byte, 0)
-		binKeys      = make([][]byte, 0)
-		ch           = make(chan *StreamedEntry[K, R])
-	)
-
-	if err != nil {
-		ch <- &StreamedEntry[K, R]{Err: err}
-		return ch
-	}
-
-	newCtx, cancel := bc.session.ensureContext(ctx)
-
-	procSerializer := NewSerializer[any](bc.format)
-	if binProcessor, err = procSerializer.Serialize(proc); err != nil {
-		ch <- &StreamedEntry[K, R]{Err: err}
-		return ch
-	}
-
-	if fltr != nil {
-		if binFilter, err = NewSerializer[any](bc.format).Serialize(fltr); err != nil {
-			ch <- &StreamedEntry[K, R]{Err: err}
-			return ch
-		}
-	}
-	if len(keys) > 0 {
-		// serialize the array of keys
-		if binKeys, err = serializeKeys[K](bc.keySerializer, keys); err != nil {
-			ch <- &StreamedEntry[K, R]{Err: err}
-			return ch
-		}
-	}
+// executeInvokeAll executes the InvokeAll operation with filter or keys, against a baseClient, sending the results to results.
+func executeInvokeAllFilterOrKeys[K comparable, V any](ctx context.Context, bc *baseClient[K, V], fltr filters.Filter, keys []K, proc processors.Processor, results entryResults[K]) {
+	ctx, op := bc.startOperation(ctx, "InvokeAll")
 
 	go func() {
+		defer op.endStream(ctx)()
+		defer results.close()
+
 		defer func() {
 			// catch panic of closed channel read in rare circumstances
 			if r := recover(); r != nil {
-				logMessage(WARNING, panicWarning, r)
+				bc.session.log(WARNING, panicWarning, r)
 				return
 			}
 		}()
 
+		var (
+			err          = bc.ensureClientConnection()
+			binFilter    = make([]byte, 0)
+			binProcessor = make([]byte, 0)
+			binKeys      = make([][]byte, 0)
+		)
+
+		if err == nil {
+			binProcessor, err = NewSerializer[any](bc.format).Serialize(proc)
+		}
+		if err == nil && fltr != nil {
+			binFilter, err = NewSerializer[any](bc.format).Serialize(fltr)
+		}
+		if err == nil && len(keys) > 0 {
+			// serialize the array of keys
+			binKeys, err = serializeKeys[K](bc.keySerializer, keys)
+		}
+		if err != nil {
+			_ = results.send(ctx, nil, nil, err)
+			return
+		}
+
+		newCtx, cancel := bc.session.ensureContext(ctx)
 		if cancel != nil {
 			defer cancel()
 		}
 
 		if bc.session.GetProtocolVersion() > 0 {
-			executeInvokeAllFilterOrKeysV1(ctx, bc, binProcessor, binKeys, binFilter, ch)
-			close(ch)
+			executeInvokeAllFilterOrKeysV1(ctx, bc, binProcessor, binKeys, binFilter, results)
 			return
 		}
 
 		request := pb.InvokeAllRequest{Cache: bc.name, Filter: binFilter, Keys: binKeys,
 			Processor: binProcessor, Format: bc.format, Scope: bc.sessionOpts.Scope}
-		valuesClient, err1 := bc.client.InvokeAll(newCtx, &request)
-		resultSerializer := NewSerializer[R](bc.format)
-
-		if err1 != nil {
-			ch <- &StreamedEntry[K, R]{Err: err1}
-			close(ch)
+		valuesClient, err := bc.client.InvokeAll(newCtx, &request)
+		if err != nil {
+			_ = results.send(ctx, nil, nil, err)
 			return
 		}
 
 		for {
-			var (
-				m        = new(pb.Entry)
-				key      *K
-				response *R
-			)
-			err1 = valuesClient.RecvMsg(m)
-			if err1 == io.EOF {
+			m := new(pb.Entry)
+			err = valuesClient.RecvMsg(m)
+			if err == io.EOF {
 				// end of stream
-				close(ch)
 				return
-			} else if err1 != nil {
-				ch <- &StreamedEntry[K, R]{Err: err1}
-				close(ch)
+			} else if err != nil {
+				_ = results.send(ctx, nil, nil, err)
 				return
 			}
 
-			response, err1 = resultSerializer.Deserialize(m.Value)
-
-			if err1 != nil {
-				ch <- &StreamedEntry[K, R]{Err: err1}
-				close(ch)
+			key, err1 := bc.keySerializer.Deserialize(m.Key)
+			if results.send(ctx, key, m.Value, err1) != nil {
 				return
 			}
-			key, err1 = bc.keySerializer.Deserialize(m.Key)
-			if err1 != nil {
-				ch <- &StreamedEntry[K, R]{Err: err1}
-				close(ch)
-				return
-			}
-
-			ch <- makeStreamedEntry(key, response, nil)
 		}
 	}()
-
-	return ch
 }
 
 // executeKeySet executes the KeySet operation against a baseClient.
 func executeKeySet[K comparable, V any](ctx context.Context, bc *baseClient[K, V]) <-chan *StreamedKey[K] {
+	ctx, op := bc.startOperation(ctx, "KeySet")
+
 	var (
 		err  = bc.ensureClientConnection()
 		ch   = make(chan *StreamedKey[K])
@@ -1277,15 +1333,16 @@ func executeKeySet[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 	}
 
 	if err != nil {
-		ch <- &StreamedKey[K]{Err: err}
-		return ch
+		return failedStream(op, &StreamedKey[K]{Err: err})
 	}
 
 	go func() {
+		defer op.endStream(ctx)()
+
 		defer func() {
 			// catch panic of closed channel read in rare circumstances
 			if r := recover(); r != nil {
-				logMessage(WARNING, panicWarning, r)
+				bc.session.log(WARNING, panicWarning, r)
 				return
 			}
 		}()
@@ -1297,11 +1354,11 @@ func executeKeySet[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 				close(ch)
 				return
 			} else if err1 != nil {
-				ch <- &StreamedKey[K]{Err: err1}
+				sendStreamed(ctx, ch, &StreamedKey[K]{Err: err1})
 				close(ch)
 				return
 			}
-			ch <- &StreamedKey[K]{Key: *result}
+			sendStreamed(ctx, ch, &StreamedKey[K]{Key: *result})
 		}
 	}()
 
@@ -1310,6 +1367,8 @@ func executeKeySet[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 
 // executeKeySetFilter executes the KeySet operation with filter against a baseClient.
 func executeKeySetFilter[K comparable, V any](ctx context.Context, bc *baseClient[K, V], fltr filters.Filter) <-chan *StreamedKey[K] {
+	ctx, op := bc.startOperation(ctx, "KeySet")
+
 	var (
 		err       = bc.ensureClientConnection()
 		binFilter = make([]byte, 0)
@@ -1317,8 +1376,7 @@ func executeKeySetFilter[K comparable, V any](ctx context.Context, bc *baseClien
 	)
 
 	if err != nil {
-		ch <- &StreamedKey[K]{Err: err}
-		return ch
+		return failedStream(op, &StreamedKey[K]{Err: err})
 	}
 
 	newCtx, cancel := bc.session.ensureContext(ctx)
@@ -1328,15 +1386,16 @@ func executeKeySetFilter[K comparable, V any](ctx context.Context, bc *baseClien
 	}
 	binFilter, err = NewSerializer[any](bc.format).Serialize(fltr)
 	if err != nil {
-		ch <- &StreamedKey[K]{Err: err}
-		return ch
+		return failedStream(op, &StreamedKey[K]{Err: err})
 	}
 
 	go func() {
+		defer op.endStream(ctx)()
+
 		defer func() {
 			// catch panic of closed channel read in rare circumstances
 			if r := recover(); r != nil {
-				logMessage(WARNING, panicWarning, r)
+				bc.session.log(WARNING, panicWarning, r)
 				return
 			}
 		}()
@@ -1356,7 +1415,7 @@ func executeKeySetFilter[K comparable, V any](ctx context.Context, bc *baseClien
 		valuesClient, err1 := bc.client.KeySet(newCtx, &request)
 
 		if err1 != nil {
-			ch <- &StreamedKey[K]{Err: err1}
+			sendStreamed(ctx, ch, &StreamedKey[K]{Err: err1})
 			close(ch)
 			return
 		}
@@ -1372,7 +1431,7 @@ func executeKeySetFilter[K comparable, V any](ctx context.Context, bc *baseClien
 				close(ch)
 				return
 			} else if err1 != nil {
-				ch <- &StreamedKey[K]{Err: err1}
+				sendStreamed(ctx, ch, &StreamedKey[K]{Err: err1})
 				close(ch)
 				return
 			}
@@ -1380,12 +1439,12 @@ func executeKeySetFilter[K comparable, V any](ctx context.Context, bc *baseClien
 			response, err1 = bc.keySerializer.Deserialize(m.Value)
 
 			if err1 != nil {
-				ch <- &StreamedKey[K]{Err: err1}
+				sendStreamed(ctx, ch, &StreamedKey[K]{Err: err1})
 				close(ch)
 				return
 			}
 
-			ch <- &StreamedKey[K]{Key: *response}
+			sendStreamed(ctx, ch, &StreamedKey[K]{Key: *response})
 		}
 	}()
 
@@ -1393,13 +1452,17 @@ func executeKeySetFilter[K comparable, V any](ctx context.Context, bc *baseClien
 }
 
 // executePutAll executes the PutAll operation against a baseClient.
-func executePutAll[K comparable, V any](ctx context.Context, bc *baseClient[K, V], entries map[K]V, ttl time.Duration) error {
+func executePutAll[K comparable, V any](ctx context.Context, bc *baseClient[K, V], entries map[K]V, ttl time.Duration) (err error) {
+	ctx, op := bc.startOperation(ctx, "PutAll")
+	defer func() { op.end(err) }()
+
 	var (
-		err       = bc.ensureClientConnection()
 		binKey    []byte
 		binValue  []byte
 		nearCache = bc.nearCache
 	)
+
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return err
 	}
@@ -1435,7 +1498,7 @@ func executePutAll[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 			v1Entries[k] = &pb1.BinaryKeyAndValue{Key: v.Key, Value: v.Value}
 		}
 
-		err = bc.session.v1StreamManagerCache.putAll(newCtx, bc.name, v1Entries, ttl)
+		err = bc.session.cacheStream(bc.name).putAll(newCtx, bc.name, v1Entries, ttl)
 		if err != nil {
 			return err
 		}
@@ -1461,9 +1524,11 @@ func executePutAll[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 }
 
 // executePutIfAbsent executes the PutIfAbsent operation against a baseClient.
-func executePutIfAbsent[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K, value V) (*V, error) {
+func executePutIfAbsent[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K, value V) (_ *V, err error) {
+	ctx, op := bc.startOperation(ctx, "PutIfAbsent")
+	defer func() { op.end(err) }()
+
 	var (
-		err         = bc.ensureClientConnection()
 		bytesResult *[]byte
 		result      *wrapperspb.BytesValue
 		binKey      []byte
@@ -1471,6 +1536,7 @@ func executePutIfAbsent[K comparable, V any](ctx context.Context, bc *baseClient
 		zeroValue   *V
 	)
 
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return zeroValue, err
 	}
@@ -1491,7 +1557,7 @@ func executePutIfAbsent[K comparable, V any](ctx context.Context, bc *baseClient
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		bytesResult, err = bc.session.v1StreamManagerCache.putIfAbsent(newCtx, bc.name, binKey, binValue)
+		bytesResult, err = bc.session.cacheStream(bc.name).putIfAbsent(newCtx, bc.name, binKey, binValue)
 		if err != nil {
 			return zeroValue, err
 		}
@@ -1510,17 +1576,21 @@ func executePutIfAbsent[K comparable, V any](ctx context.Context, bc *baseClient
 }
 
 // executePutWithExpiry executes the PutWithExpiry operation against a baseClient.
-func executePutWithExpiry[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K, value V, ttl time.Duration) (*V, error) {
+func executePutWithExpiry[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K, value V, ttl time.Duration) (_ *V, err error) {
+	ctx, op := bc.startOperation(ctx, "Put")
+	defer func() { op.end(err) }()
+
 	var (
 		result      *wrapperspb.BytesValue
 		bytesResult *[]byte
 		binKey      []byte
 		binValue    []byte
-		err         = bc.ensureClientConnection()
 		zeroValue   *V
 		nearCache   = bc.nearCache
 	)
 
+	err = bc.ensureClientConnection()
+
 	// check that the expiry value is no > Integer.MAX_VALUE millis on Java, which is 2147483647
 	if ttl.Milliseconds() > integerMaxValue {
 		return zeroValue, fmt.Errorf("expiry cannot be greater than %d millis or %v", integerMaxValue, integerMaxValue*time.Millisecond)
@@ -1535,18 +1605,22 @@ func executePutWithExpiry[K comparable, V any](ctx context.Context, bc *baseClie
 		defer cancel()
 	}
 
-	binKey, err = bc.keySerializer.Serialize(key)
+	// the key and value are only needed until the request is complete, so they are serialized into a pooled buffer
+	buffer := getBuffer()
+	defer buffer.release()
+
+	binKey, err = serializeTo(buffer, bc.keySerializer, key)
 	if err != nil {
 		return zeroValue, err
 	}
 
-	binValue, err = bc.valueSerializer.Serialize(value)
+	binValue, err = serializeTo(buffer, bc.valueSerializer, value)
 	if err != nil {
 		return zeroValue, err
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		bytesResult, err = bc.session.v1StreamManagerCache.put(newCtx, bc.name, binKey, binValue, ttl)
+		bytesResult, err = bc.session.cacheStream(bc.name).put(newCtx, bc.name, binKey, binValue, ttl)
 		if err != nil {
 			return zeroValue, err
 		}
@@ -1594,15 +1668,19 @@ func ensureOptionalValue(result *[]byte) *pb.OptionalValue {
 }
 
 // executeRemove executes the Remove operation against a baseClient.
-func executeRemove[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K) (*V, error) {
+func executeRemove[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K) (_ *V, err error) {
+	ctx, op := bc.startOperation(ctx, "Remove")
+	defer func() { op.end(err) }()
+
 	var (
-		err         = bc.ensureClientConnection()
 		oldValue    *wrapperspb.BytesValue
 		bytesResult *[]byte
 		binKey      []byte
 		zeroValue   *V
 		nearCache   = bc.nearCache
 	)
+
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return zeroValue, err
 	}
@@ -1618,7 +1696,7 @@ func executeRemove[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		bytesResult, err = bc.session.v1StreamManagerCache.remove(newCtx, bc.name, binKey)
+		bytesResult, err = bc.session.cacheStream(bc.name).remove(newCtx, bc.name, binKey)
 		if err != nil {
 			return zeroValue, err
 		}
@@ -1640,16 +1718,23 @@ func executeRemove[K comparable, V any](ctx context.Context, bc *baseClient[K, V
 }
 
 // executeRemoveMapping executes the RemoveMapping operation against a baseClient.
-func executeRemoveMapping[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K, value V) (bool, error) {
+func executeRemoveMapping[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K, value V) (_ bool, err error) {
+	ctx, op := bc.startOperation(ctx, "RemoveMapping")
+	defer func() { op.end(err) }()
+
+	if err = bc.checkValueComparison(); err != nil {
+		return false, err
+	}
+
 	var (
 		result    *wrapperspb.BoolValue
-		err       = bc.ensureClientConnection()
 		binKey    []byte
 		binValue  []byte
 		nearCache = bc.nearCache
 		removed   bool
 	)
 
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return false, err
 	}
@@ -1670,7 +1755,7 @@ func executeRemoveMapping[K comparable, V any](ctx context.Context, bc *baseClie
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		removed, err = bc.session.v1StreamManagerCache.removeMapping(newCtx, bc.name, binKey, binValue)
+		removed, err = bc.session.cacheStream(bc.name).removeMapping(newCtx, bc.name, binKey, binValue)
 		if err != nil {
 			return removed, err
 		}
@@ -1692,15 +1777,19 @@ func executeRemoveMapping[K comparable, V any](ctx context.Context, bc *baseClie
 }
 
 // executeReplace executes the Replace operation against a baseClient.
-func executeReplace[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K, value V) (*V, error) {
+func executeReplace[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K, value V) (_ *V, err error) {
+	ctx, op := bc.startOperation(ctx, "Replace")
+	defer func() { op.end(err) }()
+
 	var (
-		err         = bc.ensureClientConnection()
 		oldValue    *wrapperspb.BytesValue
 		binKey      []byte
 		binValue    []byte
 		bytesResult *[]byte
 		zeroValue   *V
 	)
+
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return zeroValue, err
 	}
@@ -1721,7 +1810,7 @@ func executeReplace[K comparable, V any](ctx context.Context, bc *baseClient[K,
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		bytesResult, err = bc.session.v1StreamManagerCache.replace(newCtx, bc.name, binKey, binValue)
+		bytesResult, err = bc.session.cacheStream(bc.name).replace(newCtx, bc.name, binKey, binValue)
 		if err != nil {
 			return zeroValue, err
 		}
@@ -1739,10 +1828,16 @@ func executeReplace[K comparable, V any](ctx context.Context, bc *baseClient[K,
 }
 
 // executeReplaceMapping executes the ReplaceMapping operation against a baseClient.
-func executeReplaceMapping[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K, prevValue V, newValue V) (bool, error) {
+func executeReplaceMapping[K comparable, V any](ctx context.Context, bc *baseClient[K, V], key K, prevValue V, newValue V) (_ bool, err error) {
+	ctx, op := bc.startOperation(ctx, "ReplaceMapping")
+	defer func() { op.end(err) }()
+
+	if err = bc.checkValueComparison(); err != nil {
+		return false, err
+	}
+
 	var (
 		result       *wrapperspb.BoolValue
-		err          = bc.ensureClientConnection()
 		binKey       []byte
 		binPrevValue []byte
 		binNewValue  []byte
@@ -1750,6 +1845,7 @@ func executeReplaceMapping[K comparable, V any](ctx context.Context, bc *baseCli
 		nearCache    = bc.nearCache
 	)
 
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return false, err
 	}
@@ -1775,7 +1871,7 @@ func executeReplaceMapping[K comparable, V any](ctx context.Context, bc *baseCli
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		replaced, err = bc.session.v1StreamManagerCache.replaceMapping(newCtx, bc.name, binKey, binPrevValue, binNewValue)
+		replaced, err = bc.session.cacheStream(bc.name).replaceMapping(newCtx, bc.name, binKey, binPrevValue, binNewValue)
 		if err != nil {
 			return replaced, err
 		}
@@ -1799,8 +1895,11 @@ func executeReplaceMapping[K comparable, V any](ctx context.Context, bc *baseCli
 }
 
 // executeSize executes the size operation against a baseClient.
-func executeSize[K comparable, V any](ctx context.Context, bc *baseClient[K, V]) (int, error) {
-	err := bc.ensureClientConnection()
+func executeSize[K comparable, V any](ctx context.Context, bc *baseClient[K, V]) (_ int, err error) {
+	ctx, op := bc.startOperation(ctx, "Size")
+	defer func() { op.end(err) }()
+
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return 0, err
 	}
@@ -1811,7 +1910,7 @@ func executeSize[K comparable, V any](ctx context.Context, bc *baseClient[K, V])
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		size, err1 := bc.session.v1StreamManagerCache.size(newCtx, bc.name)
+		size, err1 := bc.session.cacheStream(bc.name).size(newCtx, bc.name)
 		if err1 != nil {
 			return 0, err1
 		}
@@ -1829,8 +1928,11 @@ func executeSize[K comparable, V any](ctx context.Context, bc *baseClient[K, V])
 }
 
 // executeIsReady executes the isReady operation against a baseClient.
-func executeIsReady[K comparable, V any](ctx context.Context, bc *baseClient[K, V]) (bool, error) {
-	err := bc.ensureClientConnection()
+func executeIsReady[K comparable, V any](ctx context.Context, bc *baseClient[K, V]) (_ bool, err error) {
+	ctx, op := bc.startOperation(ctx, "IsReady")
+	defer func() { op.end(err) }()
+
+	err = bc.ensureClientConnection()
 	if err != nil {
 		return false, err
 	}
@@ -1841,7 +1943,7 @@ func executeIsReady[K comparable, V any](ctx context.Context, bc *baseClient[K,
 	}
 
 	if bc.session.GetProtocolVersion() > 0 {
-		return bc.session.v1StreamManagerCache.isReady(newCtx, bc.name)
+		return bc.session.cacheStream(bc.name).isReady(newCtx, bc.name)
 	}
 
 	isReadyRequest := pb.IsReadyRequest{Cache: bc.name}
@@ -1856,6 +1958,8 @@ func executeIsReady[K comparable, V any](ctx context.Context, bc *baseClient[K,
 
 // executeEntrySet executes the KeySet operation against a baseClient.
 func executeEntrySet[K comparable, V any](ctx context.Context, bc *baseClient[K, V]) <-chan *StreamedEntry[K, V] {
+	ctx, op := bc.startOperation(ctx, "EntrySet")
+
 	var (
 		err  = bc.ensureClientConnection()
 		ch   = make(chan *StreamedEntry[K, V])
@@ -1863,8 +1967,7 @@ func executeEntrySet[K comparable, V any](ctx context.Context, bc *baseClient[K,
 	)
 
 	if err != nil {
-		ch <- &StreamedEntry[K, V]{Err: err}
-		return ch
+		return failedStream(op, &StreamedEntry[K, V]{Err: err})
 	}
 
 	if bc.getProtocolVersion() > 0 {
@@ -1874,10 +1977,12 @@ func executeEntrySet[K comparable, V any](ctx context.Context, bc *baseClient[K,
 	}
 
 	go func() {
+		defer op.endStream(ctx)()
+
 		defer func() {
 			// catch panic of closed channel read in rare circumstances
 			if r := recover(); r != nil {
-				logMessage(WARNING, panicWarning, r)
+				bc.session.log(WARNING, panicWarning, r)
 				return
 			}
 		}()
@@ -1889,18 +1994,20 @@ func executeEntrySet[K comparable, V any](ctx context.Context, bc *baseClient[K,
 				close(ch)
 				return
 			} else if err1 != nil {
-				ch <- &StreamedEntry[K, V]{Err: err1}
+				sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: err1})
 				close(ch)
 				return
 			}
-			ch <- &StreamedEntry[K, V]{Key: result.Key, Value: result.Value}
+			sendStreamed(ctx, ch, &StreamedEntry[K, V]{Key: result.Key, Value: result.Value})
 		}
 	}()
 
 	return ch
 }
 
-func executeEntrySetFilter[K comparable, V any, E any](ctx context.Context, bc *baseClient[K, V], fltr filters.Filter, comparator extractors.Comparator[E]) <-chan *StreamedEntry[K, V] {
+func executeEntrySetFilter[K comparable, V any](ctx context.Context, bc *baseClient[K, V], fltr filters.Filter, comparator any) <-chan *StreamedEntry[K, V] {
+	ctx, op := bc.startOperation(ctx, "EntrySet")
+
 	var (
 		err           = bc.ensureClientConnection()
 		binFilter     = make([]byte, 0)
@@ -1910,8 +2017,7 @@ func executeEntrySetFilter[K comparable, V any, E any](ctx context.Context, bc *
 	)
 
 	if err != nil {
-		ch <- &StreamedEntry[K, V]{Err: err}
-		return ch
+		return failedStream(op, &StreamedEntry[K, V]{Err: err})
 	}
 
 	newCtx, cancel := bc.session.ensureContext(ctx)
@@ -1921,23 +2027,23 @@ func executeEntrySetFilter[K comparable, V any, E any](ctx context.Context, bc *
 	}
 	binFilter, err = serializer.Serialize(fltr)
 	if err != nil {
-		ch <- &StreamedEntry[K, V]{Err: err}
-		return ch
+		return failedStream(op, &StreamedEntry[K, V]{Err: err})
 	}
 
 	if comparator != nil {
 		binComparator, err = serializer.Serialize(comparator)
 		if err != nil {
-			ch <- &StreamedEntry[K, V]{Err: err}
-			return ch
+			return failedStream(op, &StreamedEntry[K, V]{Err: err})
 		}
 	}
 
 	go func() {
+		defer op.endStream(ctx)()
+
 		defer func() {
 			// catch panic of closed channel read in rare circumstances
 			if r := recover(); r != nil {
-				logMessage(WARNING, panicWarning, r)
+				bc.session.log(WARNING, panicWarning, r)
 				return
 			}
 		}()
@@ -1961,7 +2067,7 @@ func executeEntrySetFilter[K comparable, V any, E any](ctx context.Context, bc *
 
 		entrySetClient, err1 := bc.client.EntrySet(newCtx, &request)
 		if err1 != nil {
-			ch <- &StreamedEntry[K, V]{Err: err1}
+			sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: err1})
 			close(ch)
 			return
 		}
@@ -1977,24 +2083,24 @@ func executeEntrySetFilter[K comparable, V any, E any](ctx context.Context, bc *
 				close(ch)
 				return
 			} else if err1 != nil {
-				ch <- &StreamedEntry[K, V]{Err: err1}
+				sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: err1})
 				close(ch)
 				return
 			}
 
 			// deserialize key and value
 			if key, err1 = bc.keySerializer.Deserialize(response.Key); err1 != nil {
-				ch <- &StreamedEntry[K, V]{Err: err1}
+				sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: err1})
 				close(ch)
 				return
 			}
 			if value, err1 = bc.valueSerializer.Deserialize(response.Value); err1 != nil {
-				ch <- &StreamedEntry[K, V]{Err: err1}
+				sendStreamed(ctx, ch, &StreamedEntry[K, V]{Err: err1})
 				close(ch)
 				return
 			}
 
-			ch <- makeStreamedEntry[K, V](key, value, nil)
+			sendStreamed(ctx, ch, makeStreamedEntry[K, V](key, value, nil))
 		}
 	}()
 
@@ -2003,6 +2109,8 @@ func executeEntrySetFilter[K comparable, V any, E any](ctx context.Context, bc *
 
 // executeValues executes the Values operation against a baseClient.
 func executeValues[K comparable, V any, E any](ctx context.Context, bc *baseClient[K, V], fltr filters.Filter, comparator extractors.Comparator[E]) <-chan *StreamedValue[V] {
+	ctx, op := bc.startOperation(ctx, "Values")
+
 	var (
 		err           = bc.ensureClientConnection()
 		binFilter     = make([]byte, 0)
@@ -2012,8 +2120,7 @@ func executeValues[K comparable, V any, E any](ctx context.Context, bc *baseClie
 	)
 
 	if err != nil {
-		ch <- &StreamedValue[V]{Err: err}
-		return ch
+		return failedStream(op, &StreamedValue[V]{Err: err})
 	}
 
 	newCtx, cancel := bc.session.ensureContext(ctx)
@@ -2023,23 +2130,23 @@ func executeValues[K comparable, V any, E any](ctx context.Context, bc *baseClie
 	}
 	binFilter, err = serializer.Serialize(fltr)
 	if err != nil {
-		ch <- &StreamedValue[V]{Err: err}
-		return ch
+		return failedStream(op, &StreamedValue[V]{Err: err})
 	}
 
 	if comparator != nil {
 		binComparator, err = serializer.Serialize(comparator)
 		if err != nil {
-			ch <- &StreamedValue[V]{Err: err}
-			return ch
+			return failedStream(op, &StreamedValue[V]{Err: err})
 		}
 	}
 
 	go func() {
+		defer op.endStream(ctx)()
+
 		defer func() {
 			// catch panic of closed channel read in rare circumstances
 			if r := recover(); r != nil {
-				logMessage(WARNING, panicWarning, r)
+				bc.session.log(WARNING, panicWarning, r)
 				return
 			}
 		}()
@@ -2058,7 +2165,7 @@ func executeValues[K comparable, V any, E any](ctx context.Context, bc *baseClie
 		valuesClient, err1 := bc.client.Values(newCtx, &request)
 
 		if err1 != nil {
-			ch <- &StreamedValue[V]{Err: err1}
+			sendStreamed(ctx, ch, &StreamedValue[V]{Err: err1})
 			close(ch)
 			return
 		}
@@ -2074,7 +2181,7 @@ func executeValues[K comparable, V any, E any](ctx context.Context, bc *baseClie
 				close(ch)
 				return
 			} else if err1 != nil {
-				ch <- &StreamedValue[V]{Err: err1}
+				sendStreamed(ctx, ch, &StreamedValue[V]{Err: err1})
 				close(ch)
 				return
 			}
@@ -2082,12 +2189,12 @@ func executeValues[K comparable, V any, E any](ctx context.Context, bc *baseClie
 			response, err1 = bc.valueSerializer.Deserialize(m.Value)
 
 			if err1 != nil {
-				ch <- &StreamedValue[V]{Err: err1}
+				sendStreamed(ctx, ch, &StreamedValue[V]{Err: err1})
 				close(ch)
 				return
 			}
 
-			ch <- &StreamedValue[V]{Value: *response}
+			sendStreamed(ctx, ch, &StreamedValue[V]{Value: *response})
 		}
 	}()
 
@@ -2096,6 +2203,8 @@ func executeValues[K comparable, V any, E any](ctx context.Context, bc *baseClie
 
 // executeValuesNoFilter executes the Values operation against a baseClient when no filter is required.
 func executeValuesNoFilter[K comparable, V any](ctx context.Context, bc *baseClient[K, V]) <-chan *StreamedValue[V] {
+	ctx, op := bc.startOperation(ctx, "Values")
+
 	var (
 		err  = bc.ensureClientConnection()
 		ch   = make(chan *StreamedValue[V])
@@ -2103,8 +2212,7 @@ func executeValuesNoFilter[K comparable, V any](ctx context.Context, bc *baseCli
 	)
 
 	if err != nil {
-		ch <- &StreamedValue[V]{Err: err}
-		return ch
+		return failedStream(op, &StreamedValue[V]{Err: err})
 	}
 
 	if bc.getProtocolVersion() > 0 {
@@ -2114,10 +2222,12 @@ func executeValuesNoFilter[K comparable, V any](ctx context.Context, bc *baseCli
 	}
 
 	go func() {
+		defer op.endStream(ctx)()
+
 		defer func() {
 			// catch panic of closed channel read in rare circumstances
 			if r := recover(); r != nil {
-				logMessage(WARNING, panicWarning, r)
+				bc.session.log(WARNING, panicWarning, r)
 				return
 			}
 		}()
@@ -2129,11 +2239,11 @@ func executeValuesNoFilter[K comparable, V any](ctx context.Context, bc *baseCli
 				close(ch)
 				return
 			} else if err1 != nil {
-				ch <- &StreamedValue[V]{Err: err1}
+				sendStreamed(ctx, ch, &StreamedValue[V]{Err: err1})
 				close(ch)
 				return
 			}
-			ch <- &StreamedValue[V]{Value: *result}
+			sendStreamed(ctx, ch, &StreamedValue[V]{Value: *result})
 		}
 	}()
 
@@ -2163,11 +2273,12 @@ func (bc *baseClient[K, V]) generateMapLifecycleEvent(client interface{}, eventT
 // generateMapEvent emits the [MapEvent] for v1 clients.
 func (bc *baseClient[K, V]) generateMapEvent(client interface{}, eventResponse *pb1.MapEventMessage) {
 	if namedMap, ok := client.(NamedMap[K, V]); ok {
+		bc.session.metrics.eventReceived(bc.name)
 		receivedMapEvent := newMapEventV1(namedMap, eventResponse)
 		if eventResponse.Key != nil {
 			key, err := bc.keySerializer.Deserialize(eventResponse.Key)
 			if err != nil {
-				logMessage(WARNING, "unable to deserialize key from eventResponse %v, ignoring eventResponse", eventResponse)
+				bc.session.logAttrs(WARNING, cacheAttrs(bc.name), "unable to deserialize key from eventResponse %v, ignoring eventResponse", eventResponse)
 			} else {
 				keyGroup, groupPresent := bc.keyListenersV1[*key]
 				if groupPresent {
@@ -2198,6 +2309,9 @@ func (bc *baseClient[K, V]) ensureClientConnection() error {
 	if bc.released {
 		return ErrReleased
 	}
+	if session.shuttingDown.Load() {
+		return ErrShuttingDown
+	}
 
 	err = session.ensureConnection()
 	if err != nil {
@@ -2230,14 +2344,49 @@ func getBoolValueFromEnvVarOrDefault(envVar string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// errorStream returns a channel containing only the result with an error, for streaming operations which fail
+// before they start, as the channels they return are unbuffered and have no receiver yet.
+func errorStream[T any](result T) chan T {
+	ch := make(chan T, 1)
+	ch <- result
+	close(ch)
+	return ch
+}
+
 // serializeKeys serializes an array of keys
 func serializeKeys[K comparable](serializer Serializer[K], keys []K) ([][]byte, error) {
+	return serializeKeysTo[K](&pooledBuffer{}, serializer, keys)
+}
+
+// serializeKeysTo serializes an array of keys into the buffer where possible, rather than allocating each key.
+func serializeKeysTo[K comparable](buffer *pooledBuffer, serializer Serializer[K], keys []K) ([][]byte, error) {
 	var (
-		binKeys = make([][]byte, 0)
+		binKeys = make([][]byte, 0, len(keys))
 		err     error
 		binKey  []byte
 	)
 
+	if s, ok := serializer.(appendingSerializer[K]); ok {
+		var (
+			data  = buffer.data
+			start = len(data)
+			ends  = make([]int, len(keys))
+		)
+		for i, k := range keys {
+			if data, err = s.appendSerialize(data, k); err != nil {
+				return binKeys, err
+			}
+			ends[i] = len(data)
+		}
+		buffer.data = data
+
+		for _, end := range ends {
+			binKeys = append(binKeys, data[start:end:end])
+			start = end
+		}
+		return binKeys, nil
+	}
+
 	for _, k := range keys {
 		if binKey, err = serializer.Serialize(k); err != nil {
 			return binKeys, err
@@ -0,0 +1,284 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+const (
+	// compressedSerializationPrefix is the prefix of a compressed value, which is followed by the id of the
+	// Compressor and then the compressed serialized value. It is distinct from jsonSerializationPrefix
+	// so that values stored before compression was enabled can still be read.
+	compressedSerializationPrefix = 90
+
+	// CompressionGzip is the [Compressor.ID] of the compressor returned by [GzipCompressor].
+	CompressionGzip byte = 1
+
+	// CompressionZstd is the [Compressor.ID] of the zstd compressor in the package
+	// github.com/oracle/coherence-go-client/v2/coherence/zstdcompression.
+	CompressionZstd byte = 2
+)
+
+var (
+	// ErrInvalidCompression indicates that the options passed to [WithValueCompression] are not valid.
+	ErrInvalidCompression = errors.New("compressor must not be nil and minimum size must not be negative")
+
+	// ErrUnknownCompression indicates that a value was compressed using a [Compressor] which is not configured.
+	ErrUnknownCompression = errors.New("the value was compressed using an unknown compressor")
+)
+
+// Compressor compresses and decompresses serialized values for [WithValueCompression]. A gzip compressor is
+// provided by [GzipCompressor], and a zstd compressor by the separate zstdcompression module.
+type Compressor interface {
+	// ID returns the id which is stored with each compressed value to identify the algorithm.
+	ID() byte
+
+	// Compress returns the compressed data.
+	Compress(data []byte) ([]byte, error)
+
+	// Decompress returns the decompressed data.
+	Decompress(data []byte) ([]byte, error)
+}
+
+// ValueCompression contains the options for compressing values, set using [WithValueCompression].
+type ValueCompression struct {
+	// Compressor is the compressor used for values.
+	Compressor Compressor
+
+	// MinSize is the minimum serialized size, in bytes, of a value for it to be compressed.
+	MinSize int
+}
+
+// WithValueCompression returns a function to compress the values of a [NamedMap] or [NamedCache] whose serialized
+// size is at least minSize bytes. Compressed values are stored with a prefix that identifies the compressor,
+// and values stored uncompressed, including those stored before compression was enabled, can still be read.
+// A value is stored uncompressed if compressing it does not reduce its size. The compression statistics for
+// the cache are included in [Session.Metrics].
+//
+//	namedMap, err := coherence.GetNamedMap[string, Document](session, "documents",
+//	    coherence.WithValueCompression(coherence.GzipCompressor(gzip.BestSpeed), 4096))
+//
+// A gzip compressor is provided by [GzipCompressor], and a zstd compressor by the package
+// github.com/oracle/coherence-go-client/v2/coherence/zstdcompression, which is a separate module so that
+// applications which do not use zstd do not depend on it:
+//
+//	compressor, err := zstdcompression.New(zstdcompression.DefaultLevel)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	namedMap, err := coherence.GetNamedMap[string, Document](session, "documents",
+//	    coherence.WithValueCompression(compressor, 4096))
+//
+// Other algorithms can be used by implementing [Compressor].
+//
+// Every client reading the cache must be configured with a [Compressor] with the same id, otherwise reading a
+// compressed value returns [ErrUnknownCompression].
+//
+// As compressed values are opaque to the cluster, filters, extractors, entry processors and aggregators
+// which access values cannot be used against the compressed values in the cache.
+func WithValueCompression(compressor Compressor, minSize int) func(cacheOptions *CacheOptions) {
+	return func(c *CacheOptions) {
+		c.ValueCompression = &ValueCompression{Compressor: compressor, MinSize: minSize}
+	}
+}
+
+// validate validates the compression options.
+func (c *ValueCompression) validate() error {
+	if c.Compressor == nil || c.MinSize < 0 {
+		return ErrInvalidCompression
+	}
+	return nil
+}
+
+func (c ValueCompression) String() string {
+	return fmt.Sprintf("ValueCompression{compressor=%d, minSize=%d}", c.Compressor.ID(), c.MinSize)
+}
+
+// GzipCompressor returns a [Compressor] which uses gzip with the specified compression level,
+// such as [gzip.BestSpeed] or [gzip.DefaultCompression].
+func GzipCompressor(level int) Compressor {
+	return gzipCompressor{level: level}
+}
+
+type gzipCompressor struct {
+	level int
+}
+
+func (g gzipCompressor) ID() byte {
+	return CompressionGzip
+}
+
+func (g gzipCompressor) Compress(data []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer, err := gzip.NewWriterLevel(&buffer, g.level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (g gzipCompressor) Decompress(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// compressionStats holds the compression statistics for a cache.
+type compressionStats struct {
+	compressed        atomic.Int64
+	uncompressed      atomic.Int64
+	bytesBefore       atomic.Int64
+	bytesAfter        atomic.Int64
+	decompressed      atomic.Int64
+	decompressionErrs atomic.Int64
+}
+
+// CompressionMetrics contains the compression statistics for a cache, included in [SessionMetrics].
+type CompressionMetrics struct {
+	// Compressed is the number of values which were compressed.
+	Compressed int64
+
+	// Uncompressed is the number of values stored uncompressed, because they were smaller than the minimum
+	// size or compressing them did not reduce their size.
+	Uncompressed int64
+
+	// BytesBefore is the total serialized size of the values which were compressed, before compression.
+	BytesBefore int64
+
+	// BytesAfter is the total size of the values which were compressed, after compression.
+	BytesAfter int64
+
+	// Decompressed is the number of compressed values which were read.
+	Decompressed int64
+
+	// DecompressionErrors is the number of compressed values which could not be decompressed.
+	DecompressionErrors int64
+}
+
+// Ratio returns the compression ratio, the size before compression divided by the size after,
+// for the values which were compressed, or zero if no values have been compressed.
+func (c CompressionMetrics) Ratio() float64 {
+	if c.BytesAfter == 0 {
+		return 0
+	}
+	return float64(c.BytesBefore) / float64(c.BytesAfter)
+}
+
+func (s *compressionStats) snapshot() CompressionMetrics {
+	return CompressionMetrics{
+		Compressed:          s.compressed.Load(),
+		Uncompressed:        s.uncompressed.Load(),
+		BytesBefore:         s.bytesBefore.Load(),
+		BytesAfter:          s.bytesAfter.Load(),
+		Decompressed:        s.decompressed.Load(),
+		DecompressionErrors: s.decompressionErrs.Load(),
+	}
+}
+
+// compressionSerializer wraps the value serializer of a cache to compress values.
+type compressionSerializer[T any] struct {
+	serializer Serializer[T]
+	compressor Compressor
+	minSize    int
+	stats      *compressionStats
+}
+
+// newCompressionSerializer returns a serializer which compresses the values serialized by serializer.
+func newCompressionSerializer[T any](serializer Serializer[T], compression *ValueCompression, stats *compressionStats) Serializer[T] {
+	if stats == nil {
+		stats = &compressionStats{}
+	}
+	return compressionSerializer[T]{
+		serializer: serializer,
+		compressor: compression.Compressor,
+		minSize:    compression.MinSize,
+		stats:      stats,
+	}
+}
+
+// Serialize serializes an object of type T and returns the []byte representation.
+func (s compressionSerializer[T]) Serialize(object T) ([]byte, error) {
+	data, err := s.serializer.Serialize(object)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < s.minSize {
+		s.stats.uncompressed.Add(1)
+		return data, nil
+	}
+
+	compressed, err := s.compressor.Compress(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(compressed)+2 >= len(data) {
+		s.stats.uncompressed.Add(1)
+		return data, nil
+	}
+
+	result := make([]byte, 0, len(compressed)+2)
+	result = append(result, compressedSerializationPrefix, s.compressor.ID())
+	result = append(result, compressed...)
+
+	s.stats.compressed.Add(1)
+	s.stats.bytesBefore.Add(int64(len(data)))
+	s.stats.bytesAfter.Add(int64(len(result)))
+	return result, nil
+}
+
+// Deserialize deserialized an object and returns the correct type of T.
+func (s compressionSerializer[T]) Deserialize(data []byte) (*T, error) {
+	if len(data) == 0 || data[0] != compressedSerializationPrefix {
+		return s.serializer.Deserialize(data)
+	}
+
+	var zeroValue T
+	if len(data) < 2 {
+		s.stats.decompressionErrs.Add(1)
+		return &zeroValue, fmt.Errorf("%w: missing compressor id", ErrUnknownCompression)
+	}
+
+	var compressor Compressor
+	switch data[1] {
+	case s.compressor.ID():
+		compressor = s.compressor
+	case CompressionGzip:
+		compressor = GzipCompressor(gzip.DefaultCompression)
+	default:
+		s.stats.decompressionErrs.Add(1)
+		return &zeroValue, fmt.Errorf("%w: id %d", ErrUnknownCompression, data[1])
+	}
+
+	decompressed, err := compressor.Decompress(data[2:])
+	if err != nil {
+		s.stats.decompressionErrs.Add(1)
+		return &zeroValue, err
+	}
+	s.stats.decompressed.Add(1)
+	return s.serializer.Deserialize(decompressed)
+}
+
+// Format returns the format used for the serializer.
+func (s compressionSerializer[T]) Format() string {
+	return s.serializer.Format()
+}
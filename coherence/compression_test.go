@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/testing/fakeproxy"
+)
+
+func TestCompressionSerializer(t *testing.T) {
+	var (
+		stats      = &compressionStats{}
+		compressed = newCompressionSerializer(NewSerializer[string]("json"),
+			&ValueCompression{Compressor: GzipCompressor(gzip.BestSpeed), MinSize: 100}, stats)
+		large = strings.Repeat("compress me ", 100)
+	)
+
+	// small values are not compressed
+	data, err := compressed.Serialize("small")
+	if err != nil || data[0] != jsonSerializationPrefix {
+		t.Fatalf("expected an uncompressed value, got %v, %v", data, err)
+	}
+
+	data, err = compressed.Serialize(large)
+	if err != nil {
+		t.Fatalf("unable to serialize: %v", err)
+	}
+	if data[0] != compressedSerializationPrefix || data[1] != CompressionGzip || len(data) >= len(large) {
+		t.Fatalf("expected a compressed value, got %d bytes with prefix %v", len(data), data[:2])
+	}
+
+	value, err := compressed.Deserialize(data)
+	if err != nil || value == nil || *value != large {
+		t.Fatalf("expected the large value, got %v", err)
+	}
+
+	// values stored before compression was enabled can still be read
+	uncompressed, _ := NewSerializer[string]("json").Serialize(large)
+	if value, err = compressed.Deserialize(uncompressed); err != nil || *value != large {
+		t.Fatalf("expected the uncompressed value to be read, got %v", err)
+	}
+
+	metrics := stats.snapshot()
+	if metrics.Compressed != 1 || metrics.Uncompressed != 1 || metrics.Decompressed != 1 || metrics.Ratio() <= 1 {
+		t.Fatalf("unexpected compression metrics %+v", metrics)
+	}
+
+	// unknown compressors are rejected
+	unknown := bytes.Clone(data)
+	unknown[1] = CompressionZstd
+	if _, err = compressed.Deserialize(unknown); !errors.Is(err, ErrUnknownCompression) {
+		t.Fatalf("expected ErrUnknownCompression, got %v", err)
+	}
+	if stats.snapshot().DecompressionErrors != 1 {
+		t.Fatalf("expected a decompression error to be counted")
+	}
+}
+
+func TestValueCompression(t *testing.T) {
+	ctx := context.Background()
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	session, err := NewSession(ctx, WithAddress(address), WithPlainText(), WithRequestTimeout(5*time.Second), WithMetrics())
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	if _, err = GetNamedMap[int, string](session, "invalid", WithValueCompression(nil, 0)); !errors.Is(err, ErrInvalidCompression) {
+		t.Fatalf("expected ErrInvalidCompression, got %v", err)
+	}
+
+	namedMap, err := GetNamedMap[int, string](session, "documents", WithValueCompression(GzipCompressor(gzip.BestSpeed), 64))
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+
+	document := strings.Repeat("document ", 1000)
+	if _, err = namedMap.Put(ctx, 1, document); err != nil {
+		t.Fatalf("unable to put: %v", err)
+	}
+	value, err := namedMap.Get(ctx, 1)
+	if err != nil || value == nil || *value != document {
+		t.Fatalf("expected the document, got %v", err)
+	}
+
+	metrics := session.Metrics().Compression["documents"]
+	if metrics.Compressed != 1 || metrics.Decompressed != 1 || metrics.BytesAfter >= metrics.BytesBefore {
+		t.Fatalf("unexpected compression metrics %+v", metrics)
+	}
+}
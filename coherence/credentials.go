@@ -0,0 +1,246 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// credentialsRefreshWindow is how long before credentials expire that they are refreshed.
+	credentialsRefreshWindow = 30 * time.Second
+
+	// minStreamReopenDelay is the minimum delay before a gRPC v1 stream is re-opened with new credentials,
+	// so that credentials which have already expired do not cause the stream to be continually re-opened.
+	minStreamReopenDelay = time.Second
+)
+
+// ErrNoCredentials indicates that a [CredentialsProvider] returned no credentials and no error.
+var ErrNoCredentials = errors.New("the credentials provider returned no credentials")
+
+// Credentials are the call credentials attached to each gRPC request and gRPC v1 stream.
+type Credentials struct {
+	// Metadata contains the request metadata to attach, for example {"authorization": "Bearer <token>"}.
+	// The keys must be lower case.
+	Metadata map[string]string
+
+	// Expiry is the time the credentials expire, the zero time means they do not expire.
+	Expiry time.Time
+}
+
+// CredentialsProvider provides the [Credentials] for a [Session]. Credentials are cached and a provider is
+// asked for new credentials shortly before the current credentials expire, or when the server rejects them.
+type CredentialsProvider interface {
+	// Credentials returns the current credentials.
+	Credentials(ctx context.Context) (*Credentials, error)
+}
+
+// CredentialsProviderFunc is a function which implements [CredentialsProvider].
+type CredentialsProviderFunc func(ctx context.Context) (*Credentials, error)
+
+// Credentials calls f(ctx).
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (*Credentials, error) {
+	return f(ctx)
+}
+
+// NewBearerTokenProvider returns a [CredentialsProvider] which attaches the token returned by the
+// function as a bearer token in the authorization metadata.
+func NewBearerTokenProvider(token func(ctx context.Context) (string, time.Time, error)) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		value, expiry, err := token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &Credentials{Metadata: map[string]string{"authorization": "Bearer " + value}, Expiry: expiry}, nil
+	})
+}
+
+// WithCredentialsProvider returns a function to set the [CredentialsProvider] used to attach call credentials,
+// such as bearer tokens, to the gRPC connection and gRPC v1 streams. When the server rejects the credentials
+// with an Unauthenticated status, new credentials are obtained and a gRPC v1 stream is re-opened.
+// The credentials are attached whether the connection uses TLS or not.
+func WithCredentialsProvider(provider CredentialsProvider) func(sessionOptions *SessionOptions) {
+	return func(s *SessionOptions) {
+		s.CredentialsProvider = provider
+	}
+}
+
+// credentialsManager caches the credentials from a [CredentialsProvider] and implements
+// the gRPC PerRPCCredentials interface to attach them to requests.
+type credentialsManager struct {
+	session    *Session
+	provider   CredentialsProvider
+	mutex      sync.Mutex
+	current    *Credentials
+	refreshing chan struct{} // set while the provider is being asked for credentials, closed once it has returned
+}
+
+// attachedCredentialsKey is the context key for the [attachedCredentials] of a gRPC v1 stream being opened.
+type attachedCredentialsKey struct{}
+
+// attachedCredentials records the expiry of the credentials attached when a gRPC v1 stream was opened, as
+// the credentials are only sent when the stream is opened, so the stream must be re-opened before they expire.
+type attachedCredentials struct {
+	expiry atomic.Pointer[time.Time]
+}
+
+func newCredentialsManager(session *Session, provider CredentialsProvider) *credentialsManager {
+	return &credentialsManager{session: session, provider: provider}
+}
+
+// dialOptions returns the dial options to attach the credentials and to detect when they are rejected.
+func (c *credentialsManager) dialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithPerRPCCredentials(c),
+		grpc.WithChainUnaryInterceptor(c.unaryInterceptor),
+		grpc.WithChainStreamInterceptor(c.streamInterceptor),
+	}
+}
+
+// GetRequestMetadata returns the metadata for the current credentials, obtaining new credentials
+// if there are none or the current credentials are about to expire.
+func (c *credentialsManager) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	creds, err := c.credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if attached, ok := ctx.Value(attachedCredentialsKey{}).(*attachedCredentials); ok {
+		attached.expiry.Store(&creds.Expiry)
+	}
+
+	return creds.Metadata, nil
+}
+
+// credentials returns the current credentials, asking the provider for new credentials if there are none or the
+// current credentials are about to expire. The provider is not called while holding the mutex, and only one
+// caller asks the provider at a time, the others wait for the result.
+func (c *credentialsManager) credentials(ctx context.Context) (*Credentials, error) {
+	for {
+		c.mutex.Lock()
+		if current := c.current; current != nil && (current.Expiry.IsZero() || time.Until(current.Expiry) >= credentialsRefreshWindow) {
+			c.mutex.Unlock()
+			return current, nil
+		}
+
+		refreshing := c.refreshing
+		if refreshing == nil {
+			refreshing = make(chan struct{})
+			c.refreshing = refreshing
+			c.mutex.Unlock()
+
+			return c.refresh(ctx, refreshing)
+		}
+		c.mutex.Unlock()
+
+		select {
+		case <-refreshing:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// refresh asks the provider for new credentials, then closes refreshing to release any waiting callers.
+func (c *credentialsManager) refresh(ctx context.Context, refreshing chan struct{}) (*Credentials, error) {
+	creds, err := c.provider.Credentials(ctx)
+	if err == nil && creds == nil {
+		err = ErrNoCredentials
+	}
+
+	c.mutex.Lock()
+	if err == nil {
+		c.current = creds
+	}
+	c.refreshing = nil
+	c.mutex.Unlock()
+	close(refreshing)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.session.debug("obtained credentials expiring at %v", creds.Expiry)
+	return creds, nil
+}
+
+// streamReopenDelay returns how long until a gRPC v1 stream opened with credentials expiring at the expiry must
+// be re-opened, which is the refresh window before they expire, so that new credentials are obtained when the
+// stream is re-opened. Credentials which expire within twice the refresh window are re-opened halfway to expiry.
+func streamReopenDelay(expiry time.Time) time.Duration {
+	remaining := time.Until(expiry)
+	delay := remaining - credentialsRefreshWindow
+	if remaining < 2*credentialsRefreshWindow {
+		delay = remaining / 2
+	}
+	return max(delay, minStreamReopenDelay)
+}
+
+// RequireTransportSecurity returns false so that the credentials are attached to plain text connections,
+// for example when TLS is terminated by a gateway.
+func (c *credentialsManager) RequireTransportSecurity() bool {
+	return false
+}
+
+// invalidate discards the current credentials if the error indicates that they were rejected,
+// returning true if they were.
+func (c *credentialsManager) invalidate(err error) bool {
+	if status.Code(err) != codes.Unauthenticated {
+		return false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.session.log(WARNING, "Session [%s] credentials rejected by the server: %v", c.session.sessionID, err)
+	c.current = nil
+
+	return true
+}
+
+// unaryInterceptor retries a request once with new credentials if the server rejected the current credentials.
+func (c *credentialsManager) unaryInterceptor(ctx context.Context, method string, req, reply any,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err != nil && c.invalidate(err) {
+		err = invoker(ctx, method, req, reply, cc, opts...)
+	}
+	return err
+}
+
+// streamInterceptor discards the current credentials if the server rejects them when opening
+// or receiving from a stream, so that new credentials are used when the stream is re-opened.
+func (c *credentialsManager) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		c.invalidate(err)
+		return nil, err
+	}
+	return &credentialsStream{ClientStream: stream, manager: c}, nil
+}
+
+type credentialsStream struct {
+	grpc.ClientStream
+	manager *credentialsManager
+}
+
+func (s *credentialsStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.manager.invalidate(err)
+	}
+	return err
+}
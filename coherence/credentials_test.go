@@ -0,0 +1,286 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/testing/fakeproxy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestCredentialsManagerRefresh(t *testing.T) {
+	var (
+		calls   int
+		expiry  = time.Now().Add(time.Hour)
+		session = newTestSession()
+	)
+
+	provider := NewBearerTokenProvider(func(_ context.Context) (string, time.Time, error) {
+		calls++
+		return "token-" + strconv.Itoa(calls), expiry, nil
+	})
+
+	manager := newCredentialsManager(session, provider)
+
+	md, err := manager.GetRequestMetadata(context.Background())
+	if err != nil || md["authorization"] != "Bearer token-1" {
+		t.Fatalf("unexpected metadata %v, %v", md, err)
+	}
+
+	// credentials are cached until they are about to expire
+	_, _ = manager.GetRequestMetadata(context.Background())
+	if calls != 1 {
+		t.Fatalf("expected credentials to be cached, provider called %d times", calls)
+	}
+
+	manager.current.Expiry = time.Now().Add(credentialsRefreshWindow / 2)
+	md, _ = manager.GetRequestMetadata(context.Background())
+	if calls != 2 || md["authorization"] != "Bearer token-2" {
+		t.Fatalf("expected credentials to be refreshed before expiry, got %v after %d calls", md, calls)
+	}
+
+	// only an Unauthenticated status should invalidate the credentials
+	if manager.invalidate(status.Error(codes.Unavailable, "unavailable")) || manager.current == nil {
+		t.Fatalf("expected credentials to be retained after Unavailable")
+	}
+	if !manager.invalidate(status.Error(codes.Unauthenticated, "expired")) || manager.current != nil {
+		t.Fatalf("expected credentials to be discarded after Unauthenticated")
+	}
+
+	manager = newCredentialsManager(session, CredentialsProviderFunc(func(_ context.Context) (*Credentials, error) {
+		return nil, nil
+	}))
+	if _, err = manager.GetRequestMetadata(context.Background()); !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("expected ErrNoCredentials, got %v", err)
+	}
+}
+
+func TestCredentialsUnaryInterceptorRetry(t *testing.T) {
+	var (
+		session = newTestSession()
+		manager = newCredentialsManager(session, CredentialsProviderFunc(func(_ context.Context) (*Credentials, error) {
+			return &Credentials{Metadata: map[string]string{"x-token": "value"}}, nil
+		}))
+		calls int
+	)
+
+	invoker := func(_ context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		calls++
+		if calls == 1 {
+			return status.Error(codes.Unauthenticated, "rejected")
+		}
+		return nil
+	}
+
+	if err := manager.unaryInterceptor(context.Background(), "method", nil, nil, nil, invoker); err != nil || calls != 2 {
+		t.Fatalf("expected request to be retried once, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	failing := func(_ context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Internal, "failed")
+	}
+
+	if err := manager.unaryInterceptor(context.Background(), "method", nil, nil, nil, failing); err == nil || calls != 1 {
+		t.Fatalf("expected other errors not to be retried, got %v after %d calls", err, calls)
+	}
+}
+
+func TestCredentialsRefreshedOnce(t *testing.T) {
+	var (
+		calls   int
+		release = make(chan struct{})
+		manager *credentialsManager
+	)
+
+	manager = newCredentialsManager(newTestSession(), CredentialsProviderFunc(func(_ context.Context) (*Credentials, error) {
+		// the provider must not be called while the lock is held
+		if !manager.mutex.TryLock() {
+			t.Errorf("provider called while holding the lock")
+		} else {
+			manager.mutex.Unlock()
+		}
+		calls++
+		<-release
+		return &Credentials{Metadata: map[string]string{"x-token": "value"}, Expiry: time.Now().Add(time.Hour)}, nil
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if md, err := manager.GetRequestMetadata(context.Background()); err != nil || md["x-token"] != "value" {
+				t.Errorf("unexpected metadata %v, %v", md, err)
+			}
+		}()
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("expected the provider to be called once, called %d times", calls)
+	}
+
+	// a caller waiting for a refresh returns when its context is done
+	manager.current = nil
+	release = make(chan struct{})
+	go func() { _, _ = manager.GetRequestMetadata(context.Background()) }()
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := manager.GetRequestMetadata(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	close(release)
+}
+
+func TestStreamReopenDelay(t *testing.T) {
+	now := time.Now()
+
+	if delay := streamReopenDelay(now.Add(time.Hour)); delay > time.Hour-credentialsRefreshWindow ||
+		delay < time.Hour-credentialsRefreshWindow-time.Second {
+		t.Fatalf("expected the stream to be re-opened before the refresh window, got %v", delay)
+	}
+	if delay := streamReopenDelay(now.Add(10 * time.Second)); delay > 5*time.Second || delay < 4*time.Second {
+		t.Fatalf("expected short-lived credentials to be re-opened half way to expiry, got %v", delay)
+	}
+	if delay := streamReopenDelay(now.Add(-time.Second)); delay != minStreamReopenDelay {
+		t.Fatalf("expected the minimum delay for expired credentials, got %v", delay)
+	}
+}
+
+// tokenAuthenticator issues bearer tokens and authenticates the streams opened with them, failing a stream
+// with an Unauthenticated status when a request is received after its token has expired.
+type tokenAuthenticator struct {
+	mutex    sync.Mutex
+	lifetime time.Duration
+	expiries map[string]time.Time
+	streams  []string
+	rejected int
+}
+
+func (a *tokenAuthenticator) token(_ context.Context) (string, time.Time, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	token := "token-" + strconv.Itoa(len(a.expiries)+1)
+	expiry := time.Now().Add(a.lifetime)
+	a.expiries[token] = expiry
+	return token, expiry, nil
+}
+
+func (a *tokenAuthenticator) interceptStream(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	md, _ := metadata.FromIncomingContext(ss.Context())
+
+	var token string
+	if values := md.Get("authorization"); len(values) > 0 {
+		token = strings.TrimPrefix(values[0], "Bearer ")
+	}
+
+	a.mutex.Lock()
+	expiry, ok := a.expiries[token]
+	a.streams = append(a.streams, token)
+	a.mutex.Unlock()
+
+	if !ok || time.Now().After(expiry) {
+		return a.reject()
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, authenticator: a, expiry: expiry})
+}
+
+func (a *tokenAuthenticator) reject() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.rejected++
+	return status.Error(codes.Unauthenticated, "token expired")
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	authenticator *tokenAuthenticator
+	expiry        time.Time
+}
+
+func (s *authenticatedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if time.Now().After(s.expiry) {
+		return s.authenticator.reject()
+	}
+	return nil
+}
+
+func TestCredentialsExpireDuringOpenStream(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		auth = &tokenAuthenticator{lifetime: 2 * time.Second, expiries: make(map[string]time.Time)}
+	)
+
+	proxy := fakeproxy.New(fakeproxy.WithServerOptions(grpc.StreamInterceptor(auth.interceptStream)))
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	session, err := NewSession(ctx, WithAddress(address), WithPlainText(), WithRequestTimeout(5*time.Second),
+		WithCredentialsProvider(NewBearerTokenProvider(auth.token)))
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	namedMap, err := GetNamedMap[int, string](session, "credentials")
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+	if _, err = namedMap.Put(ctx, 1, "one"); err != nil {
+		t.Fatalf("unable to put: %v", err)
+	}
+
+	auth.mutex.Lock()
+	expiry := auth.expiries["token-1"]
+	auth.mutex.Unlock()
+
+	// the first token expires while the stream is open, so the stream must have been re-opened with a new
+	// token before it expired; wait until between the scheduled re-opens of the following streams
+	time.Sleep(time.Until(expiry) + auth.lifetime/4)
+
+	if _, err = namedMap.Put(ctx, 2, "two"); err != nil {
+		t.Fatalf("unable to put after the first token expired: %v", err)
+	}
+	if value, err := namedMap.Get(ctx, 1); err != nil || value == nil || *value != "one" {
+		t.Fatalf("unable to get after the first token expired: %v, %v", value, err)
+	}
+
+	auth.mutex.Lock()
+	defer auth.mutex.Unlock()
+	if auth.rejected != 0 {
+		t.Fatalf("expected no streams to be rejected, %d rejected", auth.rejected)
+	}
+	if len(auth.streams) < 2 || auth.streams[len(auth.streams)-1] == "token-1" {
+		t.Fatalf("expected the stream to be re-opened with a new token, streams opened with %v", auth.streams)
+	}
+}
@@ -76,8 +76,83 @@ load balance across gRPC proxies. You can turn this off by setting the environme
 To Configure SSL, you must first enable SSL on the gRPC Proxy, see [gRPC Proxy documentation] for details.
 Refer to the section on [NewSession] for more information on setting up a SSL connection on the client.
 
+If your certificates are rotated, for example by cert-manager, use [coherence.WithTLSReload] to re-read the certificate
+and key files on a schedule. Rotated certificates are used for the next reconnect without restarting the process, and a
+[TLSReloaded] session lifecycle event is raised for each reload.
+
+	session, err := coherence.NewSession(ctx, coherence.WithTLSReload(time.Minute))
+
+If your gRPC proxies require call credentials, such as short-lived bearer tokens issued by an identity-aware gateway,
+use [coherence.WithCredentialsProvider]. The credentials are attached to every request and gRPC v1 stream, refreshed
+before they expire, and if the server rejects them new credentials are obtained and the stream is re-opened.
+
+	provider := coherence.NewBearerTokenProvider(func(ctx context.Context) (string, time.Time, error) {
+	    token, err := fetchToken(ctx)
+	    return token.Value, token.Expiry, err
+	})
+	session, err := coherence.NewSession(ctx, coherence.WithCredentialsProvider(provider))
+
 See [SessionOptions] which lists all the options supported by the [Session] API.
 
+When connected to a gRPC v1 proxy, you can use [Session.ServerInfo] to retrieve the Coherence version, protocol version,
+and member id and UUID of the proxy the [Session] is attached to, and [Session.Ping] to measure the round-trip latency to it.
+
+	info, err := session.ServerInfo()
+	if err != nil {
+	    log.Fatal(err)
+	}
+	latency, err := session.Ping(ctx)
+	if err != nil {
+	    log.Fatal(err)
+	}
+	log.Printf("connected to %s member %d, latency %v", info.Version, info.ProxyMemberID, latency)
+
+By default, all cache requests for a [Session] connected to a gRPC v1 proxy are sent over a single gRPC stream. For
+high-throughput workloads you can use the option [coherence.WithStreamCount] to spread requests across multiple streams.
+All the listeners and events for a [NamedMap] or [NamedCache] use a single stream, and if any stream is disconnected all
+the streams are re-created together so that caches and listeners are rebalanced across them.
+
+	session, err := coherence.NewSession(ctx, coherence.WithStreamCount(4))
+
+To stop a large number of concurrent goroutines from overwhelming the proxy, you can limit the number of requests a [Session]
+connected to a gRPC v1 proxy sends concurrently using [coherence.WithMaxInFlight]. Once the limit is reached, requests either
+wait for a slot with the [BlockWhenFull] policy, and are reported as queued in [Session.Metrics], or fail immediately with
+[ErrMaxInFlightExceeded] with the [FailFast] policy. The limit can be overridden for a [NamedMap] or [NamedCache] using
+[coherence.WithCacheMaxInFlight].
+
+	session, err := coherence.NewSession(ctx, coherence.WithMaxInFlight(500, coherence.BlockWhenFull))
+	...
+	orders, err := coherence.GetNamedCache[int, Order](session, "orders", coherence.WithCacheMaxInFlight(50, coherence.FailFast))
+
+So that callers fail fast rather than each waiting for the request timeout when a cluster is degraded, you can enable a
+circuit breaker using [coherence.WithCircuitBreaker], or for an individual [NamedMap] or [NamedCache] using
+[coherence.WithCacheCircuitBreaker]. Once the rate of failed requests reaches the [CircuitBreakerPolicy] failure rate the
+circuit opens and requests fail with [ErrCircuitOpen]. After the open duration a limited number of requests are sent to probe
+the cluster, closing the circuit again if they succeed. Each change of state raises a [CircuitStateChanged] session lifecycle
+event, which can be converted to a [CircuitStateChangedEvent].
+
+	policy := coherence.DefaultCircuitBreakerPolicy()
+	policy.OpenDuration = 10 * time.Second
+	session, err := coherence.NewSession(ctx, coherence.WithCircuitBreaker(policy))
+
+Idempotent read operations, such as Get, GetAll, ContainsKey, Size, KeySetFilter and Aggregate, can be retried with backoff
+when they fail with a transient error, for example during a proxy failover, by setting a [RetryPolicy] using
+[coherence.WithRetryPolicy], or for an individual [NamedMap] or [NamedCache] using [coherence.WithCacheRetryPolicy].
+Transient errors are the gRPC stream being disconnected while waiting for a response, which fails with
+[ErrStreamDisconnected], or the proxy being unavailable. Operations which mutate a map or cache are never retried. When an
+operation fails after it has been retried, the error is a [*RetryError] containing the number of attempts and the final error.
+
+	session, err := coherence.NewSession(ctx, coherence.WithRetryPolicy(coherence.DefaultRetryPolicy()))
+
+To close a [Session] without abandoning in-flight requests, use [Session.Shutdown] rather than [Session.Close]. New requests
+are rejected with [ErrShuttingDown] while Shutdown waits for in-flight requests and streaming queries to complete, or for
+the context to be done, after which all listeners are unsubscribed, all maps, caches and queues are released and the
+[Session] is closed.
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	err = session.Shutdown(ctx)
+
 # Controlling timeouts
 
 Most operations you call require you to supply a [context.Context]. If your context does not contain a deadline,
@@ -99,6 +174,55 @@ You also have the ability to control maximum amount of time, in milliseconds, a
 without successfully reconnecting. For this you use the option [coherence.WithDisconnectTimeout] or the environment
 variable COHERENCE_SESSION_DISCONNECT_TIMEOUT.
 
+When connecting to a gRPC v1 proxy, a network failure may leave a stream open on the client even though
+the server has gone away. To detect this you can enable heartbeats using the option [coherence.WithHeartbeat]. The client
+will send a heartbeat at the interval specified and, if no response is received for the specified number of intervals, the
+stream is closed, a Disconnected lifecycle event is raised and the stream is re-established.
+
+	session, err = coherence.NewSession(ctx, coherence.WithHeartbeat(time.Duration(5) * time.Second, 3))
+
+# Sending request attributes
+
+When connected to a gRPC v1 proxy, you can attach attributes, such as a tenant or correlation id, to individual operations
+by using [coherence.WithRequestAttributes] to create the [context.Context] passed to the operation. The attributes are sent to the
+server in the context of the request, where they are available to server-side interceptors. Any string attributes returned by the
+server in the context of the response can be captured by using [coherence.WithResponseAttributes].
+
+	ctx = coherence.WithRequestAttributes(ctx, map[string]string{"tenant": "acme", "correlationId": id})
+	ctx, responseAttributes := coherence.WithResponseAttributes(ctx)
+
+	_, err = namedMap.Put(ctx, 1, "one")
+	if err != nil {
+	    log.Fatal(err)
+	}
+	fmt.Println(responseAttributes.Get())
+
+# Tracing operations
+
+A [coherence.Tracer] set using [coherence.WithTracer] is notified when each [NamedMap], [NamedCache] and [NamedQueue]
+operation starts and ends. When connected to a gRPC v1 proxy, the [coherence.OperationResult] passed when an operation
+ends includes the request id and the serialized sizes of the requests and responses. Operations that return a channel
+end once all the results have been sent, or when the context is done if the results are not all read.
+
+The package github.com/oracle/coherence-go-client/v2/coherence/oteltrace contains a Tracer which creates an OpenTelemetry
+span for each operation and injects the trace context into the request attributes, so it is propagated to the server.
+It is a separate module, so applications which do not use OpenTelemetry do not depend on it.
+
+	go get github.com/oracle/coherence-go-client/v2/coherence/oteltrace
+
+	session, err := coherence.NewSession(ctx, coherence.WithTracer(oteltrace.New()))
+
+# Collecting metrics
+
+A [Session] keeps counters and latency histograms for each cache or queue and operation, including the number of
+requests, errors, timeouts, bytes sent and received and operations in flight, as well as the number of map events
+received and stream reconnects. Metrics are collected once enabled using [coherence.WithMetrics]. Use [Session.Metrics]
+to obtain a snapshot of the metrics, or [Session.MetricsHandler] to expose them in the Prometheus text format.
+
+	session, err := coherence.NewSession(ctx, coherence.WithMetrics())
+	...
+	http.Handle("/metrics", session.MetricsHandler())
+
 # Setting Log Levels
 
 The Coherence Go client supports setting the following log levels to change verbosity of messages output.
@@ -120,6 +244,27 @@ Where as setting DEBUG, will show ERROR, WARNING, INFO and DEBUG messages.
 Note: Setting to ALL should only be used to diagnose issues as directed by Oracle Support. This level will
 output a large volume of messages.
 
+If you use structured logging, you can use the option [coherence.WithLogger] to supply a [slog.Logger] for a [Session].
+All messages for the [Session] are then emitted as structured records which include a sessionId attribute and, where relevant,
+cache and requestId attributes. The messages emitted are controlled by the level of the logger's handler.
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	session, err := coherence.NewSession(ctx, coherence.WithLogger(logger))
+
+To diagnose protocol-level issues such as the ordering of events or paging, every request and response on the gRPC v1
+streams can be recorded to a file, with timestamps, by setting the environment variable COHERENCE_CAPTURE_FILE to the
+name of the file, to which the session id is added, or by using the option [coherence.WithCapture]. The capture package
+can read a capture, replay it to a client without a cluster, or compare the recorded responses with those from another
+proxy.
+
+	f, err := os.Create("session.capture")
+	if err != nil {
+	    log.Fatal(err)
+	}
+	defer f.Close()
+
+	session, err := coherence.NewSession(ctx, coherence.WithCapture(f))
+
 # Obtaining a NamedMap or NamedCache
 
 Once a session has been created, the [GetNamedMap](session, name, ...options) or [GetNamedCache](session, name, ...options)
@@ -534,6 +679,28 @@ in your main code, create a new [Session] and register the listener
 	// 2023/01/31 11:15:38 closed session 59f3ec81-dda1-41b7-92de-70aad3d26615
 	// **EVENT=session_closed: source=SessionID=59f3ec81-dda1-41b7-92de-70aad3d26615, closed=true, caches=0, maps=0
 
+When a [Session] is disconnected it will attempt to reconnect as governed by a [ReconnectPolicy], which you can
+set using the option [coherence.WithReconnectPolicy]. The policy controls the exponential backoff and jitter between attempts,
+as well as the maximum number of attempts and maximum total time before the [Session] gives up and is closed.
+A ReconnectAttempt event is raised before each attempt, which can be converted to a [ReconnectAttemptEvent] to
+obtain the attempt number and the error from the previous attempt.
+
+	session, err := coherence.NewSession(ctx, coherence.WithReconnectPolicy(coherence.ReconnectPolicy{
+	    InitialBackoff: time.Duration(500) * time.Millisecond,
+	    MaxBackoff:     time.Duration(10) * time.Second,
+	    Multiplier:     2.0,
+	    Jitter:         0.2,
+	    MaxAttempts:    10,
+	    MaxElapsed:     time.Duration(2) * time.Minute,
+	}))
+
+	listener := coherence.NewSessionLifecycleListener().
+	    OnReconnectAttempt(func(e coherence.SessionLifecycleEvent) {
+	        if ra, ok := e.(coherence.ReconnectAttemptEvent); ok {
+	            fmt.Printf("reconnect attempt %d, last error: %v\n", ra.Attempt(), ra.LastError())
+	        }
+	})
+
 # Working with Queues
 
 When connecting to a Coherence CE cluster versions 25.03 or above or commercial 14.1.2.0.+, you have the ability to create two main types of queues, a [NamedQueue] or [NamedDequeue].
@@ -775,6 +942,13 @@ Lastly, when you create a Customer object you must set the Class value matching
 	    log.Fatal(err)
 	}
 
+Values with a @class which are read into an interface type, such as the values of a NamedMap[int, any], are decoded
+into a map[string]any unless a Go type has been registered for the class using [RegisterClass]. Common Java types,
+such as java.math.BigDecimal, java.util.UUID and the java.time classes, are registered by default and are decoded into
+*big.Rat, uuid.UUID, time.Time and time.Duration rather than a map[string]any as in earlier releases.
+
+	err := coherence.RegisterClass[Customer]("customer")
+
 # Using Near Caches
 
 The Coherence Go client allows you to specify a near cache to cache frequently accessed data in your Go application.
@@ -891,6 +1065,37 @@ accessed and created entries.
 	// localCache{name=my-near-cache-high-units, options=localCacheOptions{ttl=0s, highUnits=1000, highUnitsMemory=0B, pruneFactor=0.80, invalidation=ListenAll}, stats=CacheStats{puts=1001, gets=1002, hits=1, misses=1001, missesDuration=4.628931138s,
 	// hitRate=0.0998004, prunes=1, prunesDuration=181.533µs, expires=0, expiresDuration=0s, size=200, memoryUsed=53.2KB}}
 
+# Using in-process maps in tests
+
+Code which depends on the [NamedMap] or [NamedCache] interfaces can be tested without a Coherence cluster by using
+[NewLocalNamedMap] or [NewLocalNamedCache], which return independent in-process implementations. They support expiry,
+map and lifecycle listeners, and evaluate filters, aggregators and the built-in entry processors in process, including
+when used with functions such as [Invoke] and [AggregateFilter].
+
+	namedCache, err := coherence.NewLocalNamedCache[int, Person]("people")
+	if err != nil {
+	    log.Fatal(err)
+	}
+
+	_, err = namedCache.Put(ctx, 1, Person{ID: 1, Name: "Tim", Age: 50})
+	if err != nil {
+	    log.Fatal(err)
+	}
+
+	newAge, err := coherence.Invoke[int, Person, int](ctx, namedCache, 1, processors.Increment("age", 1))
+
+To test how code behaves under failure, a [FaultInjector] can inject latency, errors, dropped responses, stream disconnects
+and delayed or duplicated events, decided using a seed so that tests are repeatable. Use [WithFaultInjector] to inject
+faults into the gRPC transport of a [Session], or [NewFaultyNamedMap] and [NewFaultyNamedCache] to wrap any [NamedMap]
+or [NamedCache].
+
+	injector, err := coherence.NewFaultInjector(coherence.FaultConfig{Seed: 42, ErrorRate: 0.1, DisconnectRate: 0.01})
+	if err != nil {
+	    log.Fatal(err)
+	}
+
+	session, err := coherence.NewSession(ctx, coherence.WithPlainText(), coherence.WithFaultInjector(injector))
+
 [Coherence Documentation]: https://docs.oracle.com/en/middleware/standalone/coherence/14.1.1.2206/develop-applications/introduction-coherence-caches.html
 [examples]: https://github.com/oracle/coherence-go-client/tree/main/examples
 [gRPC Proxy documentation]: https://docs.oracle.com/en/middleware/standalone/coherence/14.1.1.2206/develop-remote-clients/using-coherence-grpc-server.html
@@ -0,0 +1,451 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	// encryptedSerializationPrefix is the prefix of an encrypted value or field, which is followed by
+	// the length of the key id, the key id, the wrapped data key, the nonce and then the ciphertext.
+	encryptedSerializationPrefix = 91
+
+	// encryptTag is the struct tag which marks a field to be encrypted when using WithFieldEncryption.
+	encryptTag = "encrypt"
+
+	dataKeySize    = 32
+	wrappedKeySize = 12 + dataKeySize + 16 // nonce, data key and GCM tag
+)
+
+var (
+	// ErrInvalidEncryption indicates that the options passed to [WithValueEncryption] or [WithFieldEncryption]
+	// are not valid.
+	ErrInvalidEncryption = errors.New("invalid encryption options")
+
+	// ErrInvalidKey indicates that a key is not a valid AES key or that a key id is not known.
+	ErrInvalidKey = errors.New("invalid encryption key")
+
+	// ErrDecryptionFailed indicates that an encrypted value or field could not be decrypted.
+	ErrDecryptionFailed = errors.New("unable to decrypt")
+
+	// ErrEncryptedValueComparison is returned by ContainsValue, ContainsEntry, RemoveMapping and ReplaceMapping
+	// for a [NamedMap] or [NamedCache] with encrypted values, as each encryption of a value is different so the
+	// value passed would never match the value in the cluster.
+	ErrEncryptedValueComparison = errors.New("encrypted values cannot be compared")
+)
+
+// KeyProvider provides the AES keys, which are 16, 24 or 32 bytes long, used to encrypt the data key
+// of each value for [WithValueEncryption] and [WithFieldEncryption]. Each encrypted value records
+// the id of the key used, so keys can be rotated while values encrypted using previous keys can still be read.
+type KeyProvider interface {
+	// CurrentKey returns the id of the key to use to encrypt values, and the key.
+	CurrentKey() (string, []byte, error)
+
+	// Key returns the key for a key id, used to decrypt values.
+	Key(keyID string) ([]byte, error)
+}
+
+// KeyRing is a [KeyProvider] which holds keys in memory. The most recently added key is used to encrypt values,
+// and previous keys are retained so values encrypted using them can still be read.
+type KeyRing struct {
+	mutex   sync.RWMutex
+	keys    map[string][]byte
+	current string
+}
+
+// NewKeyRing returns a new [KeyRing] with an initial key.
+//
+//	keys, err := coherence.NewKeyRing("key-1", key)
+//	...
+//	err = keys.Rotate("key-2", newKey)
+func NewKeyRing(keyID string, key []byte) (*KeyRing, error) {
+	k := &KeyRing{keys: make(map[string][]byte)}
+	if err := k.Rotate(keyID, key); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Rotate adds a key which is used to encrypt values from now on.
+func (k *KeyRing) Rotate(keyID string, key []byte) error {
+	if keyID == "" || len(keyID) > 255 {
+		return fmt.Errorf("%w: key id must be between 1 and 255 bytes", ErrInvalidKey)
+	}
+	if _, err := aes.NewCipher(key); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	k.keys[keyID] = bytes.Clone(key)
+	k.current = keyID
+	return nil
+}
+
+// CurrentKey returns the id of the key to use to encrypt values, and the key.
+func (k *KeyRing) CurrentKey() (string, []byte, error) {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+	return k.current, k.keys[k.current], nil
+}
+
+// Key returns the key for a key id.
+func (k *KeyRing) Key(keyID string) ([]byte, error) {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+	if key, ok := k.keys[keyID]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown key id %q", ErrInvalidKey, keyID)
+}
+
+// ValueEncryption contains the options for encrypting values, set using [WithValueEncryption]
+// or [WithFieldEncryption].
+type ValueEncryption struct {
+	// KeyProvider provides the keys used to encrypt the data keys.
+	KeyProvider KeyProvider
+
+	// FieldsOnly is true if only the struct fields tagged with `encrypt:"true"` are encrypted.
+	FieldsOnly bool
+}
+
+// WithValueEncryption returns a function to encrypt the values of a [NamedMap] or [NamedCache] before they are
+// sent to the cluster, and decrypt them when they are received. Each value is encrypted with a new data key
+// using AES-GCM, and the data key is encrypted using the current key from the [KeyProvider].
+//
+//	keys, err := coherence.NewKeyRing("key-1", key)
+//	namedMap, err := coherence.GetNamedMap[string, Customer](session, "customers",
+//	    coherence.WithValueEncryption(keys))
+//
+// As encrypted values are opaque to the cluster, filters, extractors, entry processors and aggregators
+// which access values cannot be used. Use [WithFieldEncryption] to only encrypt the sensitive fields.
+// As each value is encrypted with a new data key and nonce, ContainsValue, ContainsEntry, RemoveMapping
+// and ReplaceMapping cannot match values and return [ErrEncryptedValueComparison].
+func WithValueEncryption(provider KeyProvider) func(cacheOptions *CacheOptions) {
+	return func(c *CacheOptions) {
+		c.ValueEncryption = &ValueEncryption{KeyProvider: provider}
+	}
+}
+
+// WithFieldEncryption returns a function to encrypt only the fields of the values of a [NamedMap] or [NamedCache]
+// which are tagged with `encrypt:"true"`, so filters, extractors, entry processors and aggregators can still be
+// used on the other fields. Each field is encrypted as for [WithValueEncryption] and stored as a base64 string.
+// The value type must be a struct, or a pointer to a struct, and the format must be "json". Only top-level fields
+// are encrypted. ContainsValue, ContainsEntry, RemoveMapping and ReplaceMapping return [ErrEncryptedValueComparison],
+// as the encrypted fields of the value passed never match those in the cluster.
+//
+//	type Customer struct {
+//	    ID    int    `json:"id"`
+//	    Email string `json:"email" encrypt:"true"`
+//	}
+func WithFieldEncryption(provider KeyProvider) func(cacheOptions *CacheOptions) {
+	return func(c *CacheOptions) {
+		c.ValueEncryption = &ValueEncryption{KeyProvider: provider, FieldsOnly: true}
+	}
+}
+
+// checkValueComparison returns [ErrEncryptedValueComparison] if the values of the map or cache are encrypted.
+func (bc *baseClient[K, V]) checkValueComparison() error {
+	if bc.cacheOpts != nil && bc.cacheOpts.ValueEncryption != nil {
+		return ErrEncryptedValueComparison
+	}
+	return nil
+}
+
+// validateEncryption validates the encryption options for a cache with values of type V using the format.
+func validateEncryption[V any](format string, cOpts *CacheOptions) error {
+	encryption := cOpts.ValueEncryption
+	if encryption.KeyProvider == nil {
+		return fmt.Errorf("%w: key provider must not be nil", ErrInvalidEncryption)
+	}
+	if !encryption.FieldsOnly {
+		return nil
+	}
+
+	if s, ok := cOpts.valueSerializer.(Serializer[V]); ok {
+		format = s.Format()
+	}
+	if format != defaultFormat {
+		return fmt.Errorf("%w: field encryption requires the json format, not %s", ErrInvalidEncryption, format)
+	}
+	if len(encryptedFields[V]()) == 0 {
+		return fmt.Errorf("%w: %v has no fields tagged with encrypt:\"true\"", ErrInvalidEncryption, typeName[V]())
+	}
+	return nil
+}
+
+// encryptedFields returns the JSON names of the fields of V tagged to be encrypted.
+func encryptedFields[V any]() map[string]bool {
+	typ := reflect.TypeOf((*V)(nil)).Elem()
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := make(map[string]bool)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Tag.Get(encryptTag) != "true" || !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = true
+	}
+	return fields
+}
+
+// applyEncryption wraps a value serializer to encrypt values or fields. When encrypting fields, this must
+// be applied before any compression so the fields are encrypted in the serialized JSON, otherwise after.
+func applyEncryption[V any](serializer Serializer[V], encryption *ValueEncryption) Serializer[V] {
+	envelope := envelopeEncryption{provider: encryption.KeyProvider}
+	if encryption.FieldsOnly {
+		return fieldEncryptionSerializer[V]{serializer: serializer, envelope: envelope, fields: encryptedFields[V]()}
+	}
+	return encryptionSerializer[V]{serializer: serializer, envelope: envelope}
+}
+
+// envelopeEncryption encrypts data using a new data key, which is encrypted using a key from the provider.
+type envelopeEncryption struct {
+	provider KeyProvider
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts data using AES-GCM, returning the nonce followed by the ciphertext.
+func seal(aead cipher.AEAD, dst []byte, data []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(append(dst, nonce...), nonce, data, nil), nil
+}
+
+// open decrypts data returned by seal.
+func open(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, ErrDecryptionFailed
+	}
+	result, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+	return result, nil
+}
+
+// encrypt returns the envelope for the data.
+func (e envelopeEncryption) encrypt(data []byte) ([]byte, error) {
+	keyID, key, err := e.provider.CurrentKey()
+	if err != nil {
+		return nil, err
+	}
+	if keyID == "" || len(keyID) > 255 {
+		return nil, fmt.Errorf("%w: key id must be between 1 and 255 bytes", ErrInvalidKey)
+	}
+	keyEncryption, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err = rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	dataEncryption, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]byte, 0, 2+len(keyID)+wrappedKeySize+dataEncryption.NonceSize()+len(data)+dataEncryption.Overhead())
+	result = append(result, encryptedSerializationPrefix, byte(len(keyID)))
+	result = append(result, keyID...)
+	if result, err = seal(keyEncryption, result, dataKey); err != nil {
+		return nil, err
+	}
+	return seal(dataEncryption, result, data)
+}
+
+// decrypt returns the data from an envelope.
+func (e envelopeEncryption) decrypt(envelope []byte) ([]byte, error) {
+	if len(envelope) < 2 || envelope[0] != encryptedSerializationPrefix {
+		return nil, ErrDecryptionFailed
+	}
+	keyIDEnd := 2 + int(envelope[1])
+	if len(envelope) < keyIDEnd+wrappedKeySize {
+		return nil, ErrDecryptionFailed
+	}
+
+	key, err := e.provider.Key(string(envelope[2:keyIDEnd]))
+	if err != nil {
+		return nil, err
+	}
+	keyEncryption, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := open(keyEncryption, envelope[keyIDEnd:keyIDEnd+wrappedKeySize])
+	if err != nil {
+		return nil, err
+	}
+	dataEncryption, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(dataEncryption, envelope[keyIDEnd+wrappedKeySize:])
+}
+
+// encryptionSerializer wraps the value serializer of a cache to encrypt values.
+type encryptionSerializer[T any] struct {
+	serializer Serializer[T]
+	envelope   envelopeEncryption
+}
+
+// Serialize serializes an object of type T and returns the []byte representation.
+func (s encryptionSerializer[T]) Serialize(object T) ([]byte, error) {
+	data, err := s.serializer.Serialize(object)
+	if err != nil {
+		return nil, err
+	}
+	return s.envelope.encrypt(data)
+}
+
+// Deserialize deserialized an object and returns the correct type of T. Values which are not encrypted,
+// such as those stored before encryption was enabled or the results of entry processors, are deserialized as is.
+func (s encryptionSerializer[T]) Deserialize(data []byte) (*T, error) {
+	if len(data) == 0 || data[0] != encryptedSerializationPrefix {
+		return s.serializer.Deserialize(data)
+	}
+
+	decrypted, err := s.envelope.decrypt(data)
+	if err != nil {
+		var zeroValue T
+		return &zeroValue, err
+	}
+	return s.serializer.Deserialize(decrypted)
+}
+
+// Format returns the format used for the serializer.
+func (s encryptionSerializer[T]) Format() string {
+	return s.serializer.Format()
+}
+
+// fieldEncryptionSerializer wraps the JSON value serializer of a cache to encrypt tagged fields.
+type fieldEncryptionSerializer[T any] struct {
+	serializer Serializer[T]
+	envelope   envelopeEncryption
+	fields     map[string]bool
+}
+
+// Serialize serializes an object of type T and returns the []byte representation.
+func (s fieldEncryptionSerializer[T]) Serialize(object T) ([]byte, error) {
+	data, err := s.serializer.Serialize(object)
+	if err != nil || len(data) == 0 {
+		return data, err
+	}
+
+	return s.rewrite(data, func(value json.RawMessage) (json.RawMessage, error) {
+		envelope, err := s.envelope.encrypt(value)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(base64.StdEncoding.EncodeToString(envelope))
+	})
+}
+
+// Deserialize deserialized an object and returns the correct type of T. Fields which are not encrypted,
+// such as those stored before encryption was enabled, are deserialized as is.
+func (s fieldEncryptionSerializer[T]) Deserialize(data []byte) (*T, error) {
+	if len(data) == 0 {
+		return s.serializer.Deserialize(data)
+	}
+
+	decrypted, err := s.rewrite(data, func(value json.RawMessage) (json.RawMessage, error) {
+		var encoded string
+		if json.Unmarshal(value, &encoded) != nil {
+			return value, nil
+		}
+		envelope, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(envelope) == 0 || envelope[0] != encryptedSerializationPrefix {
+			return value, nil
+		}
+		return s.envelope.decrypt(envelope)
+	})
+	if err != nil {
+		var zeroValue T
+		return &zeroValue, err
+	}
+	return s.serializer.Deserialize(decrypted)
+}
+
+// Format returns the format used for the serializer.
+func (s fieldEncryptionSerializer[T]) Format() string {
+	return s.serializer.Format()
+}
+
+// rewrite replaces the values of the encrypted fields in a JSON serialized object using fn,
+// preserving the order of the fields. Other values, such as null, are returned unchanged.
+func (s fieldEncryptionSerializer[T]) rewrite(data []byte, fn func(value json.RawMessage) (json.RawMessage, error)) ([]byte, error) {
+	if data[0] != jsonSerializationPrefix {
+		return nil, fmt.Errorf("invalid serialization prefix %v", data[0])
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data[1:]))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return data, nil
+	}
+
+	result := make([]byte, 0, len(data))
+	result = append(result, jsonSerializationPrefix, '{')
+	for i := 0; decoder.More(); i++ {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		name, _ := token.(string)
+
+		var value json.RawMessage
+		if err = decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		if s.fields[name] && string(value) != "null" {
+			if value, err = fn(value); err != nil {
+				return nil, err
+			}
+		}
+
+		if i > 0 {
+			result = append(result, ',')
+		}
+		key, _ := json.Marshal(name)
+		result = append(append(append(result, key...), ':'), value...)
+	}
+	return append(result, '}'), nil
+}
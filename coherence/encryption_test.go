@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/extractors"
+	"github.com/oracle/coherence-go-client/v2/coherence/filters"
+	"github.com/oracle/coherence-go-client/v2/coherence/testing/fakeproxy"
+)
+
+type encryptedCustomer struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email" encrypt:"true"`
+}
+
+func newTestKeyRing(t *testing.T) *KeyRing {
+	keys, err := NewKeyRing("key-1", bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatalf("unable to create key ring: %v", err)
+	}
+	return keys
+}
+
+func TestKeyRing(t *testing.T) {
+	if _, err := NewKeyRing("key-1", []byte("short")); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected ErrInvalidKey, got %v", err)
+	}
+	if _, err := NewKeyRing("", bytes.Repeat([]byte{1}, 16)); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected ErrInvalidKey for an empty key id, got %v", err)
+	}
+
+	keys := newTestKeyRing(t)
+	if err := keys.Rotate("key-2", bytes.Repeat([]byte{2}, 16)); err != nil {
+		t.Fatalf("unable to rotate: %v", err)
+	}
+	if id, _, _ := keys.CurrentKey(); id != "key-2" {
+		t.Fatalf("expected key-2 to be current, got %s", id)
+	}
+	if _, err := keys.Key("key-1"); err != nil {
+		t.Fatalf("expected key-1 to be retained: %v", err)
+	}
+	if _, err := keys.Key("key-3"); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected ErrInvalidKey, got %v", err)
+	}
+}
+
+func TestValueEncryption(t *testing.T) {
+	var (
+		keys       = newTestKeyRing(t)
+		serializer = applyEncryption(NewSerializer[string]("json"), &ValueEncryption{KeyProvider: keys})
+	)
+
+	data, err := serializer.Serialize("secret")
+	if err != nil {
+		t.Fatalf("unable to serialize: %v", err)
+	}
+	if data[0] != encryptedSerializationPrefix || bytes.Contains(data, []byte("secret")) {
+		t.Fatalf("expected an encrypted value, got %v", data)
+	}
+
+	// values encrypted using previous keys can be read after rotation
+	if err = keys.Rotate("key-2", bytes.Repeat([]byte{2}, 32)); err != nil {
+		t.Fatalf("unable to rotate: %v", err)
+	}
+	value, err := serializer.Deserialize(data)
+	if err != nil || value == nil || *value != "secret" {
+		t.Fatalf("expected secret, got %v, %v", value, err)
+	}
+
+	// values which are not encrypted can still be read
+	plain, _ := NewSerializer[string]("json").Serialize("plain")
+	if value, err = serializer.Deserialize(plain); err != nil || *value != "plain" {
+		t.Fatalf("expected plain, got %v, %v", value, err)
+	}
+
+	// tampered values are rejected
+	data[len(data)-1] ^= 1
+	if _, err = serializer.Deserialize(data); !errors.Is(err, ErrDecryptionFailed) {
+		t.Fatalf("expected ErrDecryptionFailed, got %v", err)
+	}
+}
+
+func TestFieldEncryption(t *testing.T) {
+	var (
+		keys       = newTestKeyRing(t)
+		serializer = applyEncryption(NewSerializer[encryptedCustomer]("json"), &ValueEncryption{KeyProvider: keys, FieldsOnly: true})
+		customer   = encryptedCustomer{ID: 1, Name: "Tim", Email: "tim@example.com"}
+	)
+
+	data, err := serializer.Serialize(customer)
+	if err != nil {
+		t.Fatalf("unable to serialize: %v", err)
+	}
+	if bytes.Contains(data, []byte("tim@example.com")) || !bytes.Contains(data, []byte(`{"id":1,"name":"Tim","email":"`)) {
+		t.Fatalf("expected only the email to be encrypted, got %s", data[1:])
+	}
+
+	value, err := serializer.Deserialize(data)
+	if err != nil || value == nil || *value != customer {
+		t.Fatalf("expected %v, got %v, %v", customer, value, err)
+	}
+
+	// fields which are not encrypted can still be read
+	plain, _ := NewSerializer[encryptedCustomer]("json").Serialize(customer)
+	if value, err = serializer.Deserialize(plain); err != nil || *value != customer {
+		t.Fatalf("expected %v, got %v, %v", customer, value, err)
+	}
+
+	options := &CacheOptions{}
+	WithFieldEncryption(keys)(options)
+	if err = validateEncryption[string]("json", options); !errors.Is(err, ErrInvalidEncryption) {
+		t.Fatalf("expected ErrInvalidEncryption for a type without tagged fields, got %v", err)
+	}
+	if err = validateEncryption[encryptedCustomer]("pof", options); !errors.Is(err, ErrInvalidEncryption) {
+		t.Fatalf("expected ErrInvalidEncryption for pof, got %v", err)
+	}
+	if err = validateEncryption[*encryptedCustomer]("json", options); err != nil {
+		t.Fatalf("expected a pointer to a struct to be valid, got %v", err)
+	}
+}
+
+func TestFieldEncryptionFilter(t *testing.T) {
+	ctx := context.Background()
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	session, err := NewSession(ctx, WithAddress(address), WithPlainText(), WithRequestTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	namedMap, err := GetNamedMap[int, encryptedCustomer](session, "customers", WithFieldEncryption(newTestKeyRing(t)))
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+
+	customers := map[int]encryptedCustomer{
+		1: {ID: 1, Name: "Tim", Email: "tim@example.com"},
+		2: {ID: 2, Name: "Helen", Email: "helen@example.com"},
+	}
+	if err = namedMap.PutAll(ctx, customers); err != nil {
+		t.Fatalf("unable to put all: %v", err)
+	}
+
+	// filters on fields which are not encrypted are evaluated by the proxy
+	var results []encryptedCustomer
+	for e := range namedMap.EntrySetFilter(ctx, filters.Equal(extractors.Extract[string]("name"), "Helen")) {
+		if e.Err != nil {
+			t.Fatalf("unable to get entries: %v", e.Err)
+		}
+		results = append(results, e.Value)
+	}
+	if len(results) != 1 || results[0] != customers[2] {
+		t.Fatalf("expected %v, got %v", customers[2], results)
+	}
+}
+
+func TestEncryptedValueComparison(t *testing.T) {
+	ctx := context.Background()
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	session, err := NewSession(ctx, WithAddress(address), WithPlainText(), WithRequestTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	customer := encryptedCustomer{ID: 1, Name: "Tim", Email: "tim@example.com"}
+	for _, option := range []func(*CacheOptions){WithValueEncryption(newTestKeyRing(t)), WithFieldEncryption(newTestKeyRing(t))} {
+		namedMap, err := GetNamedMap[int, encryptedCustomer](session, "compare", option)
+		if err != nil {
+			t.Fatalf("unable to get map: %v", err)
+		}
+		if _, err = namedMap.Put(ctx, 1, customer); err != nil {
+			t.Fatalf("unable to put: %v", err)
+		}
+
+		// the same value encrypts differently each time so can never match
+		if _, err = namedMap.ContainsValue(ctx, customer); !errors.Is(err, ErrEncryptedValueComparison) {
+			t.Fatalf("expected ErrEncryptedValueComparison for ContainsValue, got %v", err)
+		}
+		if _, err = namedMap.ContainsEntry(ctx, 1, customer); !errors.Is(err, ErrEncryptedValueComparison) {
+			t.Fatalf("expected ErrEncryptedValueComparison for ContainsEntry, got %v", err)
+		}
+		if _, err = namedMap.RemoveMapping(ctx, 1, customer); !errors.Is(err, ErrEncryptedValueComparison) {
+			t.Fatalf("expected ErrEncryptedValueComparison for RemoveMapping, got %v", err)
+		}
+		if _, err = namedMap.ReplaceMapping(ctx, 1, customer, customer); !errors.Is(err, ErrEncryptedValueComparison) {
+			t.Fatalf("expected ErrEncryptedValueComparison for ReplaceMapping, got %v", err)
+		}
+
+		value, err := namedMap.Get(ctx, 1)
+		if err != nil || value == nil || *value != customer {
+			t.Fatalf("expected %v, got %v, %v", customer, value, err)
+		}
+		if err = namedMap.Destroy(ctx); err != nil {
+			t.Fatalf("unable to destroy map: %v", err)
+		}
+	}
+}
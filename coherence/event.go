@@ -55,6 +55,17 @@ const (
 
 	// Closed raised when the session has been closed.
 	Closed SessionLifecycleEventType = "session_closed"
+
+	// ReconnectAttempt raised before each attempt to reconnect a disconnected session.
+	// The event may be converted to a [ReconnectAttemptEvent] to obtain the attempt number and last error.
+	ReconnectAttempt SessionLifecycleEventType = "session_reconnect_attempt"
+
+	// TLSReloaded raised when the TLS certificates for the session have been reloaded after the files changed.
+	TLSReloaded SessionLifecycleEventType = "session_tls_reloaded"
+
+	// CircuitStateChanged raised when the state of a circuit breaker for the session or a cache changes.
+	// The event may be converted to a [CircuitStateChangedEvent] to obtain the new and previous states.
+	CircuitStateChanged SessionLifecycleEventType = "session_circuit_state_changed"
 )
 
 // MapEventType describes an event raised by a cache mutation.
@@ -394,6 +405,9 @@ type SessionLifecycleListener interface {
 	OnClosed(callback func(SessionLifecycleEvent)) SessionLifecycleListener
 	OnDisconnected(callback func(SessionLifecycleEvent)) SessionLifecycleListener
 	OnReconnected(callback func(SessionLifecycleEvent)) SessionLifecycleListener
+	OnReconnectAttempt(callback func(SessionLifecycleEvent)) SessionLifecycleListener
+	OnTLSReloaded(callback func(SessionLifecycleEvent)) SessionLifecycleListener
+	OnCircuitStateChanged(callback func(SessionLifecycleEvent)) SessionLifecycleListener
 	getEmitter() *eventEmitter[SessionLifecycleEventType, SessionLifecycleEvent]
 }
 
@@ -431,14 +445,33 @@ func (sl *sessionLifecycleListener) OnReconnected(callback func(SessionLifecycle
 	return sl.on(Reconnected, callback)
 }
 
+// OnReconnectAttempt registers a callback that will be notified before each attempt to reconnect a [Session].
+// The event may be converted to a [ReconnectAttemptEvent] to obtain the attempt number and last error.
+func (sl *sessionLifecycleListener) OnReconnectAttempt(callback func(SessionLifecycleEvent)) SessionLifecycleListener {
+	return sl.on(ReconnectAttempt, callback)
+}
+
+// OnTLSReloaded registers a callback that will be notified when the TLS certificates for a [Session] are reloaded.
+func (sl *sessionLifecycleListener) OnTLSReloaded(callback func(SessionLifecycleEvent)) SessionLifecycleListener {
+	return sl.on(TLSReloaded, callback)
+}
+
+// OnCircuitStateChanged registers a callback that will be notified when the state of a circuit breaker changes.
+// The event may be converted to a [CircuitStateChangedEvent] to obtain the new and previous states.
+func (sl *sessionLifecycleListener) OnCircuitStateChanged(callback func(SessionLifecycleEvent)) SessionLifecycleListener {
+	return sl.on(CircuitStateChanged, callback)
+}
+
 // OnClosed registers a callback that will be notified when a [Session] is closed.
 func (sl *sessionLifecycleListener) OnClosed(callback func(SessionLifecycleEvent)) SessionLifecycleListener {
 	return sl.on(Closed, callback)
 }
 
-// OnAny registers a callback that will be notified when a [Session] is connected, disconnected, reconnected or closed.
+// OnAny registers a callback that will be notified when a [Session] is connected, disconnected, reconnected,
+// attempting to reconnect, has reloaded its TLS certificates, has a circuit breaker change state or is closed.
 func (sl *sessionLifecycleListener) OnAny(callback func(SessionLifecycleEvent)) SessionLifecycleListener {
-	return sl.on(Closed, callback).OnConnected(callback).OnDisconnected(callback).OnReconnected(callback)
+	return sl.on(Closed, callback).OnConnected(callback).OnDisconnected(callback).OnReconnected(callback).
+		OnReconnectAttempt(callback).OnTLSReloaded(callback).OnCircuitStateChanged(callback)
 }
 
 // MapLifecycleListener allows registering callbacks to be notified when lifecycle events
@@ -1015,7 +1048,7 @@ func (m *mapEventManager[K, V]) ensureStream() (*eventStream, error) {
 		request.Type = proto.MapListenerRequest_INIT
 		err = grpcStream.Send(&request)
 		if err != nil {
-			logMessage(ERROR, "event stream send failed: %s", err)
+			m.session.logAttrs(ERROR, cacheAttrs(m.bc.name), "event stream send failed: %s", err)
 			cancel()
 			return nil, err
 		}
@@ -1031,7 +1064,7 @@ func (m *mapEventManager[K, V]) ensureStream() (*eventStream, error) {
 					statusLocal := status.Code(err)
 					if statusLocal != codes.Canceled {
 						// only log if it's not a cancelled error as this is just the client closing
-						logMessage(ERROR, "event stream recv failed: %s", err)
+						m.session.logAttrs(ERROR, cacheAttrs(m.bc.name), "event stream recv failed: %s", err)
 					}
 					cancel()
 					return
@@ -1041,6 +1074,7 @@ func (m *mapEventManager[K, V]) ensureStream() (*eventStream, error) {
 					{
 						eventResponse := response.GetEvent()
 						var nm = *m.namedMap
+						m.session.metrics.eventReceived(m.bc.name)
 						receivedMapEvent := newMapEvent(nm, eventResponse)
 						for _, id := range eventResponse.FilterIds {
 							filterGroup, groupPresent := m.filterIDToGroup[id]
@@ -1051,7 +1085,7 @@ func (m *mapEventManager[K, V]) ensureStream() (*eventStream, error) {
 
 						key, err := receivedMapEvent.Key()
 						if err != nil {
-							logMessage(WARNING, "Unable to deserialize event key: %s", err)
+							m.session.logAttrs(WARNING, cacheAttrs(m.bc.name), "Unable to deserialize event key: %s", err)
 							cancel()
 							return
 						}
@@ -1201,37 +1235,21 @@ func reRegisterListeners[K comparable, V any](ctx context.Context, namedMap *Nam
 		bc.session.mapMutex.Lock()
 		defer bc.session.mapMutex.Unlock()
 
-		// save the cache names
-		cacheNames := bc.session.cacheIDMap.Keys()
 		err2 := bc.session.ensureConnection()
 		if err2 != nil {
 			return err2
 		}
 
-		// re-create the new stream
-		manager, err1 := newStreamManagerV1(bc.session, cacheServiceProtocol)
-		if err1 == nil {
-			// save the stream manager for a successful V1 client connection
-			bc.session.v1StreamManagerCache = manager
-
-			// reset the filters for V1
-			bc.keyListenersV1 = make(map[K]*listenerGroupV1[K, V], 0)
-			bc.filterListenersV1 = make(map[filters.Filter]*listenerGroupV1[K, V], 0)
-			bc.filterIDToGroupV1 = make(map[int64]*listenerGroupV1[K, V], 0)
-
-			// re-ensure all the caches as the connection has gone and so has the gRPC Proxy
-			for _, c := range cacheNames {
-				cacheID, err3 := bc.session.v1StreamManagerCache.ensureCache(context.Background(), c)
-				if err3 != nil {
-					// unrecoverable
-					bc.session.Close()
-					return err3
-				}
-				bc.session.debugConnection("re-ensureCache cacheId=%v for cache=%v", cacheID, c)
-			}
-		} else {
+		// re-create the stream and re-ensure the caches if the stream has not already been re-established,
+		// which may have been done by heartbeat detection of a dead stream
+		if err1 := bc.session.reconnectCacheStream(); err1 != nil {
 			return fmt.Errorf("unable to re-stablish v1 stream: %v", err1)
 		}
+
+		// reset the filters for V1
+		bc.keyListenersV1 = make(map[K]*listenerGroupV1[K, V], 0)
+		bc.filterListenersV1 = make(map[filters.Filter]*listenerGroupV1[K, V], 0)
+		bc.filterIDToGroupV1 = make(map[int64]*listenerGroupV1[K, V], 0)
 	}
 
 	// re-register key listeners
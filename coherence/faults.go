@@ -0,0 +1,813 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/filters"
+	"github.com/oracle/coherence-go-client/v2/coherence/processors"
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// ErrInjectedFault is the error returned for an injected error when [FaultConfig.Err] is not set.
+	// It has an Unavailable status so that it is treated as a transient failure.
+	ErrInjectedFault = status.Error(codes.Unavailable, "injected fault")
+
+	// ErrResponseDropped is returned by a [NamedMap] created using [NewFaultyNamedMap] or [NewFaultyNamedCache]
+	// when the response to an operation has been dropped. The operation itself has been performed.
+	ErrResponseDropped = errors.New("the response was dropped by fault injection")
+
+	// ErrInvalidFaultConfig indicates that the values specified for a [FaultConfig] are not valid.
+	ErrInvalidFaultConfig = errors.New("fault rates must be between 0 and 1 and durations cannot be negative")
+)
+
+// errInjectedDisconnect is returned when receiving from a gRPC stream which has been disconnected by fault injection.
+var errInjectedDisconnect = status.Error(codes.Unavailable, "stream disconnected by fault injection")
+
+// FaultConfig configures the faults injected by a [FaultInjector]. Each rate is the probability, between 0 and 1,
+// that the fault is injected into a request, response or event, and a rate of zero disables the fault.
+type FaultConfig struct {
+	// Seed seeds the random numbers which decide when faults are injected, so that the same sequence
+	// of requests, responses and events has the same faults injected each time a test is run.
+	Seed int64
+
+	// LatencyRate is the probability that a request is delayed by Latency before it is sent.
+	LatencyRate float64
+	Latency     time.Duration
+
+	// ErrorRate is the probability that a request fails with Err without being sent.
+	ErrorRate float64
+
+	// Err is the error returned for a failed request, defaults to [ErrInjectedFault].
+	Err error
+
+	// DropRate is the probability that the response to a request is dropped after the request has been performed.
+	DropRate float64
+
+	// DisconnectRate is the probability that a stream is disconnected when a response is received.
+	DisconnectRate float64
+
+	// EventDelayRate is the probability that a map event is delayed by EventDelay.
+	EventDelayRate float64
+	EventDelay     time.Duration
+
+	// EventDuplicateRate is the probability that a map event is delivered twice.
+	EventDuplicateRate float64
+}
+
+// FaultStats contains the number of each type of fault injected by a [FaultInjector].
+type FaultStats struct {
+	Delays           int64
+	Errors           int64
+	Drops            int64
+	Disconnects      int64
+	DelayedEvents    int64
+	DuplicatedEvents int64
+}
+
+// String returns a string representation of [FaultStats].
+func (s FaultStats) String() string {
+	return fmt.Sprintf("FaultStats{delays=%d, errors=%d, drops=%d, disconnects=%d, delayedEvents=%d, duplicatedEvents=%d}",
+		s.Delays, s.Errors, s.Drops, s.Disconnects, s.DelayedEvents, s.DuplicatedEvents)
+}
+
+// FaultInjector injects latency, errors, dropped responses, stream disconnects and delayed or duplicated
+// map events, for testing how an application and the client behave under failure without stopping
+// cluster members. A FaultInjector can be used with a [Session] using [WithFaultInjector], so that faults
+// are injected into the gRPC transport and the client's retry, near cache and listener re-registration
+// logic is exercised, or used to wrap any [NamedMap] or [NamedCache] using [NewFaultyNamedMap] or
+// [NewFaultyNamedCache].
+//
+// Whether each fault is injected is decided using random numbers from the [FaultConfig] seed, so a test
+// which performs the same operations in the same order sees the same faults.
+//
+//	injector, err := coherence.NewFaultInjector(coherence.FaultConfig{Seed: 42, DisconnectRate: 0.01})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	session, err := coherence.NewSession(ctx, coherence.WithPlainText(), coherence.WithFaultInjector(injector))
+type FaultInjector struct {
+	config  FaultConfig
+	mutex   sync.Mutex
+	random  *rand.Rand
+	enabled bool
+	stats   FaultStats
+}
+
+// NewFaultInjector returns a new enabled [FaultInjector] for the [FaultConfig].
+func NewFaultInjector(config FaultConfig) (*FaultInjector, error) {
+	for _, rate := range []float64{config.LatencyRate, config.ErrorRate, config.DropRate, config.DisconnectRate,
+		config.EventDelayRate, config.EventDuplicateRate} {
+		if rate < 0 || rate > 1 {
+			return nil, ErrInvalidFaultConfig
+		}
+	}
+	if config.Latency < 0 || config.EventDelay < 0 {
+		return nil, ErrInvalidFaultConfig
+	}
+	if config.Err == nil {
+		config.Err = ErrInjectedFault
+	}
+
+	return &FaultInjector{
+		config:  config,
+		random:  rand.New(rand.NewSource(config.Seed)), //nolint:gosec // deterministic faults are required
+		enabled: true,
+	}, nil
+}
+
+// WithFaultInjector returns a function to set the [FaultInjector] used to inject faults into the gRPC
+// transport of a [Session]. Faults are injected into requests and responses, but not into the requests
+// used to initialize gRPC v1 streams or heartbeats. For testing only.
+func WithFaultInjector(injector *FaultInjector) func(sessionOptions *SessionOptions) {
+	return func(s *SessionOptions) {
+		s.FaultInjector = injector
+	}
+}
+
+// SetEnabled enables or disables the injection of faults, for example to allow a test to set up
+// its data before faults are injected.
+func (f *FaultInjector) SetEnabled(enabled bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.enabled = enabled
+}
+
+// Stats returns the number of each type of fault injected.
+func (f *FaultInjector) Stats() FaultStats {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.stats
+}
+
+// inject returns true if a fault with the rate should be injected, counting it if it is.
+func (f *FaultInjector) inject(rate float64, count *int64) bool {
+	if rate <= 0 {
+		return false
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !f.enabled || f.random.Float64() >= rate {
+		return false
+	}
+	*count++
+	return true
+}
+
+// beforeRequest delays the request and returns an error if a fault should be injected before it is sent.
+func (f *FaultInjector) beforeRequest(ctx context.Context) error {
+	if f.inject(f.config.LatencyRate, &f.stats.Delays) {
+		select {
+		case <-time.After(f.config.Latency):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if f.inject(f.config.ErrorRate, &f.stats.Errors) {
+		return f.config.Err
+	}
+	return nil
+}
+
+// injectRequestFaults delays or fails a gRPC v1 request if a [FaultInjector] has been set. It is called before
+// the stream is locked to send the request, so that the latency of one request does not delay the other requests,
+// responses and heartbeats on the stream.
+func (s *Session) injectRequestFaults(ctx context.Context) error {
+	if f := s.sessOpts.FaultInjector; f != nil {
+		return f.beforeRequest(ctx)
+	}
+	return nil
+}
+
+// dropResponse returns true if the response to a request should be dropped.
+func (f *FaultInjector) dropResponse() bool {
+	return f.inject(f.config.DropRate, &f.stats.Drops)
+}
+
+// disconnect returns true if a stream should be disconnected.
+func (f *FaultInjector) disconnect() bool {
+	return f.inject(f.config.DisconnectRate, &f.stats.Disconnects)
+}
+
+// eventFaults returns the delay for an event, and whether it should be duplicated.
+func (f *FaultInjector) eventFaults() (time.Duration, bool) {
+	var delay time.Duration
+	if f.inject(f.config.EventDelayRate, &f.stats.DelayedEvents) {
+		delay = f.config.EventDelay
+	}
+	return delay, f.inject(f.config.EventDuplicateRate, &f.stats.DuplicatedEvents)
+}
+
+// dialOptions returns the dial options to inject faults into the gRPC transport.
+func (f *FaultInjector) dialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(f.unaryInterceptor),
+		grpc.WithChainStreamInterceptor(f.streamInterceptor),
+	}
+}
+
+// unaryInterceptor injects faults into gRPC v0 requests.
+func (f *FaultInjector) unaryInterceptor(ctx context.Context, method string, req, reply any,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if err := f.beforeRequest(ctx); err != nil {
+		return err
+	}
+	if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+		return err
+	}
+	if f.dropResponse() {
+		return ErrResponseDropped
+	}
+	return nil
+}
+
+// streamInterceptor injects faults into the messages sent and received on gRPC streams.
+func (f *FaultInjector) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &faultyStream{ClientStream: stream, injector: f}, nil
+}
+
+// faultyStream is a gRPC stream with faults injected.
+type faultyStream struct {
+	grpc.ClientStream
+	injector *FaultInjector
+
+	// fields for gRPC v0 streams, where all the messages are the response to a single request
+	received bool  // whether a message has been received
+	err      error // the error ending the stream, if faults were injected
+
+	// fields for gRPC v1 streams, which are received by a goroutine so delayed events do not delay other responses
+	start     sync.Once
+	responses chan *pb1.ProxyResponse
+	done      chan struct{}  // closed once no more responses will be received
+	result    error          // the error which ended the stream, set before done is closed
+	drops     map[int64]bool // whether the responses for each request in progress are dropped
+}
+
+// SendMsg delays or fails the requests sent on gRPC v0 streams. The faults for gRPC v1 requests are injected
+// by [Session.injectRequestFaults] before they are sent, as the stream is shared by concurrent requests.
+func (s *faultyStream) SendMsg(m any) error {
+	if _, ok := m.(*pb1.ProxyRequest); ok {
+		return s.ClientStream.SendMsg(m)
+	}
+	if err := s.injector.beforeRequest(s.Context()); err != nil {
+		return err
+	}
+	return s.ClientStream.SendMsg(m)
+}
+
+// RecvMsg disconnects the stream or drops responses, and delays or duplicates gRPC v1 events. The responses
+// which initialize gRPC v1 streams are never faulty. Whether to drop the responses for a gRPC v1 request is
+// decided once for the request, and all its responses are discarded so that the request times out. A delayed
+// event does not delay the responses received after it, so may be received out of order.
+func (s *faultyStream) RecvMsg(m any) error {
+	resp, ok := m.(*pb1.ProxyResponse)
+	if !ok {
+		return s.recvV0(m)
+	}
+
+	s.start.Do(s.receive)
+	select {
+	case r := <-s.responses:
+		proto.Reset(resp)
+		proto.Merge(resp, r)
+		return nil
+	case <-s.done:
+		return s.result
+	}
+}
+
+// recvV0 receives a message from a gRPC v0 stream. As the messages on a gRPC v0 stream are all part of the
+// response to one request, whether to drop them is decided when the first message is received, and a dropped
+// response fails the stream with [ErrResponseDropped].
+func (s *faultyStream) recvV0(m any) error {
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if s.injector.disconnect() {
+		s.err = errInjectedDisconnect
+	} else if !s.received && s.injector.dropResponse() {
+		s.err = ErrResponseDropped
+	}
+	s.received = true
+	return s.err
+}
+
+// receive starts a goroutine to receive the responses from a gRPC v1 stream and inject faults into them.
+func (s *faultyStream) receive() {
+	s.responses = make(chan *pb1.ProxyResponse)
+	s.done = make(chan struct{})
+	s.drops = make(map[int64]bool)
+
+	go func() {
+		defer close(s.done)
+		for {
+			resp := &pb1.ProxyResponse{}
+			if s.result = s.ClientStream.RecvMsg(resp); s.result != nil {
+				return
+			}
+
+			if resp.GetInit() != nil {
+				s.deliver(resp)
+				continue
+			}
+
+			if s.injector.disconnect() {
+				s.result = errInjectedDisconnect
+				return
+			}
+
+			switch {
+			case resp.GetHeartbeat() != nil:
+				s.deliver(resp)
+			case resp.GetId() == 0 && resp.GetMessage() != nil:
+				s.deliverEvent(resp)
+			case !s.dropped(resp):
+				s.deliver(resp)
+			}
+		}
+	}()
+}
+
+// dropped returns true if the response is for a request whose responses are dropped. The decision is made
+// when the first response for the request is received, and forgotten once the request is complete.
+func (s *faultyStream) dropped(resp *pb1.ProxyResponse) bool {
+	id := resp.GetId()
+	drop, decided := s.drops[id]
+	if !decided {
+		drop = s.injector.dropResponse()
+	}
+
+	if resp.GetComplete() != nil || resp.GetError() != nil {
+		delete(s.drops, id)
+	} else {
+		s.drops[id] = drop
+	}
+	return drop
+}
+
+// deliverEvent delivers an event, after a delay and more than once if faults are injected.
+func (s *faultyStream) deliverEvent(resp *pb1.ProxyResponse) {
+	delay, duplicate := s.injector.eventFaults()
+	deliver := func() {
+		s.deliver(resp)
+		if duplicate {
+			s.deliver(proto.Clone(resp).(*pb1.ProxyResponse))
+		}
+	}
+
+	if delay > 0 {
+		time.AfterFunc(delay, deliver)
+		return
+	}
+	deliver()
+}
+
+// deliver waits for the response to be received by RecvMsg, unless the stream has ended.
+func (s *faultyStream) deliver(resp *pb1.ProxyResponse) {
+	select {
+	case s.responses <- resp:
+	case <-s.done:
+	case <-s.Context().Done():
+	}
+}
+
+// NewFaultyNamedMap returns a [NamedMap] which injects faults, using the [FaultInjector], into the operations
+// performed against the specified [NamedMap] and the events delivered to its listeners. An injected error
+// fails an operation without performing it, a dropped response fails an operation with [ErrResponseDropped]
+// after performing it, and a disconnect fails an operation with [ErrStreamDisconnected] or ends a channel of
+// results early with an entry containing [ErrStreamDisconnected]. Delayed events are delivered asynchronously
+// and so may be delivered out of order.
+//
+// Functions such as [Invoke] and [Aggregate] are run against the wrapped [NamedMap] without faults,
+// use [WithFaultInjector] to inject faults into them.
+//
+//	namedMap, err := coherence.NewLocalNamedMap[int, Person]("people")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	injector, err := coherence.NewFaultInjector(coherence.FaultConfig{Seed: 1, ErrorRate: 0.1})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	service := NewPersonService(coherence.NewFaultyNamedMap(namedMap, injector))
+func NewFaultyNamedMap[K comparable, V any](nm NamedMap[K, V], injector *FaultInjector) NamedMap[K, V] {
+	return newFaultyNamedMap(nm, injector)
+}
+
+// NewFaultyNamedCache returns a [NamedCache] which injects faults, using the [FaultInjector], into the operations
+// performed against the specified [NamedCache], as described for [NewFaultyNamedMap].
+func NewFaultyNamedCache[K comparable, V any](nc NamedCache[K, V], injector *FaultInjector) NamedCache[K, V] {
+	return &faultyNamedCache[K, V]{faultyNamedMap: newFaultyNamedMap[K, V](nc, injector), nc: nc}
+}
+
+// faultyNamedMap is a [NamedMap] which injects faults into the operations against another [NamedMap].
+type faultyNamedMap[K comparable, V any] struct {
+	nm        NamedMap[K, V]
+	injector  *FaultInjector
+	mutex     sync.Mutex
+	listeners map[MapListener[K, V]]*faultyMapListener[K, V]
+}
+
+// faultyNamedCache is a [NamedCache] which injects faults into the operations against another [NamedCache].
+type faultyNamedCache[K comparable, V any] struct {
+	*faultyNamedMap[K, V]
+	nc NamedCache[K, V]
+}
+
+func newFaultyNamedMap[K comparable, V any](nm NamedMap[K, V], injector *FaultInjector) *faultyNamedMap[K, V] {
+	return &faultyNamedMap[K, V]{
+		nm:        nm,
+		injector:  injector,
+		listeners: make(map[MapListener[K, V]]*faultyMapListener[K, V]),
+	}
+}
+
+func (fm *faultyNamedMap[K, V]) getBaseClient() *baseClient[K, V] { //nolint
+	return fm.nm.getBaseClient()
+}
+
+func (fm *faultyNamedMap[K, V]) getMapFunctions() mapFunctions[K, V] { //nolint
+	return fm.nm.getMapFunctions()
+}
+
+// before injects the faults before an operation is performed.
+func (fm *faultyNamedMap[K, V]) before(ctx context.Context) error {
+	if fm.injector.disconnect() {
+		return ErrStreamDisconnected
+	}
+	return fm.injector.beforeRequest(ctx)
+}
+
+// after returns [ErrResponseDropped] if the response to an operation which succeeded should be dropped.
+func (fm *faultyNamedMap[K, V]) after(err error) error {
+	if err == nil && fm.injector.dropResponse() {
+		return ErrResponseDropped
+	}
+	return err
+}
+
+// listener returns the listener which injects event faults for a listener.
+func (fm *faultyNamedMap[K, V]) listener(listener MapListener[K, V]) *faultyMapListener[K, V] {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+
+	l, ok := fm.listeners[listener]
+	if !ok {
+		l = &faultyMapListener[K, V]{MapListener: listener, injector: fm.injector}
+		fm.listeners[listener] = l
+	}
+	return l
+}
+
+// faultyValue runs an operation returning a value with faults injected.
+func faultyValue[K comparable, V, R any](ctx context.Context, fm *faultyNamedMap[K, V], operation func() (R, error)) (R, error) {
+	var zero R
+	if err := fm.before(ctx); err != nil {
+		return zero, err
+	}
+	result, err := operation()
+	if err = fm.after(err); err != nil {
+		return zero, err
+	}
+	return result, nil
+}
+
+// faultyChannel runs an operation returning a channel with faults injected, the channel
+// ends with an entry created by failed if the stream is disconnected.
+func faultyChannel[K comparable, V, T any](ctx context.Context, fm *faultyNamedMap[K, V], failed func(error) T, operation func() <-chan T) <-chan T {
+	if err := fm.before(ctx); err != nil {
+		return closedChannel(failed(err))
+	}
+
+	source := operation()
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for value := range source {
+			if fm.injector.disconnect() {
+				ch <- failed(ErrStreamDisconnected)
+				for range source {
+					// discard the remaining values
+				}
+				return
+			}
+			ch <- value
+		}
+	}()
+	return ch
+}
+
+func (fm *faultyNamedMap[K, V]) failedEntry(err error) *StreamedEntry[K, V] {
+	return &StreamedEntry[K, V]{Err: err}
+}
+
+func (fm *faultyNamedMap[K, V]) failedKey(err error) *StreamedKey[K] {
+	return &StreamedKey[K]{Err: err}
+}
+
+func (fm *faultyNamedMap[K, V]) failedValue(err error) *StreamedValue[V] {
+	return &StreamedValue[V]{Err: err}
+}
+
+// faultyOperation runs an operation which returns only an error with faults injected.
+func (fm *faultyNamedMap[K, V]) faultyOperation(ctx context.Context, operation func() error) error {
+	if err := fm.before(ctx); err != nil {
+		return err
+	}
+	return fm.after(operation())
+}
+
+// AddLifecycleListener adds a [MapLifecycleListener] to the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) AddLifecycleListener(listener MapLifecycleListener[K, V]) {
+	fm.nm.AddLifecycleListener(listener)
+}
+
+// RemoveLifecycleListener removes a [MapLifecycleListener] from the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) RemoveLifecycleListener(listener MapLifecycleListener[K, V]) {
+	fm.nm.RemoveLifecycleListener(listener)
+}
+
+// AddFilterListener adds a [MapListener] with event faults injected to the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) AddFilterListener(ctx context.Context, listener MapListener[K, V], filter filters.Filter) error {
+	return fm.faultyOperation(ctx, func() error {
+		return fm.nm.AddFilterListener(ctx, fm.listener(listener), filter)
+	})
+}
+
+// AddFilterListenerLite adds a lite [MapListener] with event faults injected to the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) AddFilterListenerLite(ctx context.Context, listener MapListener[K, V], filter filters.Filter) error {
+	return fm.faultyOperation(ctx, func() error {
+		return fm.nm.AddFilterListenerLite(ctx, fm.listener(listener), filter)
+	})
+}
+
+// AddKeyListener adds a [MapListener] with event faults injected to the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) AddKeyListener(ctx context.Context, listener MapListener[K, V], key K) error {
+	return fm.faultyOperation(ctx, func() error {
+		return fm.nm.AddKeyListener(ctx, fm.listener(listener), key)
+	})
+}
+
+// AddKeyListenerLite adds a lite [MapListener] with event faults injected to the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) AddKeyListenerLite(ctx context.Context, listener MapListener[K, V], key K) error {
+	return fm.faultyOperation(ctx, func() error {
+		return fm.nm.AddKeyListenerLite(ctx, fm.listener(listener), key)
+	})
+}
+
+// AddListener adds a [MapListener] with event faults injected to the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) AddListener(ctx context.Context, listener MapListener[K, V]) error {
+	return fm.faultyOperation(ctx, func() error {
+		return fm.nm.AddListener(ctx, fm.listener(listener))
+	})
+}
+
+// AddListenerLite adds a lite [MapListener] with event faults injected to the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) AddListenerLite(ctx context.Context, listener MapListener[K, V]) error {
+	return fm.faultyOperation(ctx, func() error {
+		return fm.nm.AddListenerLite(ctx, fm.listener(listener))
+	})
+}
+
+// RemoveFilterListener removes a [MapListener] from the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) RemoveFilterListener(ctx context.Context, listener MapListener[K, V], filter filters.Filter) error {
+	return fm.faultyOperation(ctx, func() error {
+		return fm.nm.RemoveFilterListener(ctx, fm.listener(listener), filter)
+	})
+}
+
+// RemoveKeyListener removes a [MapListener] from the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) RemoveKeyListener(ctx context.Context, listener MapListener[K, V], key K) error {
+	return fm.faultyOperation(ctx, func() error {
+		return fm.nm.RemoveKeyListener(ctx, fm.listener(listener), key)
+	})
+}
+
+// RemoveListener removes a [MapListener] from the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) RemoveListener(ctx context.Context, listener MapListener[K, V]) error {
+	return fm.faultyOperation(ctx, func() error {
+		return fm.nm.RemoveListener(ctx, fm.listener(listener))
+	})
+}
+
+// Clear clears the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) Clear(ctx context.Context) error {
+	return fm.faultyOperation(ctx, func() error { return fm.nm.Clear(ctx) })
+}
+
+// Truncate truncates the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) Truncate(ctx context.Context) error {
+	return fm.faultyOperation(ctx, func() error { return fm.nm.Truncate(ctx) })
+}
+
+// Destroy destroys the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) Destroy(ctx context.Context) error {
+	return fm.faultyOperation(ctx, func() error { return fm.nm.Destroy(ctx) })
+}
+
+// Release releases the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) Release() {
+	fm.nm.Release()
+}
+
+// ContainsKey calls ContainsKey on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) ContainsKey(ctx context.Context, key K) (bool, error) {
+	return faultyValue(ctx, fm, func() (bool, error) { return fm.nm.ContainsKey(ctx, key) })
+}
+
+// ContainsValue calls ContainsValue on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) ContainsValue(ctx context.Context, value V) (bool, error) {
+	return faultyValue(ctx, fm, func() (bool, error) { return fm.nm.ContainsValue(ctx, value) })
+}
+
+// ContainsEntry calls ContainsEntry on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) ContainsEntry(ctx context.Context, key K, value V) (bool, error) {
+	return faultyValue(ctx, fm, func() (bool, error) { return fm.nm.ContainsEntry(ctx, key, value) })
+}
+
+// IsEmpty calls IsEmpty on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) IsEmpty(ctx context.Context) (bool, error) {
+	return faultyValue(ctx, fm, func() (bool, error) { return fm.nm.IsEmpty(ctx) })
+}
+
+// EntrySetFilter calls EntrySetFilter on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) EntrySetFilter(ctx context.Context, filter filters.Filter) <-chan *StreamedEntry[K, V] {
+	return faultyChannel(ctx, fm, fm.failedEntry, func() <-chan *StreamedEntry[K, V] { return fm.nm.EntrySetFilter(ctx, filter) })
+}
+
+// EntrySet calls EntrySet on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) EntrySet(ctx context.Context) <-chan *StreamedEntry[K, V] {
+	return faultyChannel(ctx, fm, fm.failedEntry, func() <-chan *StreamedEntry[K, V] { return fm.nm.EntrySet(ctx) })
+}
+
+// Get calls Get on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) Get(ctx context.Context, key K) (*V, error) {
+	return faultyValue(ctx, fm, func() (*V, error) { return fm.nm.Get(ctx, key) })
+}
+
+// GetAll calls GetAll on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) GetAll(ctx context.Context, keys []K) <-chan *StreamedEntry[K, V] {
+	return faultyChannel(ctx, fm, fm.failedEntry, func() <-chan *StreamedEntry[K, V] { return fm.nm.GetAll(ctx, keys) })
+}
+
+// GetOrDefault calls GetOrDefault on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) GetOrDefault(ctx context.Context, key K, def V) (*V, error) {
+	return faultyValue(ctx, fm, func() (*V, error) { return fm.nm.GetOrDefault(ctx, key, def) })
+}
+
+// InvokeAll calls InvokeAll on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) InvokeAll(ctx context.Context, keysOrFilter any, proc processors.Processor) <-chan *StreamedValue[V] {
+	return faultyChannel(ctx, fm, fm.failedValue, func() <-chan *StreamedValue[V] { return fm.nm.InvokeAll(ctx, keysOrFilter, proc) })
+}
+
+// KeySetFilter calls KeySetFilter on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) KeySetFilter(ctx context.Context, filter filters.Filter) <-chan *StreamedKey[K] {
+	return faultyChannel(ctx, fm, fm.failedKey, func() <-chan *StreamedKey[K] { return fm.nm.KeySetFilter(ctx, filter) })
+}
+
+// KeySet calls KeySet on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) KeySet(ctx context.Context) <-chan *StreamedKey[K] {
+	return faultyChannel(ctx, fm, fm.failedKey, func() <-chan *StreamedKey[K] { return fm.nm.KeySet(ctx) })
+}
+
+// Name returns the name of the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) Name() string {
+	return fm.nm.Name()
+}
+
+// GetCacheName returns the cache name of the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) GetCacheName() string {
+	return fm.nm.GetCacheName()
+}
+
+// Put calls Put on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) Put(ctx context.Context, key K, value V) (*V, error) {
+	return faultyValue(ctx, fm, func() (*V, error) { return fm.nm.Put(ctx, key, value) })
+}
+
+// PutAll calls PutAll on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) PutAll(ctx context.Context, entries map[K]V) error {
+	return fm.faultyOperation(ctx, func() error { return fm.nm.PutAll(ctx, entries) })
+}
+
+// PutAllWithExpiry calls PutAllWithExpiry on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) PutAllWithExpiry(ctx context.Context, entries map[K]V, ttl time.Duration) error {
+	return fm.faultyOperation(ctx, func() error { return fm.nm.PutAllWithExpiry(ctx, entries, ttl) })
+}
+
+// PutIfAbsent calls PutIfAbsent on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) PutIfAbsent(ctx context.Context, key K, value V) (*V, error) {
+	return faultyValue(ctx, fm, func() (*V, error) { return fm.nm.PutIfAbsent(ctx, key, value) })
+}
+
+// Remove calls Remove on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) Remove(ctx context.Context, key K) (*V, error) {
+	return faultyValue(ctx, fm, func() (*V, error) { return fm.nm.Remove(ctx, key) })
+}
+
+// RemoveMapping calls RemoveMapping on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) RemoveMapping(ctx context.Context, key K, value V) (bool, error) {
+	return faultyValue(ctx, fm, func() (bool, error) { return fm.nm.RemoveMapping(ctx, key, value) })
+}
+
+// Replace calls Replace on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) Replace(ctx context.Context, key K, value V) (*V, error) {
+	return faultyValue(ctx, fm, func() (*V, error) { return fm.nm.Replace(ctx, key, value) })
+}
+
+// ReplaceMapping calls ReplaceMapping on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) ReplaceMapping(ctx context.Context, key K, prevValue V, newValue V) (bool, error) {
+	return faultyValue(ctx, fm, func() (bool, error) { return fm.nm.ReplaceMapping(ctx, key, prevValue, newValue) })
+}
+
+// Size calls Size on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) Size(ctx context.Context) (int, error) {
+	return faultyValue(ctx, fm, func() (int, error) { return fm.nm.Size(ctx) })
+}
+
+// GetSession returns the [Session] of the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) GetSession() *Session {
+	return fm.nm.GetSession()
+}
+
+// ValuesFilter calls ValuesFilter on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) ValuesFilter(ctx context.Context, filter filters.Filter) <-chan *StreamedValue[V] {
+	return faultyChannel(ctx, fm, fm.failedValue, func() <-chan *StreamedValue[V] { return fm.nm.ValuesFilter(ctx, filter) })
+}
+
+// Values calls Values on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) Values(ctx context.Context) <-chan *StreamedValue[V] {
+	return faultyChannel(ctx, fm, fm.failedValue, func() <-chan *StreamedValue[V] { return fm.nm.Values(ctx) })
+}
+
+// IsReady calls IsReady on the wrapped [NamedMap] with faults injected.
+func (fm *faultyNamedMap[K, V]) IsReady(ctx context.Context) (bool, error) {
+	return faultyValue(ctx, fm, func() (bool, error) { return fm.nm.IsReady(ctx) })
+}
+
+// GetNearCacheStats returns the near cache statistics of the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) GetNearCacheStats() CacheStats {
+	return fm.nm.GetNearCacheStats()
+}
+
+// String returns a string representation of the wrapped [NamedMap].
+func (fm *faultyNamedMap[K, V]) String() string {
+	return fmt.Sprintf("FaultyNamedMap{%v}", fm.nm)
+}
+
+// PutWithExpiry calls PutWithExpiry on the wrapped [NamedCache] with faults injected.
+func (fc *faultyNamedCache[K, V]) PutWithExpiry(ctx context.Context, key K, value V, ttl time.Duration) (*V, error) {
+	return faultyValue(ctx, fc.faultyNamedMap, func() (*V, error) { return fc.nc.PutWithExpiry(ctx, key, value, ttl) })
+}
+
+// faultyMapListener is a [MapListener] which delays or duplicates the events dispatched to another listener.
+type faultyMapListener[K comparable, V any] struct {
+	MapListener[K, V]
+	injector *FaultInjector
+}
+
+func (l *faultyMapListener[K, V]) dispatch(event MapEvent[K, V]) { //nolint
+	delay, duplicate := l.injector.eventFaults()
+
+	deliver := func() {
+		l.MapListener.dispatch(event)
+		if duplicate {
+			l.MapListener.dispatch(event)
+		}
+	}
+
+	if delay > 0 {
+		time.AfterFunc(delay, deliver)
+	} else {
+		deliver()
+	}
+}
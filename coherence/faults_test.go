@@ -0,0 +1,396 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/aggregators"
+	"github.com/oracle/coherence-go-client/v2/coherence/testing/fakeproxy"
+)
+
+func newTestFaultInjector(t *testing.T, config FaultConfig) *FaultInjector {
+	injector, err := NewFaultInjector(config)
+	if err != nil {
+		t.Fatalf("unable to create fault injector: %v", err)
+	}
+	return injector
+}
+
+func TestFaultInjectorIsDeterministic(t *testing.T) {
+	if _, err := NewFaultInjector(FaultConfig{ErrorRate: 1.5}); !errors.Is(err, ErrInvalidFaultConfig) {
+		t.Fatalf("expected ErrInvalidFaultConfig, got %v", err)
+	}
+
+	sequence := func() []bool {
+		injector := newTestFaultInjector(t, FaultConfig{Seed: 7, ErrorRate: 0.5})
+		results := make([]bool, 0, 100)
+		for i := 0; i < 100; i++ {
+			results = append(results, injector.beforeRequest(context.Background()) != nil)
+		}
+		if count := injector.Stats().Errors; count == 0 || count == 100 {
+			t.Fatalf("expected some errors to be injected, got %d", count)
+		}
+		return results
+	}
+
+	first, second := sequence(), sequence()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("expected the same faults for the same seed, differed at request %d", i)
+		}
+	}
+
+	injector := newTestFaultInjector(t, FaultConfig{ErrorRate: 1})
+	injector.SetEnabled(false)
+	if err := injector.beforeRequest(context.Background()); err != nil {
+		t.Fatalf("expected no faults when disabled, got %v", err)
+	}
+}
+
+func TestFaultyNamedMap(t *testing.T) {
+	ctx := context.Background()
+	local, err := NewLocalNamedMap[int, string]("faults")
+	if err != nil {
+		t.Fatalf("unable to create map: %v", err)
+	}
+
+	// errors fail the operation without performing it
+	injector := newTestFaultInjector(t, FaultConfig{ErrorRate: 1})
+	namedMap := NewFaultyNamedMap(local, injector)
+	if _, err = namedMap.Put(ctx, 1, "one"); !errors.Is(err, ErrInjectedFault) {
+		t.Fatalf("expected ErrInjectedFault, got %v", err)
+	}
+	if size, _ := local.Size(ctx); size != 0 {
+		t.Fatalf("expected the put not to be performed, got size %d", size)
+	}
+
+	// dropped responses fail the operation after performing it
+	namedMap = NewFaultyNamedMap(local, newTestFaultInjector(t, FaultConfig{DropRate: 1}))
+	if _, err = namedMap.Put(ctx, 1, "one"); !errors.Is(err, ErrResponseDropped) {
+		t.Fatalf("expected ErrResponseDropped, got %v", err)
+	}
+	if size, _ := local.Size(ctx); size != 1 {
+		t.Fatalf("expected the put to be performed, got size %d", size)
+	}
+
+	// disconnects end channels with ErrStreamDisconnected
+	namedMap = NewFaultyNamedMap(local, newTestFaultInjector(t, FaultConfig{DisconnectRate: 1}))
+	var results []error
+	for se := range namedMap.EntrySet(ctx) {
+		results = append(results, se.Err)
+	}
+	if len(results) != 1 || !errors.Is(results[0], ErrStreamDisconnected) {
+		t.Fatalf("expected a single ErrStreamDisconnected, got %v", results)
+	}
+
+	// functions such as Aggregate still work against a wrapped in-process map
+	if count, err := Aggregate(ctx, namedMap, aggregators.Count()); err != nil || *count != 1 {
+		t.Fatalf("expected count of 1, got %v, %v", count, err)
+	}
+}
+
+func TestFaultyNamedMapEvents(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		received atomic.Int32
+		done     = make(chan struct{}, 10)
+	)
+
+	local, err := NewLocalNamedCache[int, string]("events")
+	if err != nil {
+		t.Fatalf("unable to create cache: %v", err)
+	}
+	injector := newTestFaultInjector(t, FaultConfig{EventDelayRate: 1, EventDelay: 10 * time.Millisecond, EventDuplicateRate: 1})
+	namedCache := NewFaultyNamedCache(local, injector)
+
+	listener := NewMapListener[int, string]().OnInserted(func(MapEvent[int, string]) {
+		received.Add(1)
+		done <- struct{}{}
+	})
+	if err = namedCache.AddListener(ctx, listener); err != nil {
+		t.Fatalf("unable to add listener: %v", err)
+	}
+
+	if _, err = namedCache.PutWithExpiry(ctx, 1, "one", time.Minute); err != nil {
+		t.Fatalf("unable to put: %v", err)
+	}
+	if received.Load() != 0 {
+		t.Fatalf("expected the event to be delayed")
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for the event")
+		}
+	}
+
+	if stats := injector.Stats(); stats.DelayedEvents != 1 || stats.DuplicatedEvents != 1 {
+		t.Fatalf("expected one delayed and duplicated event, got %v", stats)
+	}
+
+	if err = namedCache.RemoveListener(ctx, listener); err != nil {
+		t.Fatalf("unable to remove listener: %v", err)
+	}
+}
+
+func TestFaultInjectorTransport(t *testing.T) {
+	ctx := context.Background()
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	injector := newTestFaultInjector(t, FaultConfig{Seed: 1, EventDuplicateRate: 1})
+	session, err := NewSession(ctx, WithAddress(address), WithPlainText(), WithRequestTimeout(5*time.Second),
+		WithFaultInjector(injector))
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	namedMap, err := GetNamedMap[int, string](session, "transport")
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+
+	events := make(chan struct{}, 10)
+	listener := NewMapListener[int, string]().OnAny(func(MapEvent[int, string]) {
+		events <- struct{}{}
+	})
+	if err = namedMap.AddListener(ctx, listener); err != nil {
+		t.Fatalf("unable to add listener: %v", err)
+	}
+
+	if _, err = namedMap.Put(ctx, 1, "one"); err != nil {
+		t.Fatalf("unable to put: %v", err)
+	}
+
+	// the event is duplicated by the transport
+	for i := 0; i < 2; i++ {
+		select {
+		case <-events:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for event %d", i+1)
+		}
+	}
+	if stats := injector.Stats(); stats.DuplicatedEvents != 1 {
+		t.Fatalf("expected one duplicated event, got %v", stats)
+	}
+}
+
+func TestFaultInjectorLatencyIsPerRequest(t *testing.T) {
+	const (
+		requests = 10
+		latency  = 300 * time.Millisecond
+	)
+	ctx := context.Background()
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	injector := newTestFaultInjector(t, FaultConfig{LatencyRate: 1, Latency: latency})
+	injector.SetEnabled(false)
+	session, err := NewSession(ctx, WithAddress(address), WithPlainText(), WithRequestTimeout(10*time.Second),
+		WithFaultInjector(injector))
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	namedMap, err := GetNamedMap[int, string](session, "latency")
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+	injector.SetEnabled(true)
+
+	// concurrent requests are delayed at the same time rather than one after the other
+	var (
+		wg    sync.WaitGroup
+		errs  = make(chan error, requests)
+		start = time.Now()
+	)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			_, err1 := namedMap.Put(ctx, key, "value")
+			errs <- err1
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	elapsed := time.Since(start)
+
+	for err = range errs {
+		if err != nil {
+			t.Fatalf("unable to put: %v", err)
+		}
+	}
+	if stats := injector.Stats(); stats.Delays != requests {
+		t.Fatalf("expected %d delayed requests, got %v", requests, stats)
+	}
+	if elapsed >= requests*latency/2 {
+		t.Fatalf("expected the delayed requests to be concurrent, took %v", elapsed)
+	}
+}
+
+func TestFaultInjectorDisconnect(t *testing.T) {
+	ctx := context.Background()
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	injector := newTestFaultInjector(t, FaultConfig{DisconnectRate: 1})
+	injector.SetEnabled(false)
+
+	// heartbeats are required for the stream to be re-opened when it is disconnected
+	session, err := NewSession(ctx, WithAddress(address), WithPlainText(), WithRequestTimeout(5*time.Second),
+		WithHeartbeat(time.Second, 3), WithFaultInjector(injector))
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	namedMap, err := GetNamedMap[int, string](session, "disconnect")
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+
+	events := make(chan struct{}, 10)
+	listener := NewMapListener[int, string]().OnAny(func(MapEvent[int, string]) {
+		events <- struct{}{}
+	})
+	if err = namedMap.AddListener(ctx, listener); err != nil {
+		t.Fatalf("unable to add listener: %v", err)
+	}
+
+	// the stream is disconnected when the response is received
+	injector.SetEnabled(true)
+	_, _ = namedMap.Put(ctx, 1, "one")
+	injector.SetEnabled(false)
+	if stats := injector.Stats(); stats.Disconnects != 1 {
+		t.Fatalf("expected one disconnect, got %v", stats)
+	}
+
+	// the listener is re-registered once the session has reconnected
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		_, err = namedMap.Put(ctx, 2, "two")
+		if err == nil {
+			select {
+			case <-events:
+				return
+			case <-time.After(500 * time.Millisecond):
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected an event after reconnecting, last error %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestFaultInjectorTransportResponses(t *testing.T) {
+	ctx := context.Background()
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	getMap := func(injector *FaultInjector) NamedMap[int, string] {
+		session, err := NewSession(ctx, WithAddress(address), WithPlainText(),
+			WithRequestTimeout(300*time.Millisecond), WithFaultInjector(injector))
+		if err != nil {
+			t.Fatalf("unable to create session: %v", err)
+		}
+		t.Cleanup(session.Close)
+
+		namedMap, err := GetNamedMap[int, string](session, "responses")
+		if err != nil {
+			t.Fatalf("unable to get map: %v", err)
+		}
+		return namedMap
+	}
+
+	injector := newTestFaultInjector(t, FaultConfig{Seed: 3, DropRate: 0.5})
+	injector.SetEnabled(false)
+	namedMap := getMap(injector)
+	keys := []int{1, 2, 3}
+	if err = namedMap.PutAll(ctx, map[int]string{1: "one", 2: "two", 3: "three"}); err != nil {
+		t.Fatalf("unable to put all: %v", err)
+	}
+
+	// all the responses to a request are dropped, so a request never returns partial results
+	injector.SetEnabled(true)
+	for i := 0; i < 10; i++ {
+		var (
+			count int
+			err   error
+		)
+		for se := range namedMap.GetAll(ctx, keys) {
+			if se.Err != nil {
+				err = se.Err
+				break
+			}
+			count++
+		}
+		if err == nil && count != len(keys) {
+			t.Fatalf("expected %d entries or an error, got %d entries", len(keys), count)
+		}
+	}
+	if stats := injector.Stats(); stats.Drops == 0 || stats.Drops == 10 {
+		t.Fatalf("expected some responses to be dropped, got %v", stats)
+	}
+
+	injector = newTestFaultInjector(t, FaultConfig{EventDelayRate: 1, EventDelay: 2 * time.Second})
+	namedMap = getMap(injector)
+	events := make(chan struct{}, 10)
+	listener := NewMapListener[int, string]().OnAny(func(MapEvent[int, string]) {
+		events <- struct{}{}
+	})
+	if err = namedMap.AddListener(ctx, listener); err != nil {
+		t.Fatalf("unable to add listener: %v", err)
+	}
+
+	// a delayed event does not delay the responses received after it
+	if _, err = namedMap.Put(ctx, 4, "four"); err != nil {
+		t.Fatalf("unable to put: %v", err)
+	}
+	if _, err = namedMap.Get(ctx, 4); err != nil {
+		t.Fatalf("expected the response not to be delayed by the event, got %v", err)
+	}
+	select {
+	case <-events:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the delayed event")
+	}
+	if stats := injector.Stats(); stats.DelayedEvents != 1 {
+		t.Fatalf("expected one delayed event, got %v", stats)
+	}
+}
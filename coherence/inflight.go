@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// MaxInFlightPolicy determines what happens to a request when the maximum number of in-flight requests has been reached.
+type MaxInFlightPolicy int
+
+const (
+	// BlockWhenFull blocks a request until another request completes, or the context deadline or request timeout is reached.
+	BlockWhenFull MaxInFlightPolicy = iota
+
+	// FailFast fails a request immediately with [ErrMaxInFlightExceeded].
+	FailFast
+)
+
+var (
+	// ErrMaxInFlightExceeded indicates that the maximum number of in-flight requests has been reached
+	// and the [FailFast] policy is in use.
+	ErrMaxInFlightExceeded = errors.New("the maximum number of in-flight requests has been reached")
+
+	// ErrInvalidMaxInFlight indicates that the maximum number of in-flight requests is negative.
+	ErrInvalidMaxInFlight = errors.New("maximum in-flight requests must not be negative")
+)
+
+// WithMaxInFlight returns a function to set the maximum number of requests a [Session] connected to a gRPC v1
+// proxy sends concurrently, and the policy applied to requests once the limit is reached. Requests waiting
+// because of the [BlockWhenFull] policy are reported as queued in [Session.Metrics]. A limit of zero, the
+// default, means there is no limit. The limit can be overridden for a [NamedMap] or [NamedCache] using
+// [WithCacheMaxInFlight].
+func WithMaxInFlight(limit int, policy MaxInFlightPolicy) func(sessionOptions *SessionOptions) {
+	return func(s *SessionOptions) {
+		s.MaxInFlight = limit
+		s.MaxInFlightPolicy = policy
+	}
+}
+
+// WithCacheMaxInFlight returns a function to set the maximum number of concurrent requests for a [NamedMap]
+// or [NamedCache], and the policy applied to requests once the limit is reached. The requests for the map
+// or cache are then limited separately from, rather than in addition to, those set using [WithMaxInFlight].
+func WithCacheMaxInFlight(limit int, policy MaxInFlightPolicy) func(cacheOptions *CacheOptions) {
+	return func(c *CacheOptions) {
+		c.MaxInFlight = limit
+		c.MaxInFlightPolicy = policy
+	}
+}
+
+// inFlightLimiter limits the number of concurrent requests.
+type inFlightLimiter struct {
+	session *Session
+	slots   chan struct{}
+	policy  MaxInFlightPolicy
+}
+
+// newInFlightLimiter returns a limiter for the limit and policy, or nil if there is no limit.
+func newInFlightLimiter(session *Session, limit int, policy MaxInFlightPolicy) *inFlightLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return &inFlightLimiter{session: session, slots: make(chan struct{}, limit), policy: policy}
+}
+
+// acquire acquires a slot for a request, waiting according to the policy, and returns the function to release it.
+func (l *inFlightLimiter) acquire(ctx context.Context, metrics *operationMetrics) (func(), error) {
+	select {
+	case l.slots <- struct{}{}:
+		return l.releaseFunc(), nil
+	default:
+	}
+
+	if l.policy == FailFast {
+		return nil, ErrMaxInFlightExceeded
+	}
+
+	metrics.addQueued(1)
+	defer metrics.addQueued(-1)
+
+	newCtx, cancel := l.session.ensureContext(ctx)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return l.releaseFunc(), nil
+	case <-newCtx.Done():
+		return nil, newCtx.Err()
+	}
+}
+
+// releaseFunc returns a function to release a slot which only has an effect the first time it is called.
+func (l *inFlightLimiter) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-l.slots
+		})
+	}
+}
+
+// admitRequest admits a request made by the operation being recorded in the context, acquiring an in-flight
+// slot and checking the circuit breaker if the operation is subject to either. It returns the function to
+// release the slot, or nil if there is no limit.
+func admitRequest(ctx context.Context) (func(), error) {
+	op, ok := ctx.Value(operationKey{}).(*operationRecord)
+	if !ok {
+		return nil, nil
+	}
+
+	var release func()
+	if op.limiter != nil {
+		var err error
+		if release, err = op.limiter.acquire(ctx, op.metrics); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := op.allow(); err != nil {
+		if release != nil {
+			release()
+		}
+		return nil, err
+	}
+
+	return release, nil
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newInFlightTestSession(limit int, policy MaxInFlightPolicy) *Session {
+	session := newTestSession(WithRequestTimeout(time.Second))
+	session.limiter = newInFlightLimiter(session, limit, policy)
+	return session
+}
+
+func TestMaxInFlightFailFast(t *testing.T) {
+	session := newInFlightTestSession(1, FailFast)
+
+	ctx, op := session.startOperation(context.Background(), "test", "Get", false)
+	defer op.end(nil)
+
+	release, err := admitRequest(ctx)
+	if err != nil || release == nil {
+		t.Fatalf("expected to acquire a slot, got %v", err)
+	}
+
+	if _, err = admitRequest(ctx); !errors.Is(err, ErrMaxInFlightExceeded) {
+		t.Fatalf("expected ErrMaxInFlightExceeded, got %v", err)
+	}
+
+	// releasing more than once has no effect
+	release()
+	release()
+
+	if release, err = admitRequest(ctx); err != nil {
+		t.Fatalf("expected to acquire a slot after release, got %v", err)
+	}
+	release()
+
+	// requests which are not part of an operation are not limited
+	if release, err = admitRequest(context.Background()); release != nil || err != nil {
+		t.Fatalf("expected no limit outside an operation, got %v", err)
+	}
+}
+
+func TestMaxInFlightBlockWhenFull(t *testing.T) {
+	session := newInFlightTestSession(1, BlockWhenFull)
+
+	ctx, op := session.startOperation(context.Background(), "test", "Get", false)
+	defer op.end(nil)
+
+	release, err := admitRequest(ctx)
+	if err != nil {
+		t.Fatalf("expected to acquire a slot, got %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		release2, err1 := admitRequest(ctx)
+		if err1 == nil {
+			release2()
+		}
+		done <- err1
+	}()
+
+	queued := func() int64 {
+		return session.Metrics().Operations[0].Queued
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for queued() != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if queued() != 1 {
+		t.Fatalf("expected a queued request, got %d", queued())
+	}
+
+	release()
+
+	if err = <-done; err != nil {
+		t.Fatalf("expected the queued request to acquire a slot, got %v", err)
+	}
+	if queued() != 0 {
+		t.Fatalf("expected no queued requests, got %d", queued())
+	}
+
+	// a queued request fails when the context deadline is reached
+	release, _ = admitRequest(ctx)
+	defer release()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err = admitRequest(timeoutCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestMaxInFlightCacheOverride(t *testing.T) {
+	session := newInFlightTestSession(1, FailFast)
+
+	bc := &baseClient[int, string]{
+		session: session,
+		name:    "test",
+		limiter: newInFlightLimiter(session, 2, FailFast),
+	}
+
+	_, op := bc.startOperation(context.Background(), "Get")
+	defer op.end(nil)
+	if op.limiter != bc.limiter {
+		t.Fatalf("expected the cache limiter to override the session limiter")
+	}
+
+	_, err := NewSession(context.Background(), WithMaxInFlight(-1, FailFast))
+	if !errors.Is(err, ErrInvalidMaxInFlight) {
+		t.Fatalf("expected ErrInvalidMaxInFlight, got %v", err)
+	}
+}
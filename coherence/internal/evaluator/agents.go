@@ -0,0 +1,397 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package evaluator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	aggregatorPackage = "aggregator."
+	processorPackage  = "processor."
+	bigDecimalClass   = "math.BigDec"
+)
+
+// ErrMissingAgent indicates that the aggregator or entry processor of a request is missing.
+var ErrMissingAgent = errors.New("the aggregator or entry processor is missing")
+
+// Aggregate runs an aggregator against the entries and returns the serialized result.
+func Aggregate(agentData []byte, entries []*Entry) ([]byte, error) {
+	agent, err := ParseObject(agentData)
+	if err != nil {
+		return nil, err
+	}
+	if agent == nil {
+		return nil, ErrMissingAgent
+	}
+	return aggregate(agent, entries)
+}
+
+// aggregate runs a parsed aggregator against the entries.
+func aggregate(agent Object, entries []*Entry) ([]byte, error) {
+	class := ClassName(agent)
+	switch strings.TrimPrefix(class, aggregatorPackage) {
+	case "Count":
+		return Serialize(len(entries))
+	case "PriorityAggregator":
+		inner, err := AsObject(agent["aggregator"])
+		if err != nil {
+			return nil, err
+		}
+		if inner == nil {
+			return nil, ErrMissingAgent
+		}
+		return aggregate(inner, entries)
+	}
+
+	values := make([]any, 0, len(entries))
+	for _, e := range entries {
+		value, err := ExtractFromEntry(agent["extractor"], e)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			values = append(values, value)
+		}
+	}
+
+	switch strings.TrimPrefix(class, aggregatorPackage) {
+	case "DistinctValues":
+		distinct := make([]any, 0, len(values))
+		for _, v := range values {
+			if !contains(distinct, v) {
+				distinct = append(distinct, v)
+			}
+		}
+		return Serialize(distinct)
+	case "ComparableMax", "ComparableMin":
+		var (
+			result any
+			isMax  = strings.HasSuffix(class, "Max")
+		)
+		for _, v := range values {
+			c, ok := compare(v, result)
+			if result == nil || ok && (isMax && c > 0 || !isMax && c < 0) {
+				result = v
+			}
+		}
+		return Serialize(result)
+	case "BigDecimalSum", "BigDecimalAverage":
+		if len(values) == 0 {
+			return Serialize(nil)
+		}
+		var sum float64
+		for _, v := range values {
+			f, ok := normalize(v).(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s requires numeric values but got %v", class, v)
+			}
+			sum += f
+		}
+		if strings.HasSuffix(class, "Average") {
+			sum /= float64(len(values))
+		}
+		return Serialize(map[string]any{"@class": bigDecimalClass, "value": strconv.FormatFloat(sum, 'f', -1, 64)})
+	default:
+		return nil, fmt.Errorf("unsupported aggregator %s", class)
+	}
+}
+
+// Process runs an entry processor against an entry and returns the serialized result. Any update
+// or removal is made to the entry, and the caller must apply it if [Entry.Changed] returns true.
+// Processors which insert other entries, such as ConditionalPutAll, only update the entry itself.
+func Process(agent Object, e *Entry) ([]byte, error) {
+	if agent == nil {
+		return nil, ErrMissingAgent
+	}
+
+	class := ClassName(agent)
+	returnCurrent, _ := agent["return"].(bool)
+
+	switch strings.TrimPrefix(class, processorPackage) {
+	case "ExtractorProcessor":
+		if !e.Present {
+			return Serialize(nil)
+		}
+		value, err := ExtractFromEntry(agent["extractor"], e)
+		if err != nil {
+			return nil, err
+		}
+		return Serialize(value)
+
+	case "ConditionalProcessor":
+		matched, err := evaluateMember(agent, "filter", e)
+		if err != nil || !matched {
+			return Serialize(nil)
+		}
+		inner, err := AsObject(agent["processor"])
+		if err != nil {
+			return nil, err
+		}
+		return Process(inner, e)
+
+	case "CompositeProcessor":
+		processors, _ := agent["processors"].([]any)
+		results := make([]json.RawMessage, 0, len(processors))
+		for _, p := range processors {
+			inner, err := AsObject(p)
+			if err != nil {
+				return nil, err
+			}
+			result, err := Process(inner, e)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result[1:])
+		}
+		return Serialize(results)
+
+	case "ConditionalPut", "ConditionalRemove", "ConditionalPutAll":
+		matched, err := evaluateMember(agent, "filter", e)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			if returnCurrent && e.Present {
+				return e.Value, nil
+			}
+			return Serialize(nil)
+		}
+
+		switch {
+		case strings.HasSuffix(class, "Remove"):
+			e.remove()
+			return Serialize(nil)
+		case strings.HasSuffix(class, "PutAll"):
+			value, ok, err := putAllValue(agent, e)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				e.setValue(value)
+			}
+			return Serialize(nil)
+		default:
+			value, err := Serialize(agent["value"])
+			if err != nil {
+				return nil, err
+			}
+			e.setValue(value)
+			return Serialize(nil)
+		}
+
+	case "NumberIncrementor", "NumberMultiplier":
+		if !e.Present {
+			return Serialize(nil)
+		}
+		manipulator, err := AsObject(agent["manipulator"])
+		if err != nil || manipulator == nil {
+			return nil, fmt.Errorf("%s requires a property manipulator", class)
+		}
+		return updateNumber(class, agent, manipulator, e)
+
+	case "UpdaterProcessor":
+		if !e.Present {
+			return Serialize(false)
+		}
+		target, err := e.ValueObject()
+		if err != nil {
+			return nil, err
+		}
+		if err = update(agent["updater"], target, agent["value"]); err != nil {
+			return nil, err
+		}
+		if err = setObject(e, target); err != nil {
+			return nil, err
+		}
+		return Serialize(true)
+
+	case "PreloadRequest", "TouchProcessor":
+		return Serialize(nil)
+
+	default:
+		return nil, fmt.Errorf("unsupported entry processor %s", class)
+	}
+}
+
+// evaluateMember evaluates the filter held in the named member of an agent against the entry.
+func evaluateMember(agent Object, name string, e *Entry) (bool, error) {
+	filter, err := AsObject(agent[name])
+	if err != nil {
+		return false, err
+	}
+	return Evaluate(filter, e)
+}
+
+// putAllValue returns the serialized value from the entries of a ConditionalPutAll for the entry's key.
+func putAllValue(agent Object, e *Entry) ([]byte, bool, error) {
+	key, err := Decode(e.Key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	wrapper, err := AsObject(agent["entries"])
+	if err != nil || wrapper == nil {
+		return nil, false, err
+	}
+	entries, _ := wrapper["entries"].([]any)
+	for _, entry := range entries {
+		o, err := AsObject(entry)
+		if err != nil {
+			return nil, false, err
+		}
+		if o != nil && equal(o["key"], key) {
+			value, err := Serialize(o["value"])
+			return value, err == nil, err
+		}
+	}
+	return nil, false, nil
+}
+
+// updateNumber increments or multiplies the property of the entry's value identified by the manipulator,
+// returning either the original or the new value of the property.
+func updateNumber(class string, agent, manipulator Object, e *Entry) ([]byte, error) {
+	target, err := e.ValueObject()
+	if err != nil {
+		return nil, err
+	}
+	current, err := extract(manipulator["extractor"], target)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result     json.Number
+		returnPost bool
+	)
+	if strings.HasSuffix(class, "Incrementor") {
+		returnPost, _ = agent["postInc"].(bool)
+		result, err = combineNumbers(current, agent["increment"],
+			func(x, y int64) int64 { return x + y }, func(x, y float64) float64 { return x + y })
+	} else {
+		returnPost, _ = agent["postMultiplication"].(bool)
+		result, err = combineNumbers(current, agent["multiplier"],
+			func(x, y int64) int64 { return x * y }, func(x, y float64) float64 { return x * y })
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = update(manipulator["updater"], target, result); err != nil {
+		return nil, err
+	}
+	if err = setObject(e, target); err != nil {
+		return nil, err
+	}
+
+	if returnPost {
+		if current == nil {
+			current = json.Number("0")
+		}
+		return Serialize(current)
+	}
+	return Serialize(result)
+}
+
+// combineNumbers combines two json numbers, using integer arithmetic if both are integers.
+// A nil current value is treated as zero.
+func combineNumbers(current, operand any, intOp func(x, y int64) int64, floatOp func(x, y float64) float64) (json.Number, error) {
+	x, err := asNumber(current)
+	if err != nil {
+		return "", err
+	}
+	y, err := asNumber(operand)
+	if err != nil {
+		return "", err
+	}
+
+	if xi, err := x.Int64(); err == nil {
+		if yi, err := y.Int64(); err == nil {
+			return json.Number(strconv.FormatInt(intOp(xi, yi), 10)), nil
+		}
+	}
+
+	xf, err := x.Float64()
+	if err != nil {
+		return "", err
+	}
+	yf, err := y.Float64()
+	if err != nil {
+		return "", err
+	}
+	return json.Number(strconv.FormatFloat(floatOp(xf, yf), 'f', -1, 64)), nil
+}
+
+// asNumber returns a deserialized value as a json number.
+func asNumber(value any) (json.Number, error) {
+	switch v := value.(type) {
+	case nil:
+		return "0", nil
+	case json.Number:
+		return v, nil
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'f', -1, 64)), nil
+	default:
+		return "", fmt.Errorf("expected a number but got %v", value)
+	}
+}
+
+// update applies an updater to a target, setting the updated property to the value.
+func update(updater any, target any, value any) error {
+	u, err := AsObject(updater)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return errors.New("the updater is missing")
+	}
+
+	class := ClassName(u)
+	switch strings.TrimPrefix(class, extractorPackage) {
+	case "CompositeUpdater":
+		inner, err := extract(u["extractor"], target)
+		if err != nil {
+			return err
+		}
+		return update(u["updater"], inner, value)
+	case "UniversalUpdater":
+		name, _ := u["name"].(string)
+		return setProperty(target, propertyName(name, "set"), value)
+	default:
+		return fmt.Errorf("unsupported updater %s", class)
+	}
+}
+
+// setProperty sets the named property of a json object, where a name containing periods
+// identifies a property of a nested object.
+func setProperty(target any, name string, value any) error {
+	o, ok := target.(Object)
+	if !ok {
+		return fmt.Errorf("unable to set property %s of %v", name, target)
+	}
+
+	first, rest, nested := strings.Cut(name, ".")
+	if nested {
+		return setProperty(o[first], rest, value)
+	}
+	o[name] = value
+	return nil
+}
+
+// setObject serializes the updated value object and sets it as the value of the entry.
+func setObject(e *Entry, value any) error {
+	data, err := Serialize(value)
+	if err != nil {
+		return err
+	}
+	e.setValue(data)
+	return nil
+}
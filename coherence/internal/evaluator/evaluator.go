@@ -0,0 +1,521 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+// Package evaluator evaluates filters, extractors, comparators, aggregators and entry processors,
+// serialized using the "json" format, against entries whose keys and values are also serialized using
+// the "json" format. It is used by the in-process NamedMap and the fake gRPC proxy so that both
+// evaluate queries in the same way.
+package evaluator
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// jsonSerializationPrefix is the first byte of a value serialized using the "json" format.
+	jsonSerializationPrefix = 21
+
+	filterPackage     = "util.filter."
+	extractorPackage  = "extractor."
+	comparatorPackage = "util.comparator."
+)
+
+// map event ids and the corresponding MapEventFilter mask bits
+const (
+	EntryInserted int32 = 1
+	EntryUpdated  int32 = 2
+	EntryDeleted  int32 = 3
+)
+
+var (
+	// ErrUnsupportedFormat indicates that a value, filter or agent was not serialized using the "json" format.
+	ErrUnsupportedFormat = errors.New("only the json serialization format is supported")
+)
+
+// Object is a filter, extractor, comparator or agent deserialized from json.
+type Object = map[string]any
+
+// Decode deserializes a value serialized using the "json" format, numbers are returned as a [json.Number].
+func Decode(data []byte) (any, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if data[0] != jsonSerializationPrefix {
+		return nil, ErrUnsupportedFormat
+	}
+
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(data[1:]))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Serialize serializes a value using the "json" format.
+func Serialize(value any) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{jsonSerializationPrefix}, data...), nil
+}
+
+// ParseObject deserializes a filter, comparator or agent, returning nil if the data is empty.
+func ParseObject(data []byte) (Object, error) {
+	value, err := Decode(data)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return AsObject(value)
+}
+
+// AsObject returns the value as a json object, or nil if the value is nil.
+func AsObject(value any) (Object, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if o, ok := value.(Object); ok {
+		return o, nil
+	}
+	return nil, fmt.Errorf("expected a json object but got %v", value)
+}
+
+// ClassName returns the class of a json object.
+func ClassName(o Object) string {
+	name, _ := o["@class"].(string)
+	return name
+}
+
+// Entry is a view of a cache entry, which may not be present, whose value is deserialized on demand.
+// Entry processors update the entry in place and [Entry.Changed] reports whether the update must be applied.
+type Entry struct {
+	Key     []byte
+	Value   []byte
+	Present bool
+
+	changed   bool
+	decoded   bool
+	object    any
+	objectErr error
+}
+
+// NewEntry returns a view of an entry with the serialized key and value.
+func NewEntry(key, value []byte, present bool) *Entry {
+	return &Entry{Key: key, Value: value, Present: present}
+}
+
+// ValueObject returns the deserialized value of the entry.
+func (e *Entry) ValueObject() (any, error) {
+	if !e.decoded {
+		e.decoded = true
+		e.object, e.objectErr = Decode(e.Value)
+	}
+	return e.object, e.objectErr
+}
+
+// Changed returns true if an entry processor has updated or removed the entry.
+func (e *Entry) Changed() bool {
+	return e.changed
+}
+
+// setValue sets the serialized value of the entry, making it present.
+func (e *Entry) setValue(value []byte) {
+	e.Value, e.Present, e.changed, e.decoded = value, true, true, false
+}
+
+// remove removes the entry.
+func (e *Entry) remove() {
+	if e.Present {
+		e.Value, e.Present, e.changed, e.decoded = nil, false, true, false
+	}
+}
+
+// Evaluate evaluates a filter against an entry, a nil filter matches every entry.
+func Evaluate(filter Object, e *Entry) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+
+	class := ClassName(filter)
+	switch strings.TrimPrefix(class, filterPackage) {
+	case "AlwaysFilter":
+		return true, nil
+	case "NeverFilter":
+		return false, nil
+	case "PresentFilter":
+		return e.Present, nil
+	case "AllFilter", "AndFilter", "BetweenFilter":
+		return evaluateAll(filter, e, func(results, count int) bool { return results == count })
+	case "AnyFilter", "OrFilter":
+		return evaluateAll(filter, e, func(results, _ int) bool { return results > 0 })
+	case "XorFilter":
+		return evaluateAll(filter, e, func(results, _ int) bool { return results == 1 })
+	case "NotFilter":
+		inner, err := AsObject(filter["filter"])
+		if err != nil {
+			return false, err
+		}
+		result, err := Evaluate(inner, e)
+		return !result, err
+	case "KeyAssociatedFilter", "MapEventFilter":
+		inner, err := AsObject(filter["filter"])
+		if err != nil {
+			return false, err
+		}
+		return Evaluate(inner, e)
+	}
+
+	if !e.Present {
+		return false, nil
+	}
+
+	extracted, err := ExtractFromEntry(filter["extractor"], e)
+	if err != nil {
+		return false, err
+	}
+	value := filter["value"]
+
+	switch strings.TrimPrefix(class, filterPackage) {
+	case "EqualsFilter":
+		return equal(extracted, value), nil
+	case "NotEqualsFilter":
+		return !equal(extracted, value), nil
+	case "GreaterFilter":
+		c, ok := compare(extracted, value)
+		return ok && c > 0, nil
+	case "GreaterEqualsFilter":
+		c, ok := compare(extracted, value)
+		return ok && c >= 0, nil
+	case "LessFilter":
+		c, ok := compare(extracted, value)
+		return ok && c < 0, nil
+	case "LessEqualsFilter":
+		c, ok := compare(extracted, value)
+		return ok && c <= 0, nil
+	case "InFilter":
+		return contains(value, extracted), nil
+	case "ContainsFilter":
+		return contains(extracted, value), nil
+	case "ContainsAllFilter":
+		return containsCount(extracted, value) == length(value), nil
+	case "ContainsAnyFilter":
+		return containsCount(extracted, value) > 0, nil
+	case "IsNullFilter":
+		return extracted == nil, nil
+	case "IsNotNullFilter":
+		return extracted != nil, nil
+	case "LikeFilter":
+		ignoreCase, _ := filter["ignoreCase"].(bool)
+		return matchPattern(likeExpression(fmt.Sprint(value), ignoreCase), extracted)
+	case "RegexFilter":
+		return matchPattern(fmt.Sprint(value), extracted)
+	default:
+		return false, fmt.Errorf("unsupported filter %s", class)
+	}
+}
+
+// evaluateAll evaluates the filters of a composite filter and passes the number which matched to the combiner.
+func evaluateAll(filter Object, e *Entry, combine func(results, count int) bool) (bool, error) {
+	filters, _ := filter["filters"].([]any)
+
+	results := 0
+	for _, f := range filters {
+		inner, err := AsObject(f)
+		if err != nil {
+			return false, err
+		}
+		result, err := Evaluate(inner, e)
+		if err != nil {
+			return false, err
+		}
+		if result {
+			results++
+		}
+	}
+	return combine(results, len(filters)), nil
+}
+
+// MatchesEvent returns true if a listener's filter matches a map event, where the old entry is not
+// present for an insert and the new entry is not present for a delete. If the filter is a MapEventFilter
+// its mask must also include the event. Evaluation errors are treated as not matching.
+func MatchesEvent(filter Object, eventID int32, oldEntry, newEntry *Entry) bool {
+	if ClassName(filter) == filterPackage+"MapEventFilter" {
+		mask, _ := filter["mask"].(json.Number)
+		if bits, err := mask.Int64(); err == nil && bits&(1<<(eventID-1)) == 0 {
+			return false
+		}
+		filter, _ = AsObject(filter["filter"])
+	}
+
+	switch eventID {
+	case EntryInserted:
+		return matchesEntry(filter, newEntry)
+	case EntryUpdated:
+		return matchesEntry(filter, oldEntry) || matchesEntry(filter, newEntry)
+	default:
+		return matchesEntry(filter, oldEntry)
+	}
+}
+
+// matchesEntry returns true if the filter matches the entry, treating evaluation errors as not matching.
+func matchesEntry(filter Object, e *Entry) bool {
+	matched, err := Evaluate(filter, e)
+	return err == nil && matched
+}
+
+// ExtractFromEntry applies an extractor to the value of an entry, a nil extractor returns the value.
+func ExtractFromEntry(extractor any, e *Entry) (any, error) {
+	target, err := e.ValueObject()
+	if err != nil {
+		return nil, err
+	}
+	return extract(extractor, target)
+}
+
+// extract applies an extractor to a target, a nil extractor returns the target.
+func extract(extractor any, target any) (any, error) {
+	e, err := AsObject(extractor)
+	if err != nil || e == nil {
+		return target, err
+	}
+
+	class := ClassName(e)
+	switch strings.TrimPrefix(class, extractorPackage) {
+	case "IdentityExtractor":
+		return target, nil
+	case "UniversalExtractor":
+		name, _ := e["name"].(string)
+		return property(target, name), nil
+	case "ChainedExtractor":
+		extractors, _ := e["extractors"].([]any)
+		for _, inner := range extractors {
+			if target, err = extract(inner, target); err != nil {
+				return nil, err
+			}
+		}
+		return target, nil
+	case "MultiExtractor":
+		extractors, _ := e["extractors"].([]any)
+		results := make([]any, 0, len(extractors))
+		for _, inner := range extractors {
+			result, err := extract(inner, target)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+		return results, nil
+	default:
+		return nil, fmt.Errorf("unsupported extractor %s", class)
+	}
+}
+
+// property returns the named property of a json object, also accepting Java style accessor names.
+func property(target any, name string) any {
+	o, ok := target.(Object)
+	if !ok {
+		return nil
+	}
+	if value, ok := o[name]; ok {
+		return value
+	}
+	return o[propertyName(name, "get", "is")]
+}
+
+// propertyName converts a Java style accessor or mutator name to a property name.
+func propertyName(name string, prefixes ...string) string {
+	name = strings.TrimSuffix(name, "()")
+	for _, prefix := range prefixes {
+		if trimmed, ok := strings.CutPrefix(name, prefix); ok && trimmed != "" {
+			runes := []rune(trimmed)
+			runes[0] = unicode.ToLower(runes[0])
+			return string(runes)
+		}
+	}
+	return name
+}
+
+// normalize converts the json numbers in a value to float64 so that values can be compared.
+func normalize(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case []any:
+		result := make([]any, len(v))
+		for i := range v {
+			result[i] = normalize(v[i])
+		}
+		return result
+	case Object:
+		result := make(Object, len(v))
+		for k, inner := range v {
+			result[k] = normalize(inner)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// equal returns true if two deserialized values are equal.
+func equal(a, b any) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// compare compares two deserialized numbers, strings or booleans, returning false if they are not comparable.
+func compare(a, b any) (int, bool) {
+	switch x := normalize(a).(type) {
+	case float64:
+		if y, ok := normalize(b).(float64); ok {
+			return cmp.Compare(x, y), true
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0, true
+			case y:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// contains returns true if the collection contains the value.
+func contains(collection any, value any) bool {
+	values, _ := collection.([]any)
+	for _, v := range values {
+		if equal(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsCount returns the number of the values which are contained in the collection.
+func containsCount(collection any, values any) int {
+	count := 0
+	list, _ := values.([]any)
+	for _, v := range list {
+		if contains(collection, v) {
+			count++
+		}
+	}
+	return count
+}
+
+// length returns the length of a collection.
+func length(collection any) int {
+	values, _ := collection.([]any)
+	return len(values)
+}
+
+// likeExpression converts a LikeFilter pattern, where % matches any characters and _ matches
+// a single character, to a regular expression.
+func likeExpression(pattern string, ignoreCase bool) string {
+	var sb strings.Builder
+	if ignoreCase {
+		sb.WriteString("(?i)")
+	}
+	sb.WriteString("(?s)^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			sb.WriteString(".*")
+		case '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
+
+// matchPattern returns true if the value is a string which matches the regular expression.
+func matchPattern(expression string, value any) (bool, error) {
+	s, ok := value.(string)
+	if !ok {
+		return false, nil
+	}
+	return regexp.MatchString(expression, s)
+}
+
+// Sort sorts entries using a comparator, only extractor comparators, optionally wrapped in a safe
+// or inverse comparator, are supported. A nil comparator leaves the entries unchanged.
+func Sort(entries []*Entry, comparator Object) error {
+	if comparator == nil {
+		return nil
+	}
+
+	var (
+		descending bool
+		extractor  any
+	)
+
+	for comparator != nil {
+		class := ClassName(comparator)
+		switch strings.TrimPrefix(class, comparatorPackage) {
+		case "SafeComparator", "InverseComparator":
+			if strings.HasSuffix(class, "InverseComparator") {
+				descending = !descending
+			}
+			inner, err := AsObject(comparator["comparator"])
+			if err != nil {
+				return err
+			}
+			comparator = inner
+		case "ExtractorComparator":
+			extractor = comparator["extractor"]
+			comparator = nil
+		default:
+			return fmt.Errorf("unsupported comparator %s", class)
+		}
+	}
+
+	values := make(map[*Entry]any, len(entries))
+	for _, e := range entries {
+		value, err := ExtractFromEntry(extractor, e)
+		if err != nil {
+			return err
+		}
+		values[e] = value
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := values[entries[i]], values[entries[j]]
+		if descending {
+			a, b = b, a
+		}
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		c, _ := compare(a, b)
+		return c < 0
+	})
+	return nil
+}
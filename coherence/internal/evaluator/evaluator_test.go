@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/extractors"
+	"github.com/oracle/coherence-go-client/v2/coherence/filters"
+	"github.com/oracle/coherence-go-client/v2/coherence/processors"
+)
+
+type person struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestEvaluateFilters(t *testing.T) {
+	e := NewEntry(mustSerialize(t, 1), mustSerialize(t, person{1, "Tim", 50}), true)
+	name := extractors.Extract[string]("name")
+
+	tests := []struct {
+		filter   filters.Filter
+		expected bool
+	}{
+		{filters.Always(), true},
+		{filters.Never(), false},
+		{filters.Between(extractors.Extract[int]("age"), 40, 60), true},
+		{filters.In(name, []string{"Helen", "Tim"}), true},
+		{filters.NotEqual(name, "Tim"), false},
+		{filters.Not(filters.IsNil(name)), true},
+		{filters.Regex(name, "^T.m$"), true},
+		{filters.Like(name, "t_M", false), false},
+		{filters.Equal(extractors.Identity[person](), person{1, "Tim", 50}), true},
+	}
+
+	for _, test := range tests {
+		filter := mustParse(t, test.filter)
+		if result, err := Evaluate(filter, e); err != nil || result != test.expected {
+			t.Fatalf("expected %v for %s, got %v, %v", test.expected, ClassName(filter), result, err)
+		}
+	}
+}
+
+func TestProcess(t *testing.T) {
+	age := extractors.Extract[int]("age")
+
+	tests := []struct {
+		processor processors.Processor
+		result    string
+		value     string
+	}{
+		{processors.Increment("age", 5), "55", `{"age":55,"id":1,"name":"Tim"}`},
+		{processors.Increment("age", 5, true), "50", `{"age":55,"id":1,"name":"Tim"}`},
+		{processors.Multiply("age", 2), "100", `{"age":100,"id":1,"name":"Tim"}`},
+		{processors.Update("name", "Timothy"), "true", `{"age":50,"id":1,"name":"Timothy"}`},
+		{processors.ConditionalPut(filters.Greater(age, 60), person{1, "Tom", 20}), "null", ""},
+		{processors.ConditionalPut(filters.Less(age, 60), person{1, "Tom", 20}), "null", `{"age":20,"id":1,"name":"Tom"}`},
+		{processors.ConditionalRemove(filters.Always()), "null", "removed"},
+		{processors.Extractor[string]("name").AndThen(processors.Increment("age", 1)), `["Tim",51]`, `{"age":51,"id":1,"name":"Tim"}`},
+		{processors.Increment("age", 1).When(filters.Never()), "null", ""},
+	}
+
+	for _, test := range tests {
+		e := NewEntry(mustSerialize(t, 1), mustSerialize(t, person{1, "Tim", 50}), true)
+		result, err := Process(mustParse(t, test.processor), e)
+		if err != nil {
+			t.Fatalf("unable to process %v: %v", test.processor, err)
+		}
+		if string(result[1:]) != test.result {
+			t.Fatalf("expected result %s for %v, got %s", test.result, test.processor, result[1:])
+		}
+
+		switch {
+		case test.value == "" && e.Changed():
+			t.Fatalf("expected entry to be unchanged by %v, got %s", test.processor, e.Value[1:])
+		case test.value == "removed" && (e.Present || !e.Changed()):
+			t.Fatalf("expected entry to be removed by %v", test.processor)
+		case test.value != "" && test.value != "removed" && string(e.Value[1:]) != test.value:
+			t.Fatalf("expected value %s for %v, got %s", test.value, test.processor, e.Value[1:])
+		}
+	}
+}
+
+func mustParse(t *testing.T, value any) Object {
+	o, err := ParseObject(mustSerialize(t, value))
+	if err != nil {
+		t.Fatalf("unable to parse %v: %v", value, err)
+	}
+	return o
+}
+
+func mustSerialize(t *testing.T, value any) []byte {
+	data, err := Serialize(value)
+	if err != nil {
+		t.Fatalf("unable to serialize %v: %v", value, err)
+	}
+	return data
+}
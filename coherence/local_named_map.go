@@ -0,0 +1,1153 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/filters"
+	"github.com/oracle/coherence-go-client/v2/coherence/internal/evaluator"
+	"github.com/oracle/coherence-go-client/v2/coherence/processors"
+)
+
+var (
+	_ NamedCache[string, string] = &localNamedMap[string, string]{}
+
+	// ErrInvalidKeysOrFilter indicates that the keys or filter passed to InvokeAll were not a slice of keys,
+	// a [filters.Filter] or nil.
+	ErrInvalidKeysOrFilter = errors.New("keysOrFilter must be a slice of keys, a filters.Filter or nil")
+)
+
+// NewLocalNamedMap returns an in-process implementation of [NamedMap] that does not require a [Session]
+// or a Coherence cluster, for use in tests of code which depends on the [NamedMap] interface.
+//
+// Keys and values are stored serialized using the "json" format, so values returned are copies in the same
+// way as for a [NamedMap] obtained from a [Session]. Map and lifecycle listeners are supported, with events
+// dispatched synchronously once the operation which raised them has completed. Filters, aggregators and
+// entry processors, including those passed to [Invoke], [Aggregate] and related functions, are evaluated in
+// process. The supported filters are the equality, comparison, in, contains, like, regex, is nil, present and
+// logical filters. The supported aggregators are count, distinct, min, max, sum and average, and the supported
+// entry processors are the extractor, conditional, composite, increment, multiply, update, touch and
+// preload processors. Indexes are accepted and ignored.
+//
+// Each call returns a new, empty map. [NamedMap.GetSession] returns nil and [NamedMap.GetNearCacheStats]
+// returns nil as there is no near cache. Only the [WithExpiry] option has an effect, and as for
+// [GetNamedMap] it cannot be used with a NamedMap.
+//
+//	namedMap, err := coherence.NewLocalNamedMap[int, Person]("people")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	service := NewPersonService(namedMap)
+func NewLocalNamedMap[K comparable, V any](name string, options ...func(cache *CacheOptions)) (NamedMap[K, V], error) {
+	cacheOptions := &CacheOptions{}
+	for _, f := range options {
+		f(cacheOptions)
+	}
+
+	if cacheOptions.DefaultExpiry != time.Duration(0) {
+		return nil, errors.New("you cannot use a non-zero expiry for a NamedMap")
+	}
+
+	return newLocalNamedMap[K, V](name, cacheOptions), nil
+}
+
+// NewLocalNamedCache returns an in-process implementation of [NamedCache] that does not require a [Session]
+// or a Coherence cluster. It behaves as described for [NewLocalNamedMap] and in addition supports expiry,
+// either per entry using [NamedCache.PutWithExpiry] or for every entry using the [WithExpiry] option.
+// Expired entries are removed, raising a synthetic delete event, when the cache is next accessed.
+//
+//	namedCache, err := coherence.NewLocalNamedCache[int, Person]("people", coherence.WithExpiry(time.Minute))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func NewLocalNamedCache[K comparable, V any](name string, options ...func(cache *CacheOptions)) (NamedCache[K, V], error) {
+	cacheOptions := &CacheOptions{}
+	for _, f := range options {
+		f(cacheOptions)
+	}
+
+	if cacheOptions.DefaultExpiry < 0 {
+		return nil, fmt.Errorf("invalid expiry %v", cacheOptions.DefaultExpiry)
+	}
+
+	return newLocalNamedMap[K, V](name, cacheOptions), nil
+}
+
+// localNamedMap is the in-process implementation of [NamedMap] and [NamedCache].
+type localNamedMap[K comparable, V any] struct {
+	bc                 *baseClient[K, V]
+	mutex              sync.Mutex
+	entries            map[K]*localEntry[K]
+	keyListeners       map[K]map[MapListener[K, V]]bool // the listeners for a key to whether they are lite
+	filterListeners    map[filters.Filter]*localFilterListeners[K, V]
+	lifecycleListeners []MapLifecycleListener[K, V]
+	events             []func()           // events raised while the map is locked, dispatched by unlock
+	expiries           localExpiryHeap[K] // the entries which expire, so expiring does not scan every entry
+	now                func() time.Time
+}
+
+// localEntry is an entry in a localNamedMap, expiry is zero if the entry does not expire.
+type localEntry[K comparable] struct {
+	key    K
+	binKey []byte
+	value  []byte
+	expiry time.Time
+	index  int // the index of the entry in the expiry heap if it expires
+}
+
+// localExpiryHeap is a heap of the entries which expire, ordered by expiry and then by serialized key,
+// so that entries which expire at the same time are removed in a deterministic order.
+type localExpiryHeap[K comparable] []*localEntry[K]
+
+func (h localExpiryHeap[K]) Len() int {
+	return len(h)
+}
+
+func (h localExpiryHeap[K]) Less(i, j int) bool {
+	if !h[i].expiry.Equal(h[j].expiry) {
+		return h[i].expiry.Before(h[j].expiry)
+	}
+	return bytes.Compare(h[i].binKey, h[j].binKey) < 0
+}
+
+func (h localExpiryHeap[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *localExpiryHeap[K]) Push(x any) {
+	e := x.(*localEntry[K])
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *localExpiryHeap[K]) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return e
+}
+
+// localFilterListeners are the listeners registered with the same filter.
+type localFilterListeners[K comparable, V any] struct {
+	filter    evaluator.Object
+	listeners map[MapListener[K, V]]bool // the listeners to whether they are lite
+}
+
+func newLocalNamedMap[K comparable, V any](name string, cacheOptions *CacheOptions) *localNamedMap[K, V] {
+	return &localNamedMap[K, V]{
+		bc: &baseClient[K, V]{
+			name:            name,
+			format:          defaultFormat,
+			cacheOpts:       cacheOptions,
+			keySerializer:   NewSerializer[K](defaultFormat),
+			valueSerializer: NewSerializer[V](defaultFormat),
+			mutex:           &sync.RWMutex{},
+		},
+		entries:         make(map[K]*localEntry[K]),
+		keyListeners:    make(map[K]map[MapListener[K, V]]bool),
+		filterListeners: make(map[filters.Filter]*localFilterListeners[K, V]),
+		now:             time.Now,
+	}
+}
+
+func (lm *localNamedMap[K, V]) getBaseClient() *baseClient[K, V] { //nolint
+	return lm.bc
+}
+
+func (lm *localNamedMap[K, V]) getMapFunctions() mapFunctions[K, V] { //nolint
+	return lm
+}
+
+// lock locks the map and removes any expired entries, returning an error if the context
+// is done or the map has been released or destroyed.
+func (lm *localNamedMap[K, V]) lock(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	lm.mutex.Lock()
+	if lm.bc.destroyed {
+		lm.mutex.Unlock()
+		return ErrDestroyed
+	}
+	if lm.bc.released {
+		lm.mutex.Unlock()
+		return ErrReleased
+	}
+
+	lm.expire()
+	return nil
+}
+
+// unlock unlocks the map and then dispatches the events raised while it was locked,
+// so that listeners are able to call back into the map.
+func (lm *localNamedMap[K, V]) unlock() {
+	events := lm.events
+	lm.events = nil
+	lm.mutex.Unlock()
+
+	for _, dispatch := range events {
+		dispatch()
+	}
+}
+
+// GetCacheName returns the cache name of the [NamedMap].
+func (lm *localNamedMap[K, V]) GetCacheName() string {
+	return lm.bc.name
+}
+
+// Name returns the name of the [NamedMap].
+func (lm *localNamedMap[K, V]) Name() string {
+	return lm.bc.name
+}
+
+// GetSession returns nil as there is no [Session] associated with an in-process [NamedMap].
+func (lm *localNamedMap[K, V]) GetSession() *Session {
+	return nil
+}
+
+// GetNearCacheStats returns nil as an in-process [NamedMap] does not have a near cache.
+func (lm *localNamedMap[K, V]) GetNearCacheStats() CacheStats {
+	return nil
+}
+
+// IsReady returns true unless the [NamedMap] has been released or destroyed.
+func (lm *localNamedMap[K, V]) IsReady(ctx context.Context) (bool, error) {
+	if err := lm.lock(ctx); err != nil {
+		return false, err
+	}
+	defer lm.unlock()
+	return true, nil
+}
+
+// AddLifecycleListener adds a [MapLifecycleListener] that will receive events (truncated, destroyed or released)
+// that occur against the [NamedMap].
+func (lm *localNamedMap[K, V]) AddLifecycleListener(listener MapLifecycleListener[K, V]) {
+	lm.mutex.Lock()
+	defer lm.mutex.Unlock()
+
+	for _, l := range lm.lifecycleListeners {
+		if l == listener {
+			return
+		}
+	}
+	lm.lifecycleListeners = append(lm.lifecycleListeners, listener)
+}
+
+// RemoveLifecycleListener removes the lifecycle listener that was previously registered to receive events.
+func (lm *localNamedMap[K, V]) RemoveLifecycleListener(listener MapLifecycleListener[K, V]) {
+	lm.mutex.Lock()
+	defer lm.mutex.Unlock()
+
+	for i, l := range lm.lifecycleListeners {
+		if l == listener {
+			lm.lifecycleListeners = append(lm.lifecycleListeners[:i:i], lm.lifecycleListeners[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddFilterListener adds a [MapListener] that will receive events (inserts, updates, deletes) that occur
+// against the [NamedMap] where entries satisfy the specified [filters.Filter], with the key, and optionally,
+// the old-value and new-value included.
+func (lm *localNamedMap[K, V]) AddFilterListener(ctx context.Context, listener MapListener[K, V], filter filters.Filter) error {
+	return lm.addFilterListener(ctx, listener, filter, false)
+}
+
+// AddFilterListenerLite adds a [MapListener] that will receive events (inserts, updates, deletes) that occur
+// against the [NamedMap] where entries satisfy the specified [filters.Filter], with only the key included.
+func (lm *localNamedMap[K, V]) AddFilterListenerLite(ctx context.Context, listener MapListener[K, V], filter filters.Filter) error {
+	return lm.addFilterListener(ctx, listener, filter, true)
+}
+
+// AddListener adds a [MapListener] that will receive events (inserts, updates, deletes) that occur
+// against the [NamedMap], with the key, old-value and new-value included.
+func (lm *localNamedMap[K, V]) AddListener(ctx context.Context, listener MapListener[K, V]) error {
+	return lm.addFilterListener(ctx, listener, nil, false)
+}
+
+// AddListenerLite adds a [MapListener] that will receive events (inserts, updates, deletes) that occur
+// against the [NamedMap], with only the key included.
+func (lm *localNamedMap[K, V]) AddListenerLite(ctx context.Context, listener MapListener[K, V]) error {
+	return lm.addFilterListener(ctx, listener, nil, true)
+}
+
+// AddKeyListener adds a [MapListener] that will receive events (inserts, updates, deletes) that occur
+// against the specified key within the [NamedMap], with the key, old-value and new-value included.
+func (lm *localNamedMap[K, V]) AddKeyListener(ctx context.Context, listener MapListener[K, V], key K) error {
+	return lm.addKeyListener(ctx, listener, key, false)
+}
+
+// AddKeyListenerLite adds a [MapListener] that will receive events (inserts, updates, deletes) that occur
+// against the specified key within the [NamedMap], with only the key included.
+func (lm *localNamedMap[K, V]) AddKeyListenerLite(ctx context.Context, listener MapListener[K, V], key K) error {
+	return lm.addKeyListener(ctx, listener, key, true)
+}
+
+// RemoveFilterListener removes the listener that was previously registered to receive events.
+func (lm *localNamedMap[K, V]) RemoveFilterListener(ctx context.Context, listener MapListener[K, V], filter filters.Filter) error {
+	if err := lm.lock(ctx); err != nil {
+		return err
+	}
+	defer lm.unlock()
+
+	if group, ok := lm.filterListeners[filter]; ok {
+		delete(group.listeners, listener)
+		if len(group.listeners) == 0 {
+			delete(lm.filterListeners, filter)
+		}
+	}
+	return nil
+}
+
+// RemoveKeyListener removes the listener that was previously registered to receive events.
+func (lm *localNamedMap[K, V]) RemoveKeyListener(ctx context.Context, listener MapListener[K, V], key K) error {
+	if err := lm.lock(ctx); err != nil {
+		return err
+	}
+	defer lm.unlock()
+
+	if listeners, ok := lm.keyListeners[key]; ok {
+		delete(listeners, listener)
+		if len(listeners) == 0 {
+			delete(lm.keyListeners, key)
+		}
+	}
+	return nil
+}
+
+// RemoveListener removes the listener that was previously registered to receive events.
+func (lm *localNamedMap[K, V]) RemoveListener(ctx context.Context, listener MapListener[K, V]) error {
+	return lm.RemoveFilterListener(ctx, listener, nil)
+}
+
+func (lm *localNamedMap[K, V]) addFilterListener(ctx context.Context, listener MapListener[K, V], filter filters.Filter, lite bool) error {
+	parsed, err := parseLocal(filter)
+	if err != nil {
+		return err
+	}
+
+	if err = lm.lock(ctx); err != nil {
+		return err
+	}
+	defer lm.unlock()
+
+	group, ok := lm.filterListeners[filter]
+	if !ok {
+		group = &localFilterListeners[K, V]{filter: parsed, listeners: make(map[MapListener[K, V]]bool)}
+		lm.filterListeners[filter] = group
+	}
+	group.listeners[listener] = lite
+	return nil
+}
+
+func (lm *localNamedMap[K, V]) addKeyListener(ctx context.Context, listener MapListener[K, V], key K, lite bool) error {
+	if err := lm.lock(ctx); err != nil {
+		return err
+	}
+	defer lm.unlock()
+
+	listeners, ok := lm.keyListeners[key]
+	if !ok {
+		listeners = make(map[MapListener[K, V]]bool)
+		lm.keyListeners[key] = listeners
+	}
+	listeners[listener] = lite
+
+	// a priming listener receives a synthetic update event with the current value
+	if e, ok := lm.entries[key]; ok && listener.IsPriming() {
+		event := lm.newEvent(evaluator.EntryUpdated, e.binKey, nil, e.value, lite)
+		event.isSynthetic, event.isPriming = true, true
+		lm.events = append(lm.events, func() { listener.dispatch(event) })
+	}
+	return nil
+}
+
+// Clear removes all mappings from the [NamedMap], raising a delete event for each entry.
+func (lm *localNamedMap[K, V]) Clear(ctx context.Context) error {
+	if err := lm.lock(ctx); err != nil {
+		return err
+	}
+	defer lm.unlock()
+
+	for _, e := range lm.sortedEntries() {
+		lm.remove(e.key, false)
+	}
+	return nil
+}
+
+// Truncate removes all mappings from the [NamedMap] without raising delete events.
+func (lm *localNamedMap[K, V]) Truncate(ctx context.Context) error {
+	if err := lm.lock(ctx); err != nil {
+		return err
+	}
+	defer lm.unlock()
+
+	lm.entries = make(map[K]*localEntry[K])
+	lm.expiries = nil
+	lm.lifecycleEvent(Truncated)
+	return nil
+}
+
+// Destroy removes all mappings and destroys the [NamedMap], raising destroyed and released events.
+// The [NamedMap] can no longer be used.
+func (lm *localNamedMap[K, V]) Destroy(ctx context.Context) error {
+	if err := lm.lock(ctx); err != nil {
+		return err
+	}
+	defer lm.unlock()
+
+	lm.entries = make(map[K]*localEntry[K])
+	lm.expiries = nil
+	lm.lifecycleEvent(Destroyed)
+	lm.lifecycleEvent(Released)
+	lm.bc.destroyed = true
+	return nil
+}
+
+// Release releases the [NamedMap], raising a released event. The [NamedMap] can no longer be used.
+func (lm *localNamedMap[K, V]) Release() {
+	if err := lm.lock(context.Background()); err != nil {
+		return
+	}
+	defer lm.unlock()
+
+	lm.lifecycleEvent(Released)
+	lm.bc.released = true
+}
+
+// ContainsKey returns true if the [NamedMap] contains a mapping for the specified key.
+func (lm *localNamedMap[K, V]) ContainsKey(ctx context.Context, key K) (bool, error) {
+	if err := lm.lock(ctx); err != nil {
+		return false, err
+	}
+	defer lm.unlock()
+
+	_, ok := lm.entries[key]
+	return ok, nil
+}
+
+// ContainsValue returns true if the [NamedMap] maps one or more keys to the specified value.
+func (lm *localNamedMap[K, V]) ContainsValue(ctx context.Context, value V) (bool, error) {
+	binValue, err := lm.bc.valueSerializer.Serialize(value)
+	if err != nil {
+		return false, err
+	}
+
+	if err = lm.lock(ctx); err != nil {
+		return false, err
+	}
+	defer lm.unlock()
+
+	for _, e := range lm.entries {
+		if bytes.Equal(e.value, binValue) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ContainsEntry returns true if the [NamedMap] contains a mapping for the specified key and value.
+func (lm *localNamedMap[K, V]) ContainsEntry(ctx context.Context, key K, value V) (bool, error) {
+	binValue, err := lm.bc.valueSerializer.Serialize(value)
+	if err != nil {
+		return false, err
+	}
+
+	if err = lm.lock(ctx); err != nil {
+		return false, err
+	}
+	defer lm.unlock()
+
+	e, ok := lm.entries[key]
+	return ok && bytes.Equal(e.value, binValue), nil
+}
+
+// IsEmpty returns true if the [NamedMap] contains no mappings.
+func (lm *localNamedMap[K, V]) IsEmpty(ctx context.Context) (bool, error) {
+	size, err := lm.Size(ctx)
+	return size == 0, err
+}
+
+// Size returns the number of mappings contained within the [NamedMap].
+func (lm *localNamedMap[K, V]) Size(ctx context.Context) (int, error) {
+	if err := lm.lock(ctx); err != nil {
+		return 0, err
+	}
+	defer lm.unlock()
+
+	return len(lm.entries), nil
+}
+
+// Get returns the value to which the specified key is mapped. V will be nil if there was no value.
+func (lm *localNamedMap[K, V]) Get(ctx context.Context, key K) (*V, error) {
+	if err := lm.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer lm.unlock()
+
+	if e, ok := lm.entries[key]; ok {
+		return lm.bc.valueSerializer.Deserialize(e.value)
+	}
+	return nil, nil
+}
+
+// GetOrDefault will return the value mapped to the specified key,
+// or if there is no mapping, it will return the specified default.
+func (lm *localNamedMap[K, V]) GetOrDefault(ctx context.Context, key K, def V) (*V, error) {
+	value, err := lm.Get(ctx, key)
+	if err == nil && value == nil {
+		return &def, nil
+	}
+	return value, err
+}
+
+// GetAll returns a channel from which the entries for the specified keys which are present can be obtained.
+func (lm *localNamedMap[K, V]) GetAll(ctx context.Context, keys []K) <-chan *StreamedEntry[K, V] {
+	if err := lm.lock(ctx); err != nil {
+		return closedChannel(&StreamedEntry[K, V]{Err: err})
+	}
+	defer lm.unlock()
+
+	results := make([]*StreamedEntry[K, V], 0, len(keys))
+	for _, key := range keys {
+		if e, ok := lm.entries[key]; ok {
+			results = append(results, lm.streamedEntry(e))
+		}
+	}
+	return closedChannel(results...)
+}
+
+// EntrySet returns a channel from which all entries can be obtained.
+func (lm *localNamedMap[K, V]) EntrySet(ctx context.Context) <-chan *StreamedEntry[K, V] {
+	return lm.EntrySetFilter(ctx, nil)
+}
+
+// EntrySetFilter returns a channel from which entries satisfying the specified filter can be obtained.
+func (lm *localNamedMap[K, V]) EntrySetFilter(ctx context.Context, filter filters.Filter) <-chan *StreamedEntry[K, V] {
+	return lm.entrySet(ctx, filter, nil)
+}
+
+// entrySet returns a channel from which the entries satisfying the filter, sorted using the comparator
+// if one is specified, can be obtained.
+func (lm *localNamedMap[K, V]) entrySet(ctx context.Context, filter filters.Filter, comparator any) <-chan *StreamedEntry[K, V] {
+	entries, err := lm.query(ctx, filter, comparator)
+	if err != nil {
+		return closedChannel(&StreamedEntry[K, V]{Err: err})
+	}
+
+	results := make([]*StreamedEntry[K, V], 0, len(entries))
+	for _, e := range entries {
+		results = append(results, lm.streamedEntry(e))
+	}
+	return closedChannel(results...)
+}
+
+// KeySet returns a channel from which keys of all entries can be obtained.
+func (lm *localNamedMap[K, V]) KeySet(ctx context.Context) <-chan *StreamedKey[K] {
+	return lm.KeySetFilter(ctx, nil)
+}
+
+// KeySetFilter returns a channel from which keys of the entries that satisfy the filter can be obtained.
+func (lm *localNamedMap[K, V]) KeySetFilter(ctx context.Context, filter filters.Filter) <-chan *StreamedKey[K] {
+	entries, err := lm.query(ctx, filter, nil)
+	if err != nil {
+		return closedChannel(&StreamedKey[K]{Err: err})
+	}
+
+	results := make([]*StreamedKey[K], 0, len(entries))
+	for _, e := range entries {
+		results = append(results, &StreamedKey[K]{Key: e.key})
+	}
+	return closedChannel(results...)
+}
+
+// Values return a view of all values contained in the [NamedMap].
+func (lm *localNamedMap[K, V]) Values(ctx context.Context) <-chan *StreamedValue[V] {
+	return lm.ValuesFilter(ctx, nil)
+}
+
+// ValuesFilter returns a view of filtered values contained in the [NamedMap].
+func (lm *localNamedMap[K, V]) ValuesFilter(ctx context.Context, filter filters.Filter) <-chan *StreamedValue[V] {
+	entries, err := lm.query(ctx, filter, nil)
+	if err != nil {
+		return closedChannel(&StreamedValue[V]{Err: err})
+	}
+
+	results := make([]*StreamedValue[V], 0, len(entries))
+	for _, e := range entries {
+		results = append(results, deserializeStreamedValue(lm.bc.valueSerializer, e.value))
+	}
+	return closedChannel(results...)
+}
+
+// InvokeAll invokes the specified processor against the entries matching the specified keys or filter.  If no
+// keys or filter are specified, then the function will be run against all entries.
+func (lm *localNamedMap[K, V]) InvokeAll(ctx context.Context, keysOrFilter any, proc processors.Processor) <-chan *StreamedValue[V] {
+	var (
+		keys   []K
+		filter filters.Filter
+	)
+	switch k := keysOrFilter.(type) {
+	case nil:
+	case []K:
+		keys = k
+	case filters.Filter:
+		filter = k
+	default:
+		return closedChannel(&StreamedValue[V]{Err: ErrInvalidKeysOrFilter})
+	}
+
+	results, err := lm.invoke(ctx, keys, filter, proc)
+	if err != nil {
+		return closedChannel(&StreamedValue[V]{Err: err})
+	}
+
+	values := make([]*StreamedValue[V], 0, len(results))
+	for _, r := range results {
+		values = append(values, deserializeStreamedValue(lm.bc.valueSerializer, r.value))
+	}
+	return closedChannel(values...)
+}
+
+// Put associates the specified value with the specified key returning the previously
+// mapped value. V will be nil if there was no previous value.
+func (lm *localNamedMap[K, V]) Put(ctx context.Context, key K, value V) (*V, error) {
+	return lm.PutWithExpiry(ctx, key, value, lm.bc.cacheOpts.DefaultExpiry)
+}
+
+// PutWithExpiry associates the specified value with the specified key, with the specified time to live,
+// returning the previously mapped value. A ttl of zero or less means the entry does not expire.
+// V will be nil if there was no previous value.
+func (lm *localNamedMap[K, V]) PutWithExpiry(ctx context.Context, key K, value V, ttl time.Duration) (*V, error) {
+	if ttl.Milliseconds() > integerMaxValue {
+		return nil, fmt.Errorf("expiry cannot be greater than %d millis or %v", integerMaxValue, integerMaxValue*time.Millisecond)
+	}
+
+	binKey, binValue, err := lm.serialize(key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = lm.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer lm.unlock()
+
+	return lm.bc.valueSerializer.Deserialize(lm.put(key, binKey, binValue, ttl))
+}
+
+// PutAll copies all the mappings from the specified map to the [NamedMap].
+func (lm *localNamedMap[K, V]) PutAll(ctx context.Context, entries map[K]V) error {
+	return lm.PutAllWithExpiry(ctx, entries, 0)
+}
+
+// PutAllWithExpiry copies all the mappings from the specified map to the [NamedMap] with the specified ttl.
+func (lm *localNamedMap[K, V]) PutAllWithExpiry(ctx context.Context, entries map[K]V, ttl time.Duration) error {
+	type serialized struct {
+		key           K
+		binKey, value []byte
+	}
+
+	values := make([]serialized, 0, len(entries))
+	for k, v := range entries {
+		binKey, binValue, err := lm.serialize(k, v)
+		if err != nil {
+			return err
+		}
+		values = append(values, serialized{k, binKey, binValue})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return bytes.Compare(values[i].binKey, values[j].binKey) < 0
+	})
+
+	if err := lm.lock(ctx); err != nil {
+		return err
+	}
+	defer lm.unlock()
+
+	for _, v := range values {
+		lm.put(v.key, v.binKey, v.value, ttl)
+	}
+	return nil
+}
+
+// PutIfAbsent adds the specified mapping if the key is not already associated with a value in the [NamedMap]
+// and returns nil, else returns the current value.
+func (lm *localNamedMap[K, V]) PutIfAbsent(ctx context.Context, key K, value V) (*V, error) {
+	binKey, binValue, err := lm.serialize(key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = lm.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer lm.unlock()
+
+	if e, ok := lm.entries[key]; ok {
+		return lm.bc.valueSerializer.Deserialize(e.value)
+	}
+	lm.put(key, binKey, binValue, lm.bc.cacheOpts.DefaultExpiry)
+	return nil, nil
+}
+
+// Remove removes the mapping for a key from the [NamedMap] if it is present and returns the previously
+// mapped value, if any. V will be nil if there was no previous value.
+func (lm *localNamedMap[K, V]) Remove(ctx context.Context, key K) (*V, error) {
+	if err := lm.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer lm.unlock()
+
+	return lm.bc.valueSerializer.Deserialize(lm.remove(key, false))
+}
+
+// RemoveMapping removes the entry for the specified key only if it is currently
+// mapped to the specified value. Returns true if the value was removed.
+func (lm *localNamedMap[K, V]) RemoveMapping(ctx context.Context, key K, value V) (bool, error) {
+	binValue, err := lm.bc.valueSerializer.Serialize(value)
+	if err != nil {
+		return false, err
+	}
+
+	if err = lm.lock(ctx); err != nil {
+		return false, err
+	}
+	defer lm.unlock()
+
+	if e, ok := lm.entries[key]; ok && bytes.Equal(e.value, binValue) {
+		lm.remove(key, false)
+		return true, nil
+	}
+	return false, nil
+}
+
+// Replace replaces the entry for the specified key only if it is
+// currently mapped to some value, returning the previous value.
+func (lm *localNamedMap[K, V]) Replace(ctx context.Context, key K, value V) (*V, error) {
+	binKey, binValue, err := lm.serialize(key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = lm.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer lm.unlock()
+
+	if _, ok := lm.entries[key]; !ok {
+		return nil, nil
+	}
+	return lm.bc.valueSerializer.Deserialize(lm.put(key, binKey, binValue, 0))
+}
+
+// ReplaceMapping replaces the entry for the specified key only if it is
+// currently mapped to the value. Returns true if the value was replaced.
+func (lm *localNamedMap[K, V]) ReplaceMapping(ctx context.Context, key K, prevValue V, newValue V) (bool, error) {
+	binKey, binValue, err := lm.serialize(key, newValue)
+	if err != nil {
+		return false, err
+	}
+	binPrevious, err := lm.bc.valueSerializer.Serialize(prevValue)
+	if err != nil {
+		return false, err
+	}
+
+	if err = lm.lock(ctx); err != nil {
+		return false, err
+	}
+	defer lm.unlock()
+
+	if e, ok := lm.entries[key]; ok && bytes.Equal(e.value, binPrevious) {
+		lm.put(key, binKey, binValue, 0)
+		return true, nil
+	}
+	return false, nil
+}
+
+// checkIndex validates a request to add or remove an index, which is otherwise ignored
+// as filters are evaluated against every entry.
+func (lm *localNamedMap[K, V]) checkIndex(ctx context.Context) error {
+	if err := lm.lock(ctx); err != nil {
+		return err
+	}
+	lm.unlock()
+	return nil
+}
+
+// String returns a string representation of the in-process [NamedMap].
+func (lm *localNamedMap[K, V]) String() string {
+	return fmt.Sprintf("LocalNamedMap{name=%s, format=%s}", lm.bc.name, lm.bc.format)
+}
+
+// serialize serializes a key and value.
+func (lm *localNamedMap[K, V]) serialize(key K, value V) ([]byte, []byte, error) {
+	binKey, err := lm.bc.keySerializer.Serialize(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	binValue, err := lm.bc.valueSerializer.Serialize(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return binKey, binValue, nil
+}
+
+// put puts a serialized value, raising an insert or update event, and returns the previous value.
+// The map must be locked.
+func (lm *localNamedMap[K, V]) put(key K, binKey, value []byte, ttl time.Duration) []byte {
+	previous, ok := lm.entries[key]
+	if ok && !previous.expiry.IsZero() {
+		heap.Remove(&lm.expiries, previous.index)
+	}
+
+	e := &localEntry[K]{key: key, binKey: binKey, value: value}
+	if ttl > 0 {
+		e.expiry = lm.now().Add(max(ttl, time.Millisecond))
+		heap.Push(&lm.expiries, e)
+	}
+	lm.entries[key] = e
+
+	if ok {
+		lm.mapEvent(evaluator.EntryUpdated, key, binKey, previous.value, value, false)
+		return previous.value
+	}
+	lm.mapEvent(evaluator.EntryInserted, key, binKey, nil, value, false)
+	return nil
+}
+
+// remove removes an entry, raising a delete event, and returns the previous value. The map must be locked.
+func (lm *localNamedMap[K, V]) remove(key K, expired bool) []byte {
+	previous, ok := lm.entries[key]
+	if !ok {
+		return nil
+	}
+
+	delete(lm.entries, key)
+	if !previous.expiry.IsZero() {
+		heap.Remove(&lm.expiries, previous.index)
+	}
+	lm.mapEvent(evaluator.EntryDeleted, key, previous.binKey, previous.value, nil, expired)
+	return previous.value
+}
+
+// expire removes the entries whose time to live has passed, in the order they expired. The map must be locked.
+func (lm *localNamedMap[K, V]) expire() {
+	now := lm.now()
+	for len(lm.expiries) > 0 && !now.Before(lm.expiries[0].expiry) {
+		lm.remove(lm.expiries[0].key, true)
+	}
+}
+
+// sortedEntries returns the entries sorted by serialized key, so that results are deterministic.
+// The map must be locked.
+func (lm *localNamedMap[K, V]) sortedEntries() []*localEntry[K] {
+	entries := make([]*localEntry[K], 0, len(lm.entries))
+	for _, e := range lm.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].binKey, entries[j].binKey) < 0
+	})
+	return entries
+}
+
+// query returns the entries which match the filter, sorted using the comparator if one is specified.
+func (lm *localNamedMap[K, V]) query(ctx context.Context, filter filters.Filter, comparator any) ([]*localEntry[K], error) {
+	parsedFilter, err := parseLocal(filter)
+	if err != nil {
+		return nil, err
+	}
+	parsedComparator, err := parseLocal(comparator)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = lm.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer lm.unlock()
+
+	return lm.selectEntries(nil, parsedFilter, parsedComparator, false)
+}
+
+// selectEntries returns the entries for the keys, including entries which are not present if includeAbsent
+// is true, or otherwise the entries which match the filter sorted using the comparator. The map must be locked.
+func (lm *localNamedMap[K, V]) selectEntries(keys []K, filter, comparator evaluator.Object, includeAbsent bool) ([]*localEntry[K], error) {
+	if len(keys) > 0 {
+		results := make([]*localEntry[K], 0, len(keys))
+		for _, key := range keys {
+			if e, ok := lm.entries[key]; ok {
+				results = append(results, e)
+			} else if includeAbsent {
+				binKey, err := lm.bc.keySerializer.Serialize(key)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, &localEntry[K]{key: key, binKey: binKey})
+			}
+		}
+		return results, nil
+	}
+
+	var (
+		views   []*evaluator.Entry
+		entries = make(map[*evaluator.Entry]*localEntry[K])
+	)
+	for _, e := range lm.sortedEntries() {
+		v := evaluator.NewEntry(e.binKey, e.value, true)
+		matched, err := evaluator.Evaluate(filter, v)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			views = append(views, v)
+			entries[v] = e
+		}
+	}
+
+	if err := evaluator.Sort(views, comparator); err != nil {
+		return nil, err
+	}
+
+	results := make([]*localEntry[K], 0, len(views))
+	for _, v := range views {
+		results = append(results, entries[v])
+	}
+	return results, nil
+}
+
+// localResult is the serialized result of running an entry processor against the entry for a key.
+type localResult[K comparable] struct {
+	key   K
+	value []byte
+}
+
+// invoke runs an entry processor against the entries for the keys, or matching the filter, applying
+// any updates and returning the serialized results.
+func (lm *localNamedMap[K, V]) invoke(ctx context.Context, keys []K, filter filters.Filter, proc processors.Processor) ([]localResult[K], error) {
+	agent, err := parseLocal(proc)
+	if err != nil {
+		return nil, err
+	}
+	parsedFilter, err := parseLocal(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = lm.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer lm.unlock()
+
+	entries, err := lm.selectEntries(keys, parsedFilter, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]localResult[K], 0, len(entries))
+	for _, e := range entries {
+		v := evaluator.NewEntry(e.binKey, e.value, e.value != nil)
+		result, err := evaluator.Process(agent, v)
+		if err != nil {
+			return nil, err
+		}
+		if v.Changed() {
+			if v.Present {
+				lm.put(e.key, e.binKey, v.Value, 0)
+			} else {
+				lm.remove(e.key, false)
+			}
+		}
+		results = append(results, localResult[K]{key: e.key, value: result})
+	}
+	return results, nil
+}
+
+// aggregate runs an aggregator against the entries for the keys, or matching the filter,
+// and returns the serialized result.
+func (lm *localNamedMap[K, V]) aggregate(ctx context.Context, keys []K, filter filters.Filter, aggr any) ([]byte, error) {
+	agentData, err := NewSerializer[any](defaultFormat).Serialize(aggr)
+	if err != nil {
+		return nil, err
+	}
+	parsedFilter, err := parseLocal(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = lm.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer lm.unlock()
+
+	entries, err := lm.selectEntries(keys, parsedFilter, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]*evaluator.Entry, 0, len(entries))
+	for _, e := range entries {
+		views = append(views, evaluator.NewEntry(e.binKey, e.value, true))
+	}
+	return evaluator.Aggregate(agentData, views)
+}
+
+// newEvent creates a map event, omitting the values if lite is true.
+func (lm *localNamedMap[K, V]) newEvent(eventID int32, binKey, oldValue, newValue []byte, lite bool) *mapEvent[K, V] {
+	event := &mapEvent[K, V]{source: lm, eventType: eventTypeFromID(eventID), keyBytes: &binKey, isgRPCv1: true}
+	if !lite {
+		if oldValue != nil {
+			event.oldValueBytes = &oldValue
+		}
+		if newValue != nil {
+			event.newValueBytes = &newValue
+		}
+	}
+	return event
+}
+
+// mapEvent queues a map event for the listeners registered for the key, followed by those registered with
+// a filter which matches the event. A listener registered more than once is notified once, and receives the
+// values unless every registration was lite. The map must be locked.
+func (lm *localNamedMap[K, V]) mapEvent(eventID int32, key K, binKey, oldValue, newValue []byte, expired bool) {
+	var (
+		order     []MapListener[K, V]
+		listeners = make(map[MapListener[K, V]]bool)
+	)
+	add := func(l MapListener[K, V], lite bool) {
+		current, ok := listeners[l]
+		if !ok {
+			order = append(order, l)
+		}
+		listeners[l] = lite && (!ok || current)
+	}
+
+	for l, lite := range lm.keyListeners[key] {
+		add(l, lite)
+	}
+
+	if len(lm.filterListeners) > 0 {
+		oldEntry := evaluator.NewEntry(binKey, oldValue, oldValue != nil)
+		newEntry := evaluator.NewEntry(binKey, newValue, newValue != nil)
+		for _, group := range lm.filterListeners {
+			if !evaluator.MatchesEvent(group.filter, eventID, oldEntry, newEntry) {
+				continue
+			}
+			for l, lite := range group.listeners {
+				add(l, lite)
+			}
+		}
+	}
+
+	for _, l := range order {
+		event := lm.newEvent(eventID, binKey, oldValue, newValue, listeners[l])
+		event.isExpired, event.isSynthetic = expired, expired
+		lm.events = append(lm.events, func() { l.dispatch(event) })
+	}
+}
+
+// lifecycleEvent queues a lifecycle event for the lifecycle listeners. The map must be locked.
+func (lm *localNamedMap[K, V]) lifecycleEvent(eventType MapLifecycleEventType) {
+	event := newMapLifecycleEvent[K, V](lm, eventType)
+	for _, l := range lm.lifecycleListeners {
+		emitter := l.getEmitter()
+		lm.events = append(lm.events, func() { emitter.emit(eventType, event) })
+	}
+}
+
+// streamedEntry deserializes an entry into a [StreamedEntry].
+func (lm *localNamedMap[K, V]) streamedEntry(e *localEntry[K]) *StreamedEntry[K, V] {
+	value, err := lm.bc.valueSerializer.Deserialize(e.value)
+	if err != nil {
+		return &StreamedEntry[K, V]{Err: err}
+	}
+	return &StreamedEntry[K, V]{Key: e.key, Value: *value}
+}
+
+// invokeKey runs an entry processor against the entry for a key and returns the serialized result.
+func (lm *localNamedMap[K, V]) invokeKey(ctx context.Context, key K, proc processors.Processor) ([]byte, error) {
+	results, err := lm.invoke(ctx, []K{key}, nil, proc)
+	if err != nil {
+		return nil, err
+	}
+	return results[0].value, nil
+}
+
+// invokeAll runs an entry processor against the entries for the keys, or matching the filter, and sends
+// the serialized results. The entry processor has been run against the entries when invokeAll returns.
+func (lm *localNamedMap[K, V]) invokeAll(ctx context.Context, keys []K, filter filters.Filter, proc processors.Processor, results entryResults[K]) {
+	entries, err := lm.invoke(ctx, keys, filter, proc)
+
+	go func() {
+		defer results.close()
+
+		if err != nil {
+			_ = results.send(ctx, nil, nil, err)
+			return
+		}
+		for _, e := range entries {
+			if results.send(ctx, &e.key, e.value, nil) != nil {
+				return
+			}
+		}
+	}()
+}
+
+// addIndex validates a request to add an index.
+func (lm *localNamedMap[K, V]) addIndex(ctx context.Context, _ any, _ bool, _ any) error {
+	return lm.checkIndex(ctx)
+}
+
+// removeIndex validates a request to remove an index.
+func (lm *localNamedMap[K, V]) removeIndex(ctx context.Context, _ any) error {
+	return lm.checkIndex(ctx)
+}
+
+// parseLocal serializes a filter, comparator or agent and parses it for evaluation, returning nil if it is nil.
+func parseLocal(value any) (evaluator.Object, error) {
+	data, err := NewSerializer[any](defaultFormat).Serialize(value)
+	if err != nil {
+		return nil, err
+	}
+	return evaluator.ParseObject(data)
+}
+
+// deserializeStreamedValue deserializes a value into a [StreamedValue].
+func deserializeStreamedValue[V any](serializer Serializer[V], data []byte) *StreamedValue[V] {
+	value, err := serializer.Deserialize(data)
+	if err != nil {
+		return &StreamedValue[V]{Err: err}
+	}
+	if value == nil {
+		return &StreamedValue[V]{IsValueEmpty: true}
+	}
+	return &StreamedValue[V]{Value: *value}
+}
+
+// closedChannel returns a closed channel containing the values.
+func closedChannel[T any](values ...T) <-chan T {
+	ch := make(chan T, len(values))
+	for _, v := range values {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
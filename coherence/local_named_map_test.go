@@ -0,0 +1,303 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/aggregators"
+	"github.com/oracle/coherence-go-client/v2/coherence/extractors"
+	"github.com/oracle/coherence-go-client/v2/coherence/filters"
+	"github.com/oracle/coherence-go-client/v2/coherence/processors"
+)
+
+type localPerson struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func newLocalPeople(t *testing.T) NamedMap[int, localPerson] {
+	namedMap, err := NewLocalNamedMap[int, localPerson]("people")
+	if err != nil {
+		t.Fatalf("unable to create map: %v", err)
+	}
+
+	err = namedMap.PutAll(context.Background(), map[int]localPerson{
+		1: {1, "Tim", 50},
+		2: {2, "Helen", 40},
+		3: {3, "Andrew", 20},
+	})
+	if err != nil {
+		t.Fatalf("unable to populate map: %v", err)
+	}
+	return namedMap
+}
+
+func TestLocalNamedMapCrud(t *testing.T) {
+	ctx := context.Background()
+	namedMap := newLocalPeople(t)
+
+	if _, err := NewLocalNamedMap[int, string]("expiry", WithExpiry(time.Second)); err == nil {
+		t.Fatalf("expected an error using an expiry with a NamedMap")
+	}
+
+	if value, err := namedMap.Get(ctx, 1); err != nil || value == nil || value.Name != "Tim" {
+		t.Fatalf("expected Tim, got %v, %v", value, err)
+	}
+	if value, err := namedMap.Get(ctx, 4); err != nil || value != nil {
+		t.Fatalf("expected nil, got %v, %v", value, err)
+	}
+	if value, err := namedMap.GetOrDefault(ctx, 4, localPerson{Name: "none"}); err != nil || value.Name != "none" {
+		t.Fatalf("expected the default, got %v, %v", value, err)
+	}
+
+	old, err := namedMap.Put(ctx, 3, localPerson{3, "Andrew", 21})
+	if err != nil || old == nil || old.Age != 20 {
+		t.Fatalf("expected the previous value, got %v, %v", old, err)
+	}
+	if ok, err := namedMap.ContainsValue(ctx, localPerson{3, "Andrew", 21}); err != nil || !ok {
+		t.Fatalf("expected value to be present, got %v, %v", ok, err)
+	}
+	if ok, err := namedMap.ReplaceMapping(ctx, 3, localPerson{3, "Andrew", 20}, localPerson{}); err != nil || ok {
+		t.Fatalf("expected replace of a different value to fail, got %v, %v", ok, err)
+	}
+	if ok, err := namedMap.RemoveMapping(ctx, 3, localPerson{3, "Andrew", 21}); err != nil || !ok {
+		t.Fatalf("expected mapping to be removed, got %v, %v", ok, err)
+	}
+	if value, err := namedMap.PutIfAbsent(ctx, 1, localPerson{}); err != nil || value == nil || value.Name != "Tim" {
+		t.Fatalf("expected the current value, got %v, %v", value, err)
+	}
+	if size, err := namedMap.Size(ctx); err != nil || size != 2 {
+		t.Fatalf("expected size 2, got %v, %v", size, err)
+	}
+
+	var keys []int
+	for se := range namedMap.GetAll(ctx, []int{2, 1, 5}) {
+		if se.Err != nil {
+			t.Fatalf("unexpected error: %v", se.Err)
+		}
+		keys = append(keys, se.Key)
+	}
+	if len(keys) != 2 || keys[0] != 2 || keys[1] != 1 {
+		t.Fatalf("expected keys 2 and 1, got %v", keys)
+	}
+
+	if err = namedMap.Clear(ctx); err != nil {
+		t.Fatalf("unable to clear: %v", err)
+	}
+	if empty, err := namedMap.IsEmpty(ctx); err != nil || !empty {
+		t.Fatalf("expected map to be empty, got %v, %v", empty, err)
+	}
+}
+
+func TestLocalNamedMapQueries(t *testing.T) {
+	ctx := context.Background()
+	namedMap := newLocalPeople(t)
+	age := extractors.Extract[int]("age")
+
+	var names []string
+	comparator := extractors.ExtractorComparator(age, true)
+	for se := range EntrySetFilterWithComparator(ctx, namedMap, filters.Greater(age, 30), comparator) {
+		if se.Err != nil {
+			t.Fatalf("unexpected error: %v", se.Err)
+		}
+		names = append(names, se.Value.Name)
+	}
+	if len(names) != 2 || names[0] != "Helen" || names[1] != "Tim" {
+		t.Fatalf("expected Helen and Tim, got %v", names)
+	}
+
+	count, err := AggregateFilter(ctx, namedMap, filters.Less(age, 45), aggregators.Count())
+	if err != nil || *count != 2 {
+		t.Fatalf("expected count of 2, got %v, %v", count, err)
+	}
+	maxAge, err := Aggregate(ctx, namedMap, aggregators.Max(age))
+	if err != nil || *maxAge != 50 {
+		t.Fatalf("expected max age of 50, got %v, %v", maxAge, err)
+	}
+
+	newAge, err := Invoke[int, localPerson, int](ctx, namedMap, 1, processors.Increment("age", 1))
+	if err != nil || *newAge != 51 {
+		t.Fatalf("expected new age of 51, got %v, %v", newAge, err)
+	}
+	if err = InvokeAllFilterBlind(ctx, namedMap, filters.Less(age, 45), processors.Update("name", "young")); err != nil {
+		t.Fatalf("unable to invoke: %v", err)
+	}
+	for se := range namedMap.ValuesFilter(ctx, filters.Equal(extractors.Extract[string]("name"), "young")) {
+		if se.Err != nil || se.Value.Age >= 45 {
+			t.Fatalf("unexpected value %v, %v", se.Value, se.Err)
+		}
+	}
+
+	names = nil
+	for se := range InvokeAll[int, localPerson, string](ctx, namedMap, processors.Extractor[string]("name")) {
+		if se.Err != nil {
+			t.Fatalf("unexpected error: %v", se.Err)
+		}
+		names = append(names, se.Value)
+	}
+	if len(names) != 3 {
+		t.Fatalf("expected 3 names, got %v", names)
+	}
+
+	if err = AddIndex(ctx, namedMap, age, true); err != nil {
+		t.Fatalf("unable to add index: %v", err)
+	}
+}
+
+func TestLocalNamedMapListeners(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		namedMap  = newLocalPeople(t)
+		events    []string
+		lifecycle []MapLifecycleEventType
+	)
+
+	record := func(e MapEvent[int, localPerson]) {
+		key, _ := e.Key()
+		value, _ := e.NewValue()
+		name := ""
+		if value != nil {
+			name = value.Name
+		}
+		events = append(events, fmt.Sprintf("%s:%d:%s", e.Type(), *key, name))
+	}
+
+	keyListener := NewMapListener[int, localPerson]().OnAny(record)
+	if err := namedMap.AddKeyListener(ctx, keyListener, 1); err != nil {
+		t.Fatalf("unable to add listener: %v", err)
+	}
+	filterListener := NewMapListener[int, localPerson]().OnAny(record)
+	if err := namedMap.AddFilterListenerLite(ctx, filterListener, filters.Greater(extractors.Extract[int]("age"), 45)); err != nil {
+		t.Fatalf("unable to add listener: %v", err)
+	}
+
+	lifecycleListener := NewMapLifecycleListener[int, localPerson]().OnAny(func(e MapLifecycleEvent[int, localPerson]) {
+		lifecycle = append(lifecycle, e.Type())
+	})
+	namedMap.AddLifecycleListener(lifecycleListener)
+
+	_, _ = namedMap.Put(ctx, 1, localPerson{1, "Tim", 51})
+	_, _ = namedMap.Put(ctx, 4, localPerson{4, "Sue", 60})
+	_, _ = namedMap.Put(ctx, 2, localPerson{2, "Helen", 41})
+	_ = namedMap.RemoveKeyListener(ctx, keyListener, 1)
+	_, _ = namedMap.Remove(ctx, 1)
+
+	expected := []string{"update:1:Tim", "update:1:", "insert:4:", "delete:1:"}
+	if len(events) != len(expected) {
+		t.Fatalf("expected events %v, got %v", expected, events)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Fatalf("expected events %v, got %v", expected, events)
+		}
+	}
+
+	_ = namedMap.Truncate(ctx)
+	if err := namedMap.Destroy(ctx); err != nil {
+		t.Fatalf("unable to destroy: %v", err)
+	}
+	if len(lifecycle) != 3 || lifecycle[0] != Truncated || lifecycle[1] != Destroyed || lifecycle[2] != Released {
+		t.Fatalf("expected truncated, destroyed and released events, got %v", lifecycle)
+	}
+	if _, err := namedMap.Get(ctx, 1); !errors.Is(err, ErrDestroyed) {
+		t.Fatalf("expected ErrDestroyed, got %v", err)
+	}
+}
+
+func TestLocalNamedCacheExpiry(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		now     = time.Now()
+		expired []bool
+	)
+
+	namedCache, err := NewLocalNamedCache[int, string]("expiry", WithExpiry(time.Minute))
+	if err != nil {
+		t.Fatalf("unable to create cache: %v", err)
+	}
+	namedCache.(*localNamedMap[int, string]).now = func() time.Time { return now }
+
+	listener := NewMapListener[int, string]().OnDeleted(func(e MapEvent[int, string]) {
+		isExpired, _ := e.IsExpired()
+		expired = append(expired, isExpired)
+	})
+	if err = namedCache.AddListener(ctx, listener); err != nil {
+		t.Fatalf("unable to add listener: %v", err)
+	}
+
+	_, _ = namedCache.Put(ctx, 1, "one")
+	_, _ = namedCache.PutWithExpiry(ctx, 2, "two", time.Second)
+	_, _ = namedCache.PutWithExpiry(ctx, 3, "three", 0)
+
+	if _, err = namedCache.PutWithExpiry(ctx, 4, "four", time.Duration(integerMaxValue+1)*time.Millisecond); err == nil {
+		t.Fatalf("expected an error for an expiry which is too large")
+	}
+
+	now = now.Add(2 * time.Second)
+	if size, err := namedCache.Size(ctx); err != nil || size != 2 {
+		t.Fatalf("expected size 2, got %v, %v", size, err)
+	}
+
+	now = now.Add(time.Hour)
+	if value, err := namedCache.Get(ctx, 3); err != nil || value == nil || *value != "three" {
+		t.Fatalf("expected entry without expiry to be present, got %v, %v", value, err)
+	}
+	if len(expired) != 2 || !expired[0] || !expired[1] {
+		t.Fatalf("expected two expired events, got %v", expired)
+	}
+}
+
+func TestLocalNamedCacheExpiryOrder(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		now     = time.Now()
+		expired []int
+	)
+
+	namedCache, err := NewLocalNamedCache[int, string]("expiry-order")
+	if err != nil {
+		t.Fatalf("unable to create cache: %v", err)
+	}
+	lm := namedCache.(*localNamedMap[int, string])
+	lm.now = func() time.Time { return now }
+
+	listener := NewMapListener[int, string]().OnDeleted(func(e MapEvent[int, string]) {
+		if isExpired, _ := e.IsExpired(); isExpired {
+			key, _ := e.Key()
+			expired = append(expired, *key)
+		}
+	})
+	if err = namedCache.AddListener(ctx, listener); err != nil {
+		t.Fatalf("unable to add listener: %v", err)
+	}
+
+	for key, ttl := range map[int]time.Duration{1: 5 * time.Second, 2: time.Second, 3: 3 * time.Second, 4: time.Second, 5: 2 * time.Second} {
+		_, _ = namedCache.PutWithExpiry(ctx, key, "value", ttl)
+	}
+
+	// replaced and removed entries no longer expire
+	_, _ = namedCache.Put(ctx, 3, "three")
+	_, _ = namedCache.Remove(ctx, 5)
+	if len(lm.expiries) != 3 {
+		t.Fatalf("expected 3 entries to expire, got %d", len(lm.expiries))
+	}
+
+	now = now.Add(10 * time.Second)
+	if size, err := namedCache.Size(ctx); err != nil || size != 1 {
+		t.Fatalf("expected size 1, got %v, %v", size, err)
+	}
+	if !reflect.DeepEqual(expired, []int{2, 4, 1}) || len(lm.expiries) != 0 {
+		t.Fatalf("expected entries to expire in expiry then key order, got %v", expired)
+	}
+}
@@ -8,9 +8,16 @@ package coherence
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
 	"log"
+	"log/slog"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/testing/fakeproxy"
 )
 
 func TestErrorLogLevel(t *testing.T) {
@@ -64,3 +71,113 @@ func runLogLevelTest(t *testing.T, messageLevel, testLogLevel logLevel, expectOu
 		t.Fatalf("expected output to NOT contain %q but it did", message)
 	}
 }
+
+func TestSessionLogger(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	s, err := NewSession(context.Background(), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("unexpected error creating session: %v", err)
+	}
+	defer s.Close()
+
+	buf.Reset()
+	s.logAttrs(WARNING, cacheAttrs("my-cache"), "warning for %s", "cache")
+
+	var record map[string]any
+	if err = json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected a JSON log record, got %q: %v", buf.String(), err)
+	}
+	if record["level"] != "WARN" || record["msg"] != "warning for cache" ||
+		record["sessionId"] != s.sessionID.String() || record["cache"] != "my-cache" {
+		t.Fatalf("unexpected log record %v", record)
+	}
+
+	// debug messages should be routed to the logger
+	buf.Reset()
+	s.debug("debug message")
+	if !strings.Contains(buf.String(), `"level":"DEBUG"`) || !strings.Contains(buf.String(), "debug message") {
+		t.Fatalf("expected debug record, got %q", buf.String())
+	}
+
+	// message debug is below the handler level so should not be emitted
+	buf.Reset()
+	s.debugConnection("message debug")
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output for message debug, got %q", buf.String())
+	}
+}
+
+func TestSessionLoggerRequests(t *testing.T) {
+	var (
+		ctx = context.Background()
+		buf syncBuffer
+	)
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	session, err := NewSession(ctx, WithAddress(address), WithPlainText(), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	namedMap, err := GetNamedMap[int, string](session, "logging")
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+	if _, err = namedMap.Put(ctx, 1, "one"); err != nil {
+		t.Fatalf("unable to put: %v", err)
+	}
+
+	// each request submitted is logged at debug with the request id
+	output := buf.String()
+	if !strings.Contains(output, `"level":"DEBUG"`) || !strings.Contains(output, "submit request: Put") ||
+		!strings.Contains(output, `"requestId":`) {
+		t.Fatalf("expected a debug record for the request with the request id, got %q", output)
+	}
+
+	// TLS messages are logged using the session logger
+	buf.Reset()
+	session.sessOpts.PlainText = false
+	session.sessOpts.TlSConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec // testing the warning
+	if _, err = session.createTLSOption(); err != nil {
+		t.Fatalf("unable to create TLS option: %v", err)
+	}
+	if output = buf.String(); !strings.Contains(output, `"level":"WARN"`) ||
+		!strings.Contains(output, `"sessionId":"`+session.sessionID.String()+`"`) {
+		t.Fatalf("expected the insecure warning for the session, got %q", output)
+	}
+}
+
+// syncBuffer is a bytes.Buffer which can be written to by the goroutines of a session.
+type syncBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.String()
+}
+
+func (b *syncBuffer) Reset() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.buf.Reset()
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// levelAll is the [slog.Level] used for messages logged at the ALL log level.
+const levelAll = slog.LevelDebug - 4
+
+// WithLogger returns a function to set the [slog.Logger] used by a [Session]. When set, all messages for the
+// session, including connection, reconnect, listener, near cache and resolver messages, are emitted as structured
+// records with a sessionId attribute and, where relevant, cache and requestId attributes.
+// The messages emitted are controlled by the level of the logger's handler rather than the COHERENCE_LOG_LEVEL,
+// COHERENCE_SESSION_DEBUG and COHERENCE_MESSAGE_DEBUG environment variables. The type and id of each request
+// submitted are emitted at slog.LevelDebug, and the contents of requests and responses at slog.LevelDebug - 4.
+func WithLogger(logger *slog.Logger) func(sessionOptions *SessionOptions) {
+	return func(s *SessionOptions) {
+		s.Logger = logger
+	}
+}
+
+// slogLevel returns the [slog.Level] for the log level.
+func (l logLevel) slogLevel() slog.Level {
+	switch l {
+	case ERROR:
+		return slog.LevelError
+	case WARNING:
+		return slog.LevelWarn
+	case INFO:
+		return slog.LevelInfo
+	case DEBUG:
+		return slog.LevelDebug
+	default:
+		return levelAll
+	}
+}
+
+// setLogger sets the logger for the session and routes the session debug messages to it.
+func (s *Session) setLogger(logger *slog.Logger) {
+	s.logger = logger.With(slog.String("sessionId", s.sessionID.String()))
+
+	s.debug = func(format string, v ...any) {
+		s.log(DEBUG, format, v...)
+	}
+	s.debugConnection = func(format string, v ...any) {
+		s.log(ALL, format, v...)
+	}
+}
+
+// log logs a message for the session using the session logger if one has been set, otherwise using logMessage.
+func (s *Session) log(level logLevel, format string, args ...any) {
+	s.logAttrs(level, nil, format, args...)
+}
+
+// logAttrs logs a message for the session with the additional attributes, which are only
+// included if a session logger has been set.
+func (s *Session) logAttrs(level logLevel, attrs []slog.Attr, format string, args ...any) {
+	if s.logger == nil {
+		logMessage(level, format, args...)
+		return
+	}
+
+	ctx := context.Background()
+	if !s.logger.Enabled(ctx, level.slogLevel()) {
+		return
+	}
+
+	s.logger.LogAttrs(ctx, level.slogLevel(), fmt.Sprintf(format, args...), attrs...)
+}
+
+// cacheAttrs returns the attributes for a message about a cache.
+func cacheAttrs(cache string) []slog.Attr {
+	return []slog.Attr{slog.String("cache", cache)}
+}
+
+// requestAttrs returns the attributes for a message about a request.
+func requestAttrs(requestID int64) []slog.Attr {
+	return []slog.Attr{slog.Int64("requestId", requestID)}
+}
@@ -0,0 +1,436 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// latencyBuckets are the upper bounds of the operation latency histogram buckets.
+var latencyBuckets = []time.Duration{
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// SessionMetrics is a snapshot of the metrics for a [Session], returned by [Session.Metrics].
+type SessionMetrics struct {
+	// Operations contains the metrics for each cache or queue and operation, ordered by name and operation.
+	Operations []OperationMetrics
+
+	// Events contains the number of map events received for each cache.
+	Events map[string]int64
+
+	// StreamReconnects is the number of times a gRPC v1 stream has been re-established.
+	StreamReconnects int64
+
+	// Compression contains the compression statistics for each cache created using [WithValueCompression].
+	Compression map[string]CompressionMetrics
+}
+
+// OperationMetrics contains the metrics for an operation against a cache or queue.
+// The byte counts are only available when connected to a gRPC v1 proxy.
+type OperationMetrics struct {
+	// Name is the name of the cache or queue.
+	Name string
+
+	// Operation is the operation, for example "Get", "Put" or "OfferTail".
+	Operation string
+
+	// Queue is true if the operation is against a queue rather than a map or cache.
+	Queue bool
+
+	// Requests is the number of operations started.
+	Requests int64
+
+	// Errors is the number of operations which failed, including those which timed out.
+	Errors int64
+
+	// Timeouts is the number of operations which failed because the request timeout or context deadline was exceeded.
+	Timeouts int64
+
+	// BytesSent is the total serialized size of the requests sent.
+	BytesSent int64
+
+	// BytesReceived is the total serialized size of the responses received.
+	BytesReceived int64
+
+	// InFlight is the number of operations which have started but not yet ended.
+	InFlight int64
+
+	// Queued is the number of requests waiting because the maximum number of in-flight requests has been reached.
+	Queued int64
+
+	// Latency is the histogram of the latencies of the operations which have ended.
+	Latency LatencyHistogram
+}
+
+// LatencyHistogram is a histogram of operation latencies.
+type LatencyHistogram struct {
+	// Bounds are the upper bounds of the buckets.
+	Bounds []time.Duration
+
+	// Counts are the cumulative number of operations with a latency less than or equal to the corresponding bound.
+	Counts []int64
+
+	// Count is the total number of operations.
+	Count int64
+
+	// Sum is the total latency of the operations.
+	Sum time.Duration
+}
+
+// sessionMetrics holds the metrics for a session.
+type sessionMetrics struct {
+	mutex            sync.RWMutex
+	operations       map[operationMetricsKey]*operationMetrics
+	events           map[string]*atomic.Int64
+	compressionStats map[string]*compressionStats
+	streamReconnects atomic.Int64
+}
+
+type operationMetricsKey struct {
+	name      string
+	operation string
+	queue     bool
+}
+
+// operationMetrics holds the metrics for an operation against a cache or queue.
+type operationMetrics struct {
+	requests      atomic.Int64
+	errors        atomic.Int64
+	timeouts      atomic.Int64
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+	inFlight      atomic.Int64
+	queued        atomic.Int64
+	latencySum    atomic.Int64
+	latencyCounts []atomic.Int64 // per bucket, not cumulative, with a final bucket for latencies above the largest bound
+}
+
+func newSessionMetrics() *sessionMetrics {
+	return &sessionMetrics{
+		operations:       make(map[operationMetricsKey]*operationMetrics),
+		events:           make(map[string]*atomic.Int64),
+		compressionStats: make(map[string]*compressionStats),
+	}
+}
+
+// WithMetrics returns a function to enable the collection of metrics for a [Session], which are returned
+// by [Session.Metrics] and [Session.MetricsHandler]. Metrics are not collected by default.
+func WithMetrics() func(sessionOptions *SessionOptions) {
+	return func(s *SessionOptions) {
+		s.Metrics = true
+	}
+}
+
+// Metrics returns a snapshot of the metrics for the session, which include counters and latency histograms
+// for each cache or queue and operation, the number of map events received and the number of stream reconnects.
+// The snapshot is empty unless metrics have been enabled using [WithMetrics].
+func (s *Session) Metrics() SessionMetrics {
+	return s.metrics.snapshot()
+}
+
+// MetricsHandler returns an [http.Handler] which writes the metrics for the session in the Prometheus text format.
+//
+//	http.Handle("/metrics", session.MetricsHandler())
+func (s *Session) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writePrometheusMetrics(w, s.Metrics())
+	})
+}
+
+// operation returns the metrics for the operation, creating them if required.
+func (m *sessionMetrics) operation(name string, operation string, queue bool) *operationMetrics {
+	if m == nil {
+		return nil
+	}
+
+	key := operationMetricsKey{name: name, operation: operation, queue: queue}
+
+	m.mutex.RLock()
+	om, ok := m.operations[key]
+	m.mutex.RUnlock()
+	if ok {
+		return om
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if om, ok = m.operations[key]; !ok {
+		om = &operationMetrics{latencyCounts: make([]atomic.Int64, len(latencyBuckets)+1)}
+		m.operations[key] = om
+	}
+	return om
+}
+
+// eventReceived counts a map event received for the cache.
+func (m *sessionMetrics) eventReceived(cache string) {
+	if m == nil {
+		return
+	}
+
+	m.mutex.RLock()
+	counter, ok := m.events[cache]
+	m.mutex.RUnlock()
+
+	if !ok {
+		m.mutex.Lock()
+		if counter, ok = m.events[cache]; !ok {
+			counter = &atomic.Int64{}
+			m.events[cache] = counter
+		}
+		m.mutex.Unlock()
+	}
+
+	counter.Add(1)
+}
+
+// compression returns the compression statistics for the cache, creating them if required.
+func (m *sessionMetrics) compression(cache string) *compressionStats {
+	if m == nil {
+		return nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	stats, ok := m.compressionStats[cache]
+	if !ok {
+		stats = &compressionStats{}
+		m.compressionStats[cache] = stats
+	}
+	return stats
+}
+
+// streamReconnected counts a gRPC v1 stream being re-established.
+func (m *sessionMetrics) streamReconnected() {
+	if m != nil {
+		m.streamReconnects.Add(1)
+	}
+}
+
+// started records an operation starting.
+func (om *operationMetrics) started() {
+	if om != nil {
+		om.requests.Add(1)
+		om.inFlight.Add(1)
+	}
+}
+
+// addQueued adjusts the number of requests waiting for an in-flight slot.
+func (om *operationMetrics) addQueued(delta int64) {
+	if om != nil {
+		om.queued.Add(delta)
+	}
+}
+
+// ended records an operation ending after the latency with the result.
+func (om *operationMetrics) ended(latency time.Duration, result OperationResult) {
+	if om == nil {
+		return
+	}
+
+	om.inFlight.Add(-1)
+	om.bytesSent.Add(int64(result.RequestBytes))
+	om.bytesReceived.Add(int64(result.ResponseBytes))
+
+	if result.Err != nil {
+		om.errors.Add(1)
+		if isTimeout(result.Err) {
+			om.timeouts.Add(1)
+		}
+	}
+
+	bucket, _ := slices.BinarySearch(latencyBuckets, latency)
+	om.latencyCounts[bucket].Add(1)
+	om.latencySum.Add(int64(latency))
+}
+
+// isTimeout returns true if the error indicates a request timeout or context deadline was exceeded.
+func isTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded
+}
+
+// snapshot returns a snapshot of the metrics.
+func (m *sessionMetrics) snapshot() SessionMetrics {
+	snapshot := SessionMetrics{Events: make(map[string]int64), Compression: make(map[string]CompressionMetrics)}
+	if m == nil {
+		return snapshot
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for key, om := range m.operations {
+		histogram := LatencyHistogram{
+			Bounds: slices.Clone(latencyBuckets),
+			Counts: make([]int64, len(latencyBuckets)),
+			Sum:    time.Duration(om.latencySum.Load()),
+		}
+		for i := range om.latencyCounts {
+			histogram.Count += om.latencyCounts[i].Load()
+			if i < len(latencyBuckets) {
+				histogram.Counts[i] = histogram.Count
+			}
+		}
+
+		snapshot.Operations = append(snapshot.Operations, OperationMetrics{
+			Name:          key.name,
+			Operation:     key.operation,
+			Queue:         key.queue,
+			Requests:      om.requests.Load(),
+			Errors:        om.errors.Load(),
+			Timeouts:      om.timeouts.Load(),
+			BytesSent:     om.bytesSent.Load(),
+			BytesReceived: om.bytesReceived.Load(),
+			InFlight:      om.inFlight.Load(),
+			Queued:        om.queued.Load(),
+			Latency:       histogram,
+		})
+	}
+
+	slices.SortFunc(snapshot.Operations, func(a, b OperationMetrics) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Operation, b.Operation)
+	})
+
+	for name, counter := range m.events {
+		snapshot.Events[name] = counter.Load()
+	}
+	for name, stats := range m.compressionStats {
+		snapshot.Compression[name] = stats.snapshot()
+	}
+	snapshot.StreamReconnects = m.streamReconnects.Load()
+
+	return snapshot
+}
+
+// writePrometheusMetrics writes the metrics in the Prometheus text format.
+func writePrometheusMetrics(w io.Writer, metrics SessionMetrics) {
+	counters := []struct {
+		name  string
+		help  string
+		value func(om OperationMetrics) int64
+	}{
+		{"coherence_client_requests_total", "The number of operations started.", func(om OperationMetrics) int64 { return om.Requests }},
+		{"coherence_client_request_errors_total", "The number of operations which failed.", func(om OperationMetrics) int64 { return om.Errors }},
+		{"coherence_client_request_timeouts_total", "The number of operations which timed out.", func(om OperationMetrics) int64 { return om.Timeouts }},
+		{"coherence_client_request_bytes_sent_total", "The serialized size of the requests sent.", func(om OperationMetrics) int64 { return om.BytesSent }},
+		{"coherence_client_response_bytes_received_total", "The serialized size of the responses received.", func(om OperationMetrics) int64 { return om.BytesReceived }},
+	}
+
+	for _, c := range counters {
+		writeMetricHeader(w, c.name, c.help, "counter")
+		for _, om := range metrics.Operations {
+			_, _ = fmt.Fprintf(w, "%s{%s} %d\n", c.name, operationLabels(om), c.value(om))
+		}
+	}
+
+	writeMetricHeader(w, "coherence_client_requests_in_flight", "The number of operations in flight.", "gauge")
+	for _, om := range metrics.Operations {
+		_, _ = fmt.Fprintf(w, "coherence_client_requests_in_flight{%s} %d\n", operationLabels(om), om.InFlight)
+	}
+
+	writeMetricHeader(w, "coherence_client_requests_queued", "The number of requests waiting for an in-flight slot.", "gauge")
+	for _, om := range metrics.Operations {
+		_, _ = fmt.Fprintf(w, "coherence_client_requests_queued{%s} %d\n", operationLabels(om), om.Queued)
+	}
+
+	const durationName = "coherence_client_request_duration_seconds"
+	writeMetricHeader(w, durationName, "The latency of operations.", "histogram")
+	for _, om := range metrics.Operations {
+		labels := operationLabels(om)
+		for i, bound := range om.Latency.Bounds {
+			_, _ = fmt.Fprintf(w, "%s_bucket{%s,le=\"%g\"} %d\n", durationName, labels, bound.Seconds(), om.Latency.Counts[i])
+		}
+		_, _ = fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", durationName, labels, om.Latency.Count)
+		_, _ = fmt.Fprintf(w, "%s_sum{%s} %g\n", durationName, labels, om.Latency.Sum.Seconds())
+		_, _ = fmt.Fprintf(w, "%s_count{%s} %d\n", durationName, labels, om.Latency.Count)
+	}
+
+	writeMetricHeader(w, "coherence_client_events_total", "The number of map events received.", "counter")
+	names := make([]string, 0, len(metrics.Events))
+	for name := range metrics.Events {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "coherence_client_events_total{name=\"%s\"} %d\n", escapeLabelValue(name), metrics.Events[name])
+	}
+
+	compressed := make([]string, 0, len(metrics.Compression))
+	for name := range metrics.Compression {
+		compressed = append(compressed, name)
+	}
+	slices.Sort(compressed)
+	compressionCounters := []struct {
+		name  string
+		help  string
+		value func(c CompressionMetrics) int64
+	}{
+		{"coherence_client_values_compressed_total", "The number of values compressed.", func(c CompressionMetrics) int64 { return c.Compressed }},
+		{"coherence_client_values_uncompressed_total", "The number of values stored uncompressed.", func(c CompressionMetrics) int64 { return c.Uncompressed }},
+		{"coherence_client_compression_bytes_before_total", "The size of the values compressed before compression.", func(c CompressionMetrics) int64 { return c.BytesBefore }},
+		{"coherence_client_compression_bytes_after_total", "The size of the values compressed after compression.", func(c CompressionMetrics) int64 { return c.BytesAfter }},
+		{"coherence_client_decompression_errors_total", "The number of values which could not be decompressed.", func(c CompressionMetrics) int64 { return c.DecompressionErrors }},
+	}
+	for _, c := range compressionCounters {
+		writeMetricHeader(w, c.name, c.help, "counter")
+		for _, name := range compressed {
+			_, _ = fmt.Fprintf(w, "%s{name=\"%s\"} %d\n", c.name, escapeLabelValue(name), c.value(metrics.Compression[name]))
+		}
+	}
+
+	writeMetricHeader(w, "coherence_client_stream_reconnects_total", "The number of times a stream has been re-established.", "counter")
+	_, _ = fmt.Fprintf(w, "coherence_client_stream_reconnects_total %d\n", metrics.StreamReconnects)
+}
+
+func writeMetricHeader(w io.Writer, name string, help string, metricType string) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+func operationLabels(om OperationMetrics) string {
+	kind := "cache"
+	if om.Queue {
+		kind = "queue"
+	}
+	return fmt.Sprintf("name=\"%s\",type=\"%s\",operation=\"%s\"", escapeLabelValue(om.Name), kind, om.Operation)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/testing/fakeproxy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSessionMetrics(t *testing.T) {
+	session := newTestSession()
+	bc := &baseClient[int, string]{session: session, name: "test-cache", destroyed: true}
+
+	for i := 0; i < 3; i++ {
+		if _, err := executeSize(context.Background(), bc); !errors.Is(err, ErrDestroyed) {
+			t.Fatalf("expected ErrDestroyed, got %v", err)
+		}
+	}
+
+	ctx, op := session.startOperation(context.Background(), "test-queue", "PollHead", true)
+	recordResponseReceived(ctx, responseMessage{})
+	op.end(fmt.Errorf("wrapped: %w", context.DeadlineExceeded))
+
+	// leave an operation in flight
+	_, _ = session.startOperation(context.Background(), "test-cache", "Get", false)
+
+	session.metrics.eventReceived("test-cache")
+	session.metrics.eventReceived("test-cache")
+	session.metrics.streamReconnected()
+
+	metrics := session.Metrics()
+	if len(metrics.Operations) != 3 {
+		t.Fatalf("expected 3 operations, got %v", metrics.Operations)
+	}
+
+	get, size, poll := metrics.Operations[0], metrics.Operations[1], metrics.Operations[2]
+	if get.Operation != "Get" || get.Requests != 1 || get.InFlight != 1 || get.Latency.Count != 0 {
+		t.Fatalf("unexpected Get metrics %+v", get)
+	}
+	if size.Operation != "Size" || size.Requests != 3 || size.Errors != 3 || size.Timeouts != 0 || size.InFlight != 0 ||
+		size.Latency.Count != 3 || size.Latency.Counts[len(size.Latency.Counts)-1] != 3 {
+		t.Fatalf("unexpected Size metrics %+v", size)
+	}
+	if !poll.Queue || poll.Errors != 1 || poll.Timeouts != 1 {
+		t.Fatalf("unexpected PollHead metrics %+v", poll)
+	}
+	if metrics.Events["test-cache"] != 2 || metrics.StreamReconnects != 1 {
+		t.Fatalf("unexpected event or reconnect metrics %+v", metrics)
+	}
+
+	recorder := httptest.NewRecorder()
+	session.MetricsHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+
+	for _, expected := range []string{
+		"# TYPE coherence_client_requests_total counter",
+		`coherence_client_requests_total{name="test-cache",type="cache",operation="Size"} 3`,
+		`coherence_client_request_timeouts_total{name="test-queue",type="queue",operation="PollHead"} 1`,
+		`coherence_client_requests_in_flight{name="test-cache",type="cache",operation="Get"} 1`,
+		`coherence_client_request_duration_seconds_bucket{name="test-cache",type="cache",operation="Size",le="+Inf"} 3`,
+		`coherence_client_request_duration_seconds_count{name="test-cache",type="cache",operation="Size"} 3`,
+		`coherence_client_events_total{name="test-cache"} 2`,
+		"coherence_client_stream_reconnects_total 1",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Fatalf("expected %q in metrics output:\n%s", expected, body)
+		}
+	}
+}
+
+func TestOperationMetricsLatencyBuckets(t *testing.T) {
+	m := newSessionMetrics()
+	om := m.operation("test", "Get", false)
+
+	om.ended(latencyBuckets[0], OperationResult{})
+	om.ended(3*time.Millisecond, OperationResult{})
+	om.ended(time.Minute, OperationResult{Err: status.Error(codes.DeadlineExceeded, "timeout")})
+
+	metrics := m.snapshot().Operations[0]
+
+	histogram := metrics.Latency
+	if histogram.Counts[0] != 1 || histogram.Counts[3] != 2 || histogram.Counts[len(histogram.Counts)-1] != 2 || histogram.Count != 3 {
+		t.Fatalf("unexpected latency histogram %+v", histogram)
+	}
+	if metrics.Timeouts != 1 {
+		t.Fatalf("expected gRPC DeadlineExceeded to be counted as a timeout")
+	}
+}
+
+func TestMetricsOptIn(t *testing.T) {
+	ctx := context.Background()
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	newSession := func(options ...func(*SessionOptions)) *Session {
+		options = append(options, WithAddress(address), WithPlainText(), WithRequestTimeout(5*time.Second))
+		session, err := NewSession(ctx, options...)
+		if err != nil {
+			t.Fatalf("unable to create session: %v", err)
+		}
+		t.Cleanup(session.Close)
+
+		namedMap, err := GetNamedMap[int, string](session, "metrics")
+		if err != nil {
+			t.Fatalf("unable to get map: %v", err)
+		}
+		if _, err = namedMap.Put(ctx, 1, "one"); err != nil {
+			t.Fatalf("unable to put: %v", err)
+		}
+		return session
+	}
+
+	// metrics are not collected by default, but in-flight requests are still limited
+	session := newSession(WithMaxInFlight(1, FailFast))
+	if session.metrics != nil || len(session.Metrics().Operations) != 0 {
+		t.Fatalf("expected no metrics by default, got %v", session.Metrics())
+	}
+	opCtx, op := session.startOperation(ctx, "metrics", "Get", false)
+	release, err := admitRequest(opCtx)
+	if err != nil {
+		t.Fatalf("expected to acquire a slot, got %v", err)
+	}
+	if _, err = admitRequest(opCtx); !errors.Is(err, ErrMaxInFlightExceeded) {
+		t.Fatalf("expected ErrMaxInFlightExceeded without metrics, got %v", err)
+	}
+	release()
+	op.end(nil)
+
+	session = newSession(WithMetrics())
+	if metrics := session.Metrics(); len(metrics.Operations) != 1 || metrics.Operations[0].Requests != 1 {
+		t.Fatalf("expected the Put to be recorded, got %v", metrics.Operations)
+	}
+}
@@ -27,6 +27,10 @@ func (nc *NamedCacheClient[K, V]) getBaseClient() *baseClient[K, V] { // nolint
 	return nc.baseClient
 }
 
+func (nc *NamedCacheClient[K, V]) getMapFunctions() mapFunctions[K, V] { // nolint
+	return nc.baseClient
+}
+
 // GetCacheName returns the cache name of the [NamedCache].
 func (nc *NamedCacheClient[K, V]) GetCacheName() string {
 	return nc.name
@@ -158,7 +162,7 @@ func (nc *NamedCacheClient[K, V]) Release() {
 	if nc.baseClient.nearCacheListener != nil {
 		err := nc.RemoveListener(context.Background(), nc.baseClient.nearCacheListener.listener)
 		if err != nil {
-			logMessage(WARNING, "unable to remove listener from near cache: %v", err)
+			s.logAttrs(WARNING, cacheAttrs(nc.name), "unable to remove listener from near cache: %v", err)
 		}
 	}
 
@@ -178,7 +182,7 @@ func (nc *NamedCacheClient[K, V]) Release() {
 
 	// remove the cacheID mapping
 	if s.GetProtocolVersion() > 0 {
-		s.cacheIDMap.Remove(nc.name)
+		s.removeCacheID(nc.name)
 	}
 
 	if nc.namedCacheReconnectListener.listener != nil {
@@ -199,7 +203,9 @@ func (nc *NamedCacheClient[K, V]) Release() {
 //	   log.Fatal(err)
 //	}
 func (nc *NamedCacheClient[K, V]) ContainsKey(ctx context.Context, key K) (bool, error) {
-	return executeContainsKey(ctx, nc.baseClient, key)
+	return retryRead(ctx, nc.baseClient, func() (bool, error) {
+		return executeContainsKey(ctx, nc.baseClient, key)
+	})
 }
 
 // ContainsValue returns true if this [NamedCache] contains a mapping for the specified value.
@@ -216,7 +222,9 @@ func (nc *NamedCacheClient[K, V]) ContainsKey(ctx context.Context, key K) (bool,
 //	   log.Fatal(err)
 //	}
 func (nc *NamedCacheClient[K, V]) ContainsValue(ctx context.Context, value V) (bool, error) {
-	return executeContainsValue(ctx, nc.baseClient, value)
+	return retryRead(ctx, nc.baseClient, func() (bool, error) {
+		return executeContainsValue(ctx, nc.baseClient, value)
+	})
 }
 
 // ContainsEntry returns true if this [NamedCache] contains a mapping for the specified key and value.
@@ -233,12 +241,16 @@ func (nc *NamedCacheClient[K, V]) ContainsValue(ctx context.Context, value V) (b
 //	   log.Fatal(err)
 //	}
 func (nc *NamedCacheClient[K, V]) ContainsEntry(ctx context.Context, key K, value V) (bool, error) {
-	return executeContainsEntry(ctx, nc.baseClient, key, value)
+	return retryRead(ctx, nc.baseClient, func() (bool, error) {
+		return executeContainsEntry(ctx, nc.baseClient, key, value)
+	})
 }
 
 // IsEmpty returns true if this [NamedCache] contains no mappings.
 func (nc *NamedCacheClient[K, V]) IsEmpty(ctx context.Context) (bool, error) {
-	return executeIsEmpty(ctx, nc.baseClient)
+	return retryRead(ctx, nc.baseClient, func() (bool, error) {
+		return executeIsEmpty(ctx, nc.baseClient)
+	})
 }
 
 // EntrySetFilter returns a channel from which entries satisfying the specified filter can be obtained.
@@ -261,7 +273,9 @@ func (nc *NamedCacheClient[K, V]) IsEmpty(ctx context.Context) (bool, error) {
 //	    }
 //	}
 func (nc *NamedCacheClient[K, V]) EntrySetFilter(ctx context.Context, fltr filters.Filter) <-chan *StreamedEntry[K, V] {
-	return executeEntrySetFilter[K, V, any](ctx, nc.baseClient, fltr, nil)
+	return retryStream(ctx, nc.baseClient, func() <-chan *StreamedEntry[K, V] {
+		return executeEntrySetFilter(ctx, nc.baseClient, fltr, nil)
+	})
 }
 
 // EntrySet returns a channel from which all entries can be obtained.
@@ -285,7 +299,9 @@ func (nc *NamedCacheClient[K, V]) EntrySetFilter(ctx context.Context, fltr filte
 //	    }
 //	}
 func (nc *NamedCacheClient[K, V]) EntrySet(ctx context.Context) <-chan *StreamedEntry[K, V] {
-	return executeEntrySet[K, V](ctx, nc.baseClient)
+	return retryStream(ctx, nc.baseClient, func() <-chan *StreamedEntry[K, V] {
+		return executeEntrySet[K, V](ctx, nc.baseClient)
+	})
 }
 
 // Get returns the value to which the specified key is mapped. V will be nil
@@ -306,7 +322,9 @@ func (nc *NamedCacheClient[K, V]) EntrySet(ctx context.Context) <-chan *Streamed
 //	    fmt.Println("No person found")
 //	}
 func (nc *NamedCacheClient[K, V]) Get(ctx context.Context, key K) (*V, error) {
-	return executeGet(ctx, nc.baseClient, key)
+	return retryRead(ctx, nc.baseClient, func() (*V, error) {
+		return executeGet(ctx, nc.baseClient, key)
+	})
 }
 
 // GetAll returns a channel from which entries satisfying the specified filter can be obtained.
@@ -329,13 +347,17 @@ func (nc *NamedCacheClient[K, V]) Get(ctx context.Context, key K) (*V, error) {
 //	    }
 //	}
 func (nc *NamedCacheClient[K, V]) GetAll(ctx context.Context, keys []K) <-chan *StreamedEntry[K, V] {
-	return executeGetAll[K, V](ctx, nc.baseClient, keys)
+	return retryStream(ctx, nc.baseClient, func() <-chan *StreamedEntry[K, V] {
+		return executeGetAll[K, V](ctx, nc.baseClient, keys)
+	})
 }
 
 // GetOrDefault will return the value mapped to the specified key,
 // or if there is no mapping, it will return the specified default.
 func (nc *NamedCacheClient[K, V]) GetOrDefault(ctx context.Context, key K, def V) (*V, error) {
-	return executeGetOrDefault(ctx, nc.baseClient, key, def)
+	return retryRead(ctx, nc.baseClient, func() (*V, error) {
+		return executeGetOrDefault(ctx, nc.baseClient, key, def)
+	})
 }
 
 // KeySetFilter returns a channel from which keys of the entries that satisfy the filter can be obtained.
@@ -361,7 +383,9 @@ func (nc *NamedCacheClient[K, V]) GetOrDefault(ctx context.Context, key K, def V
 // Note: the entries are sorted internally on the gRPC proxy to avoid excessive memory usage, but you need to be
 // careful when running this operation against NamedCaches with large number of entries.
 func (nc *NamedCacheClient[K, V]) KeySetFilter(ctx context.Context, fltr filters.Filter) <-chan *StreamedKey[K] {
-	return executeKeySetFilter(ctx, nc.baseClient, fltr)
+	return retryStream(ctx, nc.baseClient, func() <-chan *StreamedKey[K] {
+		return executeKeySetFilter(ctx, nc.baseClient, fltr)
+	})
 }
 
 // KeySet returns a channel from which keys of all entries can be obtained.
@@ -385,7 +409,9 @@ func (nc *NamedCacheClient[K, V]) KeySetFilter(ctx context.Context, fltr filters
 //	    }
 //	}
 func (nc *NamedCacheClient[K, V]) KeySet(ctx context.Context) <-chan *StreamedKey[K] {
-	return executeKeySet[K, V](ctx, nc.baseClient)
+	return retryStream(ctx, nc.baseClient, func() <-chan *StreamedKey[K] {
+		return executeKeySet[K, V](ctx, nc.baseClient)
+	})
 }
 
 // Name returns the name of the [NamedCache]].
@@ -544,7 +570,9 @@ func (nc *NamedCacheClient[K, V]) GetSession() *Session {
 
 // Size returns the number of mappings contained within this [NamedCache].
 func (nc *NamedCacheClient[K, V]) Size(ctx context.Context) (int, error) {
-	return executeSize(ctx, nc.baseClient)
+	return retryRead(ctx, nc.baseClient, func() (int, error) {
+		return executeSize(ctx, nc.baseClient)
+	})
 }
 
 // ValuesFilter returns a view of filtered values contained in this [NamedCache].
@@ -567,7 +595,9 @@ func (nc *NamedCacheClient[K, V]) Size(ctx context.Context) (int, error) {
 //	    }
 //	}
 func (nc *NamedCacheClient[K, V]) ValuesFilter(ctx context.Context, fltr filters.Filter) <-chan *StreamedValue[V] {
-	return executeValues[K, V, any](ctx, nc.baseClient, fltr, nil)
+	return retryStream(ctx, nc.baseClient, func() <-chan *StreamedValue[V] {
+		return executeValues[K, V, any](ctx, nc.baseClient, fltr, nil)
+	})
 }
 
 // Values returns a view of all values contained in this [NamedCache].
@@ -591,7 +621,9 @@ func (nc *NamedCacheClient[K, V]) ValuesFilter(ctx context.Context, fltr filters
 //	    }
 //	}
 func (nc *NamedCacheClient[K, V]) Values(ctx context.Context) <-chan *StreamedValue[V] {
-	return executeValuesNoFilter[K, V](ctx, nc.baseClient)
+	return retryStream(ctx, nc.baseClient, func() <-chan *StreamedValue[V] {
+		return executeValuesNoFilter[K, V](ctx, nc.baseClient)
+	})
 }
 
 // IsReady returns whether this [NamedCache] is ready to be used.
@@ -600,7 +632,9 @@ func (nc *NamedCacheClient[K, V]) Values(ctx context.Context) <-chan *StreamedVa
 // storage-enabled members.
 // If it is not supported by the gRPC proxy, an error will be returned.
 func (nc *NamedCacheClient[K, V]) IsReady(ctx context.Context) (bool, error) {
-	return executeIsReady[K, V](ctx, nc.baseClient)
+	return retryRead(ctx, nc.baseClient, func() (bool, error) {
+		return executeIsReady[K, V](ctx, nc.baseClient)
+	})
 }
 
 // GetNearCacheStats returns the [CacheStats] for a near cache for a [NamedMap].
@@ -624,7 +658,7 @@ func getNamedCache[K comparable, V any](session *Session, name string, sOpts *Se
 		ok            bool
 	)
 
-	if session.closed {
+	if session.closed.Load() {
 		return nil, ErrClosed
 	}
 
@@ -646,6 +680,38 @@ func getNamedCache[K comparable, V any](session *Session, name string, sOpts *Se
 		return nil, err
 	}
 
+	if cacheOptions.MaxInFlight < 0 {
+		return nil, ErrInvalidMaxInFlight
+	}
+
+	if cacheOptions.CircuitBreakerPolicy != nil {
+		if err = cacheOptions.CircuitBreakerPolicy.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if cacheOptions.RetryPolicy != nil {
+		if err = cacheOptions.RetryPolicy.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if cacheOptions.ValueCompression != nil {
+		if err = cacheOptions.ValueCompression.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if format, err = validateSerializers[K, V](format, cacheOptions); err != nil {
+		return nil, err
+	}
+
+	if cacheOptions.ValueEncryption != nil {
+		if err = validateEncryption[V](format, cacheOptions); err != nil {
+			return nil, err
+		}
+	}
+
 	// check to see if we already have an entry for the cache
 	if existingCache, ok = session.caches[name]; ok {
 		existing, ok2 := existingCache.(*NamedCacheClient[K, V])
@@ -672,12 +738,15 @@ func getNamedCache[K comparable, V any](session *Session, name string, sOpts *Se
 	if err = newCache.baseClient.ensureClientConnection(); err != nil {
 		return nil, err
 	}
+	if err = validateStreamFormat(session, sOpts, format); err != nil {
+		return nil, err
+	}
 
 	namedCache := convertNamedCacheClient[K, V](newCache)
 
 	if session.GetProtocolVersion() > 0 {
 		// ensure the cache via gRPC v1
-		_, err = session.v1StreamManagerCache.ensureCache(context.Background(), name)
+		_, err = session.ensureCacheStreams(context.Background(), name)
 		if err != nil {
 			return nil, err
 		}
@@ -730,7 +799,7 @@ func newNamedCacheReconnectListener[K comparable, V any](nc NamedCacheClient[K,
 		// re-register listeners for the NamedCache
 		namedMap := convertNamedCacheClient[K, V](&nc)
 		if err := reRegisterListeners[K, V](context.Background(), &namedMap, nc.baseClient); err != nil {
-			logMessage(WARNING, "error re-registering listeners: %v", err)
+			nc.session.logAttrs(WARNING, cacheAttrs(nc.name), "error re-registering listeners: %v", err)
 		}
 	})
 
@@ -761,7 +830,7 @@ func newNearNamedCacheMapLister[K comparable, V any](nc NamedCacheClient[K, V],
 	listener.listener.OnAny(func(e MapEvent[K, V]) {
 		err := processNearCacheEvent(nc.baseClient.nearCache, e)
 		if err != nil {
-			logMessage(WARNING, "error processing near cache MapEvent: %v", e)
+			nc.session.logAttrs(WARNING, cacheAttrs(nc.name), "error processing near cache MapEvent: %v", e)
 		}
 	})
 
@@ -32,6 +32,10 @@ func (nm *NamedMapClient[K, V]) getBaseClient() *baseClient[K, V] { //nolint
 	return nm.baseClient
 }
 
+func (nm *NamedMapClient[K, V]) getMapFunctions() mapFunctions[K, V] { //nolint
+	return nm.baseClient
+}
+
 // Invoke the specified processor against the entry mapped to the specified key.
 // Processors are invoked atomically against a specific entry as the process may mutate the entry.
 // The type parameter is R = type of the result of the invocation.
@@ -46,7 +50,11 @@ func (nm *NamedMapClient[K, V]) getBaseClient() *baseClient[K, V] { //nolint
 //	newAge, err := coherence.Invoke[int, Person, int](ctx, namedMap, 1, processors.Increment("age", 1))
 //	fmt.Println("New age is", *newAge)
 func Invoke[K comparable, V, R any](ctx context.Context, nm NamedMap[K, V], key K, proc processors.Processor) (*R, error) {
-	return executeInvoke[K, V, R](ctx, nm.getBaseClient(), key, proc)
+	data, err := nm.getMapFunctions().invokeKey(ctx, key, proc)
+	if err != nil {
+		return nil, err
+	}
+	return NewSerializer[R](nm.getBaseClient().format).Deserialize(data)
 }
 
 // InvokeAllFilter invokes the specified function against the entries matching the specified filter.
@@ -75,7 +83,7 @@ func Invoke[K comparable, V, R any](ctx context.Context, nm NamedMap[K, V], key
 //	    }
 //	}
 func InvokeAllFilter[K comparable, V any, R any](ctx context.Context, nm NamedMap[K, V], fltr filters.Filter, proc processors.Processor) <-chan *StreamedEntry[K, R] {
-	return executeInvokeAllFilterOrKeys[K, V, R](ctx, nm.getBaseClient(), fltr, []K{}, proc)
+	return invokeAll[K, V, R](ctx, nm, nil, fltr, proc)
 }
 
 // InvokeAllFilterBlind invokes the specified function against the entries matching the specified filter but does not return results via a channel.
@@ -96,13 +104,7 @@ func InvokeAllFilter[K comparable, V any, R any](ctx context.Context, nm NamedMa
 //	    log.Fatal(err)
 //	}
 func InvokeAllFilterBlind[K comparable, V any](ctx context.Context, nm NamedMap[K, V], fltr filters.Filter, proc processors.Processor) error {
-	ch := executeInvokeAllFilterOrKeys[K, V, any](ctx, nm.getBaseClient(), fltr, []K{}, proc)
-	for e := range ch {
-		if e.Err != nil {
-			return e.Err
-		}
-	}
-	return nil
+	return invokeAllBlind(ctx, nm, nil, fltr, proc)
 }
 
 // InvokeAllKeys invokes the specified function against the entries matching the specified keys.
@@ -129,7 +131,7 @@ func InvokeAllFilterBlind[K comparable, V any](ctx context.Context, nm NamedMap[
 //	    }
 //	}
 func InvokeAllKeys[K comparable, V any, R any](ctx context.Context, nm NamedMap[K, V], keys []K, proc processors.Processor) <-chan *StreamedEntry[K, R] {
-	return executeInvokeAllFilterOrKeys[K, V, R](ctx, nm.getBaseClient(), nil, keys, proc)
+	return invokeAll[K, V, R](ctx, nm, keys, nil, proc)
 }
 
 // InvokeAllKeysBlind invokes the specified function against the entries matching the specified keys but does not return results via a channel.
@@ -148,13 +150,7 @@ func InvokeAllKeys[K comparable, V any, R any](ctx context.Context, nm NamedMap[
 //	    log.Fatal(err)
 //	}
 func InvokeAllKeysBlind[K comparable, V any](ctx context.Context, nm NamedMap[K, V], keys []K, proc processors.Processor) error {
-	ch := executeInvokeAllFilterOrKeys[K, V, any](ctx, nm.getBaseClient(), nil, keys, proc)
-	for e := range ch {
-		if e.Err != nil {
-			return e.Err
-		}
-	}
-	return nil
+	return invokeAllBlind(ctx, nm, keys, nil, proc)
 }
 
 // InvokeAll invokes the specified function against all entries in a [NamedMap].
@@ -181,7 +177,7 @@ func InvokeAllKeysBlind[K comparable, V any](ctx context.Context, nm NamedMap[K,
 //	    }
 //	}
 func InvokeAll[K comparable, V any, R any](ctx context.Context, nm NamedMap[K, V], proc processors.Processor) <-chan *StreamedEntry[K, R] {
-	return executeInvokeAllFilterOrKeys[K, V, R](ctx, nm.getBaseClient(), filters.Always(), nil, proc)
+	return invokeAll[K, V, R](ctx, nm, nil, filters.Always(), proc)
 }
 
 // InvokeAllBlind invokes the specified function against all entries in a [NamedMap] but does not return results via a channel.
@@ -200,13 +196,7 @@ func InvokeAll[K comparable, V any, R any](ctx context.Context, nm NamedMap[K, V
 //	    log.Fatal(err)
 //	}
 func InvokeAllBlind[K comparable, V any](ctx context.Context, nm NamedMap[K, V], proc processors.Processor) error {
-	ch := executeInvokeAllFilterOrKeys[K, V, any](ctx, nm.getBaseClient(), filters.Always(), nil, proc)
-	for e := range ch {
-		if e.Err != nil {
-			return e.Err
-		}
-	}
-	return nil
+	return invokeAllBlind(ctx, nm, nil, filters.Always(), proc)
 }
 
 // AggregateKeys performs an aggregating operation (identified by aggregator) against the
@@ -226,7 +216,7 @@ func InvokeAllBlind[K comparable, V any](ctx context.Context, nm NamedMap[K, V],
 //	}
 //	fmt.Println("Minimum age of people with keys 3, 4 and 5 is", *minAge)
 func AggregateKeys[K comparable, V, R any](ctx context.Context, nm NamedMap[K, V], keys []K, aggr aggregators.Aggregator[R]) (*R, error) {
-	return executeAggregate[K, V, R](ctx, nm.getBaseClient(), keys, nil, aggr)
+	return aggregate[K, V, R](ctx, nm, keys, nil, aggr)
 }
 
 // AggregateFilter performs an aggregating operation (identified by aggregator) against the
@@ -246,8 +236,7 @@ func AggregateKeys[K comparable, V, R any](ctx context.Context, nm NamedMap[K, V
 //	}
 //	fmt.Println("Number of people aged greater than 19 is", *count)
 func AggregateFilter[K comparable, V, R any](ctx context.Context, nm NamedMap[K, V], filter filters.Filter, aggr aggregators.Aggregator[R]) (*R, error) {
-	var noKeys = make([]K, 0)
-	return executeAggregate[K, V, R](ctx, nm.getBaseClient(), noKeys, filter, aggr)
+	return aggregate[K, V, R](ctx, nm, nil, filter, aggr)
 }
 
 // Aggregate performs an aggregating operation (identified by aggregator) against all the
@@ -269,7 +258,7 @@ func AggregateFilter[K comparable, V, R any](ctx context.Context, nm NamedMap[K,
 //	value, _ := bigRat.Float32()
 //	fmt.Printf("Average age of people is %.2f\n", value)
 func Aggregate[K comparable, V, R any](ctx context.Context, nm NamedMap[K, V], aggr aggregators.Aggregator[R]) (*R, error) {
-	return executeAggregate[K, V, R](ctx, nm.getBaseClient(), make([]K, 0), nil, aggr)
+	return aggregate[K, V, R](ctx, nm, nil, nil, aggr)
 }
 
 // AddIndex adds the index based upon the supplied [extractors.ValueExtractor].
@@ -286,7 +275,7 @@ func Aggregate[K comparable, V, R any](ctx context.Context, nm NamedMap[K, V], a
 //	    log.Fatal(err)
 //	}
 func AddIndex[K comparable, V, T, E any](ctx context.Context, nm NamedMap[K, V], extractor extractors.ValueExtractor[T, E], sorted bool) error {
-	return executeAddIndex(ctx, nm.getBaseClient(), extractor, sorted, nil)
+	return nm.getMapFunctions().addIndex(ctx, extractor, sorted, nil)
 }
 
 // AddIndexWithComparator adds the index based upon the supplied [extractors.ValueExtractor] and comparator.
@@ -304,7 +293,7 @@ func AddIndex[K comparable, V, T, E any](ctx context.Context, nm NamedMap[K, V],
 //	    log.Fatal(err)
 //	}
 func AddIndexWithComparator[K comparable, V, T, E any](ctx context.Context, nm NamedMap[K, V], extractor extractors.ValueExtractor[T, E], comparator extractors.Comparator[E]) error {
-	return executeAddIndex(ctx, nm.getBaseClient(), extractor, false, comparator)
+	return nm.getMapFunctions().addIndex(ctx, extractor, false, comparator)
 }
 
 // EntrySetFilterWithComparator returns a channel from which entries satisfying the specified filter can be obtained.
@@ -336,7 +325,7 @@ func AddIndexWithComparator[K comparable, V, T, E any](ctx context.Context, nm N
 //
 // This feature is only available when connecting to Coherence server versions CE 25.03+ and commercial 14.1.2.0+.
 func EntrySetFilterWithComparator[K comparable, V, E any](ctx context.Context, nm NamedMap[K, V], filter filters.Filter, comparator extractors.Comparator[E]) <-chan *StreamedEntry[K, V] {
-	return executeEntrySetFilter(ctx, nm.getBaseClient(), filter, comparator)
+	return nm.getMapFunctions().entrySet(ctx, filter, comparator)
 }
 
 // RemoveIndex removes index based upon the supplied [extractors.ValueExtractor].
@@ -353,7 +342,35 @@ func EntrySetFilterWithComparator[K comparable, V, E any](ctx context.Context, n
 //	    log.Fatal(err)
 //	}
 func RemoveIndex[K comparable, V, T, E any](ctx context.Context, nm NamedMap[K, V], extractor extractors.ValueExtractor[T, E]) error {
-	return executeRemoveIndex(ctx, nm.getBaseClient(), extractor)
+	return nm.getMapFunctions().removeIndex(ctx, extractor)
+}
+
+// invokeAll runs an entry processor against the entries for the keys, or matching the filter, and returns
+// a channel of the results.
+func invokeAll[K comparable, V, R any](ctx context.Context, nm NamedMap[K, V], keys []K, filter filters.Filter, proc processors.Processor) <-chan *StreamedEntry[K, R] {
+	results := newStreamedResults[K, R](nm.getBaseClient().format)
+	nm.getMapFunctions().invokeAll(ctx, keys, filter, proc, results)
+	return results.ch
+}
+
+// invokeAllBlind runs an entry processor against the entries for the keys, or matching the filter,
+// and returns the first error encountered, if any.
+func invokeAllBlind[K comparable, V any](ctx context.Context, nm NamedMap[K, V], keys []K, filter filters.Filter, proc processors.Processor) error {
+	for e := range invokeAll[K, V, any](ctx, nm, keys, filter, proc) {
+		if e.Err != nil {
+			return e.Err
+		}
+	}
+	return nil
+}
+
+// aggregate runs an aggregator against the entries for the keys, or matching the filter, and returns the result.
+func aggregate[K comparable, V, R any](ctx context.Context, nm NamedMap[K, V], keys []K, filter filters.Filter, aggr aggregators.Aggregator[R]) (*R, error) {
+	data, err := nm.getMapFunctions().aggregate(ctx, keys, filter, aggr)
+	if err != nil {
+		return nil, err
+	}
+	return NewSerializer[R](nm.getBaseClient().format).Deserialize(data)
 }
 
 // GetCacheName returns the cache name of the [NamedMap].
@@ -487,7 +504,7 @@ func (nm *NamedMapClient[K, V]) Release() {
 	if nm.baseClient.nearCacheListener != nil {
 		err := nm.RemoveListener(context.Background(), nm.baseClient.nearCacheListener.listener)
 		if err != nil {
-			logMessage(WARNING, "unable to remove listener to near cache: %v", err)
+			s.logAttrs(WARNING, cacheAttrs(nm.name), "unable to remove listener to near cache: %v", err)
 		}
 	}
 
@@ -507,7 +524,7 @@ func (nm *NamedMapClient[K, V]) Release() {
 
 	// remove the cacheID mapping
 	if s.GetProtocolVersion() > 0 {
-		s.cacheIDMap.Remove(nm.Name())
+		s.removeCacheID(nm.Name())
 	}
 
 	if nm.namedMapReconnectListener.listener != nil {
@@ -528,7 +545,9 @@ func (nm *NamedMapClient[K, V]) Release() {
 //	   log.Fatal(err)
 //	}
 func (nm *NamedMapClient[K, V]) ContainsKey(ctx context.Context, key K) (bool, error) {
-	return executeContainsKey(ctx, nm.baseClient, key)
+	return retryRead(ctx, nm.baseClient, func() (bool, error) {
+		return executeContainsKey(ctx, nm.baseClient, key)
+	})
 }
 
 // ContainsValue returns true if the [NamedMap] contains a mapping for the specified value.
@@ -545,7 +564,9 @@ func (nm *NamedMapClient[K, V]) ContainsKey(ctx context.Context, key K) (bool, e
 //	   log.Fatal(err)
 //	}
 func (nm *NamedMapClient[K, V]) ContainsValue(ctx context.Context, value V) (bool, error) {
-	return executeContainsValue(ctx, nm.baseClient, value)
+	return retryRead(ctx, nm.baseClient, func() (bool, error) {
+		return executeContainsValue(ctx, nm.baseClient, value)
+	})
 }
 
 // ContainsEntry returns true if the [NamedMap] contains a mapping for the specified key and value.
@@ -562,12 +583,16 @@ func (nm *NamedMapClient[K, V]) ContainsValue(ctx context.Context, value V) (boo
 //	   log.Fatal(err)
 //	}
 func (nm *NamedMapClient[K, V]) ContainsEntry(ctx context.Context, key K, value V) (bool, error) {
-	return executeContainsEntry(ctx, nm.baseClient, key, value)
+	return retryRead(ctx, nm.baseClient, func() (bool, error) {
+		return executeContainsEntry(ctx, nm.baseClient, key, value)
+	})
 }
 
 // IsEmpty returns true if the [NamedMap] contains no mappings.
 func (nm *NamedMapClient[K, V]) IsEmpty(ctx context.Context) (bool, error) {
-	return executeIsEmpty(ctx, nm.baseClient)
+	return retryRead(ctx, nm.baseClient, func() (bool, error) {
+		return executeIsEmpty(ctx, nm.baseClient)
+	})
 }
 
 // EntrySetFilter returns a channel from which entries satisfying the specified filter can be obtained.
@@ -590,7 +615,9 @@ func (nm *NamedMapClient[K, V]) IsEmpty(ctx context.Context) (bool, error) {
 //	    }
 //	}
 func (nm *NamedMapClient[K, V]) EntrySetFilter(ctx context.Context, fltr filters.Filter) <-chan *StreamedEntry[K, V] {
-	return executeEntrySetFilter[K, V, any](ctx, nm.baseClient, fltr, nil)
+	return retryStream(ctx, nm.baseClient, func() <-chan *StreamedEntry[K, V] {
+		return executeEntrySetFilter(ctx, nm.baseClient, fltr, nil)
+	})
 }
 
 // EntrySetFilterWithComparator returns a channel from which entries satisfying the specified filter can be obtained.
@@ -620,7 +647,9 @@ func (nm *NamedMapClient[K, V]) EntrySetFilter(ctx context.Context, fltr filters
 // Note: the entries are sorted internally on the gRPC proxy to avoid excessive memory usage, but you need to be
 // careful when running this operation against NamedMaps with large number of entries.
 func (nm *NamedMapClient[K, V]) EntrySetFilterWithComparator(ctx context.Context, fltr filters.Filter, comparator extractors.Comparator[any]) <-chan *StreamedEntry[K, V] {
-	return executeEntrySetFilter(ctx, nm.baseClient, fltr, comparator)
+	return retryStream(ctx, nm.baseClient, func() <-chan *StreamedEntry[K, V] {
+		return executeEntrySetFilter(ctx, nm.baseClient, fltr, comparator)
+	})
 }
 
 // EntrySet returns a channel from which  all entries can be obtained.
@@ -644,7 +673,9 @@ func (nm *NamedMapClient[K, V]) EntrySetFilterWithComparator(ctx context.Context
 //	    }
 //	}
 func (nm *NamedMapClient[K, V]) EntrySet(ctx context.Context) <-chan *StreamedEntry[K, V] {
-	return executeEntrySet[K, V](ctx, nm.baseClient)
+	return retryStream(ctx, nm.baseClient, func() <-chan *StreamedEntry[K, V] {
+		return executeEntrySet[K, V](ctx, nm.baseClient)
+	})
 }
 
 // Get returns the value to which the specified key is mapped. V will be nil
@@ -665,7 +696,9 @@ func (nm *NamedMapClient[K, V]) EntrySet(ctx context.Context) <-chan *StreamedEn
 //	    fmt.Println("No person found")
 //	}
 func (nm *NamedMapClient[K, V]) Get(ctx context.Context, key K) (*V, error) {
-	return executeGet(ctx, nm.baseClient, key)
+	return retryRead(ctx, nm.baseClient, func() (*V, error) {
+		return executeGet(ctx, nm.baseClient, key)
+	})
 }
 
 // GetAll returns a channel from which entries satisfying the specified filter can be obtained.
@@ -688,13 +721,17 @@ func (nm *NamedMapClient[K, V]) Get(ctx context.Context, key K) (*V, error) {
 //	    }
 //	}
 func (nm *NamedMapClient[K, V]) GetAll(ctx context.Context, keys []K) <-chan *StreamedEntry[K, V] {
-	return executeGetAll[K, V](ctx, nm.baseClient, keys)
+	return retryStream(ctx, nm.baseClient, func() <-chan *StreamedEntry[K, V] {
+		return executeGetAll[K, V](ctx, nm.baseClient, keys)
+	})
 }
 
 // GetOrDefault will return the value mapped to the specified key,
 // or if there is no mapping, it will return the specified default.
 func (nm *NamedMapClient[K, V]) GetOrDefault(ctx context.Context, key K, def V) (*V, error) {
-	return executeGetOrDefault(ctx, nm.baseClient, key, def)
+	return retryRead(ctx, nm.baseClient, func() (*V, error) {
+		return executeGetOrDefault(ctx, nm.baseClient, key, def)
+	})
 }
 
 // KeySetFilter returns a channel from which keys of the entries that satisfy the filter can be obtained.
@@ -717,7 +754,9 @@ func (nm *NamedMapClient[K, V]) GetOrDefault(ctx context.Context, key K, def V)
 //	    }
 //	}
 func (nm *NamedMapClient[K, V]) KeySetFilter(ctx context.Context, fltr filters.Filter) <-chan *StreamedKey[K] {
-	return executeKeySetFilter(ctx, nm.baseClient, fltr)
+	return retryStream(ctx, nm.baseClient, func() <-chan *StreamedKey[K] {
+		return executeKeySetFilter(ctx, nm.baseClient, fltr)
+	})
 }
 
 // KeySet returns a channel from which keys of all entries can be obtained.
@@ -741,7 +780,9 @@ func (nm *NamedMapClient[K, V]) KeySetFilter(ctx context.Context, fltr filters.F
 //	    }
 //	}
 func (nm *NamedMapClient[K, V]) KeySet(ctx context.Context) <-chan *StreamedKey[K] {
-	return executeKeySet[K, V](ctx, nm.baseClient)
+	return retryStream(ctx, nm.baseClient, func() <-chan *StreamedKey[K] {
+		return executeKeySet[K, V](ctx, nm.baseClient)
+	})
 }
 
 // Name returns the name of the NamedMap.
@@ -866,7 +907,9 @@ func (nm *NamedMapClient[K, V]) GetSession() *Session {
 
 // Size returns the number of mappings contained within the [NamedMap].
 func (nm *NamedMapClient[K, V]) Size(ctx context.Context) (int, error) {
-	return executeSize(ctx, nm.baseClient)
+	return retryRead(ctx, nm.baseClient, func() (int, error) {
+		return executeSize(ctx, nm.baseClient)
+	})
 }
 
 // ValuesFilter returns a view of filtered values contained in the [NamedMap].
@@ -889,7 +932,9 @@ func (nm *NamedMapClient[K, V]) Size(ctx context.Context) (int, error) {
 //	    }
 //	}
 func (nm *NamedMapClient[K, V]) ValuesFilter(ctx context.Context, fltr filters.Filter) <-chan *StreamedValue[V] {
-	return executeValues[K, V, any](ctx, nm.baseClient, fltr, nil)
+	return retryStream(ctx, nm.baseClient, func() <-chan *StreamedValue[V] {
+		return executeValues[K, V, any](ctx, nm.baseClient, fltr, nil)
+	})
 }
 
 // Values returns a view of all values contained in the [NamedMap].
@@ -913,7 +958,9 @@ func (nm *NamedMapClient[K, V]) ValuesFilter(ctx context.Context, fltr filters.F
 //	    }
 //	}
 func (nm *NamedMapClient[K, V]) Values(ctx context.Context) <-chan *StreamedValue[V] {
-	return executeValuesNoFilter[K, V](ctx, nm.baseClient)
+	return retryStream(ctx, nm.baseClient, func() <-chan *StreamedValue[V] {
+		return executeValuesNoFilter[K, V](ctx, nm.baseClient)
+	})
 }
 
 // IsReady returns whether this [NamedMap] is ready to be used.
@@ -922,7 +969,9 @@ func (nm *NamedMapClient[K, V]) Values(ctx context.Context) <-chan *StreamedValu
 // storage-enabled members.
 // If it is not supported by the gRPC proxy, an error will be returned.
 func (nm *NamedMapClient[K, V]) IsReady(ctx context.Context) (bool, error) {
-	return executeIsReady[K, V](ctx, nm.baseClient)
+	return retryRead(ctx, nm.baseClient, func() (bool, error) {
+		return executeIsReady[K, V](ctx, nm.baseClient)
+	})
 }
 
 // GetNearCacheStats returns the [CacheStats] for a near cache for a [NamedMap].
@@ -946,7 +995,7 @@ func getNamedMap[K comparable, V any](session *Session, name string, sOpts *Sess
 		ok            bool
 	)
 
-	if session.closed {
+	if session.closed.Load() {
 		return nil, ErrClosed
 	}
 
@@ -968,6 +1017,38 @@ func getNamedMap[K comparable, V any](session *Session, name string, sOpts *Sess
 		return nil, err
 	}
 
+	if cacheOptions.MaxInFlight < 0 {
+		return nil, ErrInvalidMaxInFlight
+	}
+
+	if cacheOptions.CircuitBreakerPolicy != nil {
+		if err = cacheOptions.CircuitBreakerPolicy.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if cacheOptions.RetryPolicy != nil {
+		if err = cacheOptions.RetryPolicy.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if cacheOptions.ValueCompression != nil {
+		if err = cacheOptions.ValueCompression.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if format, err = validateSerializers[K, V](format, cacheOptions); err != nil {
+		return nil, err
+	}
+
+	if cacheOptions.ValueEncryption != nil {
+		if err = validateEncryption[V](format, cacheOptions); err != nil {
+			return nil, err
+		}
+	}
+
 	if cacheOptions.DefaultExpiry != time.Duration(0) {
 		return nil, errors.New("you cannot use a non-zero expiry for a NamedMap")
 	}
@@ -997,12 +1078,15 @@ func getNamedMap[K comparable, V any](session *Session, name string, sOpts *Sess
 	if err = newMap.baseClient.ensureClientConnection(); err != nil {
 		return nil, err
 	}
+	if err = validateStreamFormat(session, sOpts, format); err != nil {
+		return nil, err
+	}
 
 	namedMap := convertNamedMapClient[K, V](newMap)
 
 	if session.GetProtocolVersion() > 0 {
 		// ensure the cache via gRPC v1
-		_, err = session.v1StreamManagerCache.ensureCache(context.Background(), name)
+		_, err = session.ensureCacheStreams(context.Background(), name)
 		if err != nil {
 			return nil, err
 		}
@@ -1056,7 +1140,7 @@ func newNamedMapReconnectListener[K comparable, V any](nm NamedMapClient[K, V])
 		// re-register listeners for the NamedMap
 		namedMap := convertNamedMapClient[K, V](&nm)
 		if err := reRegisterListeners[K, V](context.Background(), &namedMap, nm.baseClient); err != nil {
-			logMessage(WARNING, "unable to re-register listeners: %v", err)
+			nm.session.logAttrs(WARNING, cacheAttrs(nm.name), "unable to re-register listeners: %v", err)
 		}
 	})
 
@@ -1077,6 +1161,18 @@ func newBaseClient[K comparable, V any](session *Session, name string, format st
 		filterListenersV1:    make(map[filters.Filter]*listenerGroupV1[K, V], 0),
 		filterIDToGroupV1:    make(map[int64]*listenerGroupV1[K, V], 0),
 		lifecycleListenersV1: make([]*MapLifecycleListener[K, V], 0),
+		limiter:              newInFlightLimiter(session, cOpts.MaxInFlight, cOpts.MaxInFlightPolicy),
+		breaker:              newCircuitBreaker(session, name, cOpts.CircuitBreakerPolicy),
+	}
+	bc.applySerializers()
+	if cOpts.ValueEncryption != nil && cOpts.ValueEncryption.FieldsOnly {
+		bc.valueSerializer = applyEncryption(bc.valueSerializer, cOpts.ValueEncryption)
+	}
+	if cOpts.ValueCompression != nil {
+		bc.valueSerializer = newCompressionSerializer(bc.valueSerializer, cOpts.ValueCompression, session.metrics.compression(name))
+	}
+	if cOpts.ValueEncryption != nil && !cOpts.ValueEncryption.FieldsOnly {
+		bc.valueSerializer = applyEncryption(bc.valueSerializer, cOpts.ValueEncryption)
 	}
 
 	// if near cache options specified then setup internal local cache
@@ -1118,7 +1214,7 @@ func newNearNamedMapMapLister[K comparable, V any](nc NamedMapClient[K, V], cach
 	listener.listener.OnAny(func(e MapEvent[K, V]) {
 		err := processNearCacheEvent(nc.baseClient.nearCache, e)
 		if err != nil {
-			logMessage(WARNING, "Error processing near cache MapEvent: %v", e)
+			nc.session.logAttrs(WARNING, cacheAttrs(nc.name), "Error processing near cache MapEvent: %v", e)
 		}
 	})
 
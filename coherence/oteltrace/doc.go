@@ -0,0 +1,24 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+/*
+Package oteltrace provides a [coherence.Tracer] which creates an OpenTelemetry span for each
+NamedMap, NamedCache and NamedQueue operation and propagates the trace context to the server.
+It is a separate module so that the OpenTelemetry dependencies are only required by applications which use it.
+
+Example:
+
+	// use the global TracerProvider and TextMapPropagator
+	session, err := coherence.NewSession(ctx, coherence.WithTracer(oteltrace.New()))
+	if err != nil {
+	    log.Fatal(err)
+	}
+	defer session.Close()
+
+The trace context is injected into the request attributes, see [coherence.WithRequestAttributes], so is
+sent to the server when connected to a gRPC v1 proxy.
+*/
+package oteltrace
@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package oteltrace
+
+import (
+	"context"
+
+	"github.com/oracle/coherence-go-client/v2/coherence"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/oracle/coherence-go-client/v2/coherence"
+
+// attribute keys for the spans
+const (
+	dbSystemKey       = attribute.Key("db.system")
+	dbOperationKey    = attribute.Key("db.operation.name")
+	cacheKey          = attribute.Key("coherence.cache")
+	queueKey          = attribute.Key("coherence.queue")
+	requestIDKey      = attribute.Key("coherence.request.id")
+	requestsKey       = attribute.Key("coherence.request.count")
+	requestSizeKey    = attribute.Key("coherence.request.size")
+	responseSizeKey   = attribute.Key("coherence.response.size")
+	dbSystemCoherence = "coherence"
+)
+
+// Tracer is a [coherence.Tracer] which creates an OpenTelemetry client span for each operation and
+// injects the span context into the request attributes of the operation.
+type Tracer struct {
+	tracerProvider trace.TracerProvider
+	propagator     propagation.TextMapPropagator
+	tracer         trace.Tracer
+}
+
+// Option configures a [Tracer].
+type Option func(t *Tracer)
+
+// WithTracerProvider returns an [Option] to set the [trace.TracerProvider] used to create spans,
+// if not set the global TracerProvider is used.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(t *Tracer) {
+		t.tracerProvider = provider
+	}
+}
+
+// WithPropagator returns an [Option] to set the [propagation.TextMapPropagator] used to inject
+// the span context into the request attributes, if not set the global TextMapPropagator is used.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(t *Tracer) {
+		t.propagator = propagator
+	}
+}
+
+// New returns a new [Tracer] configured with the options, which can be passed to [coherence.WithTracer].
+func New(options ...Option) *Tracer {
+	t := &Tracer{}
+
+	for _, f := range options {
+		f(t)
+	}
+
+	if t.tracerProvider == nil {
+		t.tracerProvider = otel.GetTracerProvider()
+	}
+	if t.propagator == nil {
+		t.propagator = otel.GetTextMapPropagator()
+	}
+
+	t.tracer = t.tracerProvider.Tracer(instrumentationName)
+
+	return t
+}
+
+// Start starts a span for the operation and returns a context carrying the span, with the span
+// context injected into the request attributes.
+func (t *Tracer) Start(ctx context.Context, operation coherence.Operation) (context.Context, coherence.OperationSpan) {
+	nameKey := cacheKey
+	if operation.Queue {
+		nameKey = queueKey
+	}
+
+	ctx, span := t.tracer.Start(ctx, operation.Type+" "+operation.Name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			dbSystemKey.String(dbSystemCoherence),
+			dbOperationKey.String(operation.Type),
+			nameKey.String(operation.Name),
+		))
+
+	carrier := propagation.MapCarrier{}
+	t.propagator.Inject(ctx, carrier)
+	if len(carrier) > 0 {
+		ctx = coherence.WithRequestAttributes(ctx, carrier)
+	}
+
+	return ctx, operationSpan{span: span}
+}
+
+// operationSpan ends the OpenTelemetry span when the operation ends.
+type operationSpan struct {
+	span trace.Span
+}
+
+// End records the result of the operation against the span and ends the span.
+func (s operationSpan) End(result coherence.OperationResult) {
+	if result.Requests > 0 {
+		s.span.SetAttributes(
+			requestIDKey.Int64(result.RequestID),
+			requestsKey.Int(result.Requests),
+			requestSizeKey.Int(result.RequestBytes),
+			responseSizeKey.Int(result.ResponseBytes),
+		)
+	}
+
+	if result.Err != nil {
+		s.span.RecordError(result.Err)
+		s.span.SetStatus(codes.Error, result.Err.Error())
+	}
+
+	s.span.End()
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package oteltrace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/oracle/coherence-go-client/v2/coherence"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestTracerInjectsTraceContext(t *testing.T) {
+	tracer := New(WithTracerProvider(noop.NewTracerProvider()), WithPropagator(propagation.TraceContext{}))
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	parent := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	ctx, span := tracer.Start(parent, coherence.Operation{Name: "test-cache", Type: "Get"})
+
+	traceparent, ok := coherence.RequestAttributes(ctx)["traceparent"]
+	if !ok || traceparent != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Fatalf("expected traceparent request attribute, got %v", coherence.RequestAttributes(ctx))
+	}
+
+	span.End(coherence.OperationResult{RequestID: 1, Requests: 1, Err: errors.New("test")})
+}
+
+func TestTracerWithoutTraceContext(t *testing.T) {
+	tracer := New(WithTracerProvider(noop.NewTracerProvider()), WithPropagator(propagation.TraceContext{}))
+
+	ctx, span := tracer.Start(context.Background(), coherence.Operation{Name: "test-queue", Type: "OfferTail", Queue: true})
+	if attributes := coherence.RequestAttributes(ctx); attributes != nil {
+		t.Fatalf("expected no request attributes without a valid span context, got %v", attributes)
+	}
+
+	span.End(coherence.OperationResult{})
+}
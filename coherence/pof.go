@@ -0,0 +1,1037 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/aggregators"
+	"github.com/oracle/coherence-go-client/v2/coherence/extractors"
+	"github.com/oracle/coherence-go-client/v2/coherence/filters"
+	"github.com/oracle/coherence-go-client/v2/coherence/processors"
+)
+
+const (
+	pofFormat = "pof"
+
+	// pofSerializationPrefix is the prefix of POF serialized data, the same as for JSON
+	// as both are written by Coherence using an external serializer.
+	pofSerializationPrefix = 21
+
+	// pofTag is the struct tag which contains the POF property index of a field.
+	pofTag = "pof"
+)
+
+// the predefined POF type ids
+const (
+	pofTypeInt16                = -1
+	pofTypeInt32                = -2
+	pofTypeInt64                = -3
+	pofTypeInt128               = -4
+	pofTypeFloat32              = -5
+	pofTypeFloat64              = -6
+	pofTypeFloat128             = -7
+	pofTypeDecimal32            = -8
+	pofTypeDecimal64            = -9
+	pofTypeDecimal128           = -10
+	pofTypeBoolean              = -11
+	pofTypeOctet                = -12
+	pofTypeOctetString          = -13
+	pofTypeChar                 = -14
+	pofTypeCharString           = -15
+	pofTypeDate                 = -16
+	pofTypeYearMonthInterval    = -17
+	pofTypeTime                 = -18
+	pofTypeTimeInterval         = -19
+	pofTypeDateTime             = -20
+	pofTypeDayTimeInterval      = -21
+	pofTypeCollection           = -22
+	pofTypeUniformCollection    = -23
+	pofTypeArray                = -24
+	pofTypeUniformArray         = -25
+	pofTypeSparseArray          = -26
+	pofTypeUniformSparseArray   = -27
+	pofTypeMap                  = -28
+	pofTypeUniformKeysMap       = -29
+	pofTypeUniformMap           = -30
+	pofTypeIdentity             = -31
+	pofTypeReference            = -32
+	pofValueBooleanFalse        = -33
+	pofValueBooleanTrue         = -34
+	pofValueStringZeroLength    = -35
+	pofValueCollectionEmpty     = -36
+	pofValueReferenceNull       = -37
+	pofValueFloatPosInfinity    = -38
+	pofValueFloatNegInfinity    = -39
+	pofValueFloatNaN            = -40
+	pofValueIntNeg1             = -41
+	pofValueInt0                = -42
+	pofValueInt22               = -64
+	pofTimeZoneNone             = 0
+	pofTimeZoneUTC              = 1
+	pofTimeZoneOffset           = 2
+	pofFractionMillisMultiplier = 1000000
+)
+
+var (
+	// ErrPofTypeNotRegistered indicates that a struct, or a POF user type id, has not been registered
+	// using [RegisterPofType].
+	ErrPofTypeNotRegistered = errors.New("the type has not been registered for POF serialization")
+
+	// ErrInvalidPofType indicates that a type cannot be registered for POF serialization.
+	ErrInvalidPofType = errors.New("invalid POF type")
+
+	// ErrUnsupportedPofValue indicates that a value cannot be serialized or deserialized using POF.
+	ErrUnsupportedPofValue = errors.New("the value is not supported by POF serialization")
+
+	// ErrInvalidPofData indicates that POF serialized data is invalid or cannot be deserialized into the type.
+	ErrInvalidPofData = errors.New("invalid POF data")
+
+	// ErrPofAgentNotSupported indicates that a filter, extractor, entry processor or aggregator from this module
+	// was used with the POF format, which they do not support.
+	ErrPofAgentNotSupported = errors.New("filters, extractors, entry processors and aggregators are not supported by POF serialization")
+
+	_ Serializer[string] = PofSerializer[string]{pofFormat}
+
+	timeType = reflect.TypeOf(time.Time{})
+
+	// agentPackages are the packages of the filters, extractors, entry processors and aggregators, which
+	// are serialized as the JSON classes known to the cluster and have no POF user types.
+	agentPackages = []string{
+		reflect.TypeOf((*filters.Filter)(nil)).Elem().PkgPath(),
+		reflect.TypeOf((*extractors.ValueExtractor[any, any])(nil)).Elem().PkgPath(),
+		reflect.TypeOf((*processors.Processor)(nil)).Elem().PkgPath(),
+		reflect.TypeOf((*aggregators.Aggregator[any])(nil)).Elem().PkgPath(),
+	}
+
+	pofTypes = pofTypeRegistry{
+		byID:   make(map[int32]*pofUserType),
+		byType: make(map[reflect.Type]*pofUserType),
+	}
+)
+
+// pofTypeRegistry maps Go struct types to POF user type ids.
+type pofTypeRegistry struct {
+	mutex  sync.RWMutex
+	byID   map[int32]*pofUserType
+	byType map[reflect.Type]*pofUserType
+}
+
+// pofUserType is a struct registered as a POF user type.
+type pofUserType struct {
+	typeID int32
+	typ    reflect.Type
+	fields []pofField // ordered by property index
+}
+
+// pofField is a struct field which is serialized as a POF property.
+type pofField struct {
+	index int64
+	field int
+}
+
+// RegisterPofType registers the struct type T as the POF user type typeID, which must match the type id
+// configured for the corresponding class in the POF configuration of the cluster and other clients.
+// Each field to be serialized is tagged with its POF property index, fields without a tag are not serialized.
+//
+//	type Customer struct {
+//	    ID   int32  `pof:"0"`
+//	    Name string `pof:"1"`
+//	}
+//
+//	err := coherence.RegisterPofType[Customer](1001)
+//
+// Registering the same type with the same id more than once has no effect.
+func RegisterPofType[T any](typeID int32) error {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct || typ == timeType {
+		return fmt.Errorf("%w: %v is not a struct", ErrInvalidPofType, typ)
+	}
+	if typeID < 0 {
+		return fmt.Errorf("%w: type id %d must not be negative", ErrInvalidPofType, typeID)
+	}
+
+	userType := &pofUserType{typeID: typeID, typ: typ}
+	indexes := make(map[int64]string)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag, ok := field.Tag.Lookup(pofTag)
+		if !ok || tag == "-" {
+			continue
+		}
+		index, err := strconv.ParseInt(strings.Split(tag, ",")[0], 10, 32)
+		if err != nil || index < 0 {
+			return fmt.Errorf("%w: invalid property index %q for field %s", ErrInvalidPofType, tag, field.Name)
+		}
+		if !field.IsExported() {
+			return fmt.Errorf("%w: field %s is not exported", ErrInvalidPofType, field.Name)
+		}
+		if existing, ok := indexes[index]; ok {
+			return fmt.Errorf("%w: fields %s and %s have the same property index %d", ErrInvalidPofType, existing, field.Name, index)
+		}
+		indexes[index] = field.Name
+		userType.fields = append(userType.fields, pofField{index: index, field: i})
+	}
+	sort.Slice(userType.fields, func(i, j int) bool {
+		return userType.fields[i].index < userType.fields[j].index
+	})
+
+	pofTypes.mutex.Lock()
+	defer pofTypes.mutex.Unlock()
+
+	if existing, ok := pofTypes.byID[typeID]; ok && existing.typ != typ {
+		return fmt.Errorf("%w: type id %d is already registered for %v", ErrInvalidPofType, typeID, existing.typ)
+	}
+	if existing, ok := pofTypes.byType[typ]; ok && existing.typeID != typeID {
+		return fmt.Errorf("%w: %v is already registered with type id %d", ErrInvalidPofType, typ, existing.typeID)
+	}
+	pofTypes.byID[typeID] = userType
+	pofTypes.byType[typ] = userType
+	return nil
+}
+
+func (r *pofTypeRegistry) forType(typ reflect.Type) *pofUserType {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.byType[typ]
+}
+
+func (r *pofTypeRegistry) forID(typeID int64) *pofUserType {
+	if typeID > math.MaxInt32 {
+		return nil
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.byID[int32(typeID)]
+}
+
+// PofSerializer serializes data using the Portable Object Format (POF), so that the data can be shared
+// with Java, C++ and .NET clients which use POF. Go types are serialized as follows:
+//
+//   - bool as a boolean, and string as a string
+//   - int8 and int16 as a Java short, int32 as an int and int and int64 as a long
+//   - uint8 as an octet, uint16 as an int and other unsigned integers as a long
+//   - float32 and float64 as a float and a double
+//   - []byte as an octet string, other slices and arrays as a collection and maps as a map
+//   - time.Time as a date-time in UTC
+//   - structs registered using [RegisterPofType] as their user type
+//
+// Pointers are serialized as the value they point to, or null. When deserializing into an interface,
+// collections are returned as []any and maps as map[any]any.
+//
+// The filters, extractors, entry processors and aggregators in this module are only supported by the cluster
+// when serialized as JSON, so they cannot be serialized using POF and [ErrPofAgentNotSupported] is returned.
+type PofSerializer[T any] struct {
+	format string
+}
+
+// Serialize serializes an object of type T and returns the []byte representation.
+func (s PofSerializer[T]) Serialize(object T) ([]byte, error) {
+	if typ := reflect.TypeOf(any(object)); isAgentType(typ) {
+		return nil, fmt.Errorf("%w: %v", ErrPofAgentNotSupported, typ)
+	}
+	return appendPofValue([]byte{pofSerializationPrefix}, reflect.ValueOf(&object).Elem())
+}
+
+// isAgentType returns true if typ is a filter, extractor, entry processor or aggregator from this module.
+func isAgentType(typ reflect.Type) bool {
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ != nil && slices.Contains(agentPackages, typ.PkgPath())
+}
+
+// Deserialize deserialized an object and returns the correct type of T.
+func (s PofSerializer[T]) Deserialize(data []byte) (*T, error) {
+	var zeroValue T
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if data[0] != pofSerializationPrefix {
+		return &zeroValue, fmt.Errorf("invalid serialization prefix %v", data[0])
+	}
+
+	decoder := &pofDecoder{data: data[1:]}
+	value, err := decoder.decode()
+	if err != nil {
+		return &zeroValue, err
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	result := new(T)
+	if err = assignPofValue(reflect.ValueOf(result).Elem(), value); err != nil {
+		return &zeroValue, err
+	}
+	return result, nil
+}
+
+// Format returns the format used for the serializer.
+func (s PofSerializer[T]) Format() string {
+	return s.format
+}
+
+// appendPackedInt appends a POF packed integer, where the first byte holds six bits of the value and
+// a sign bit and each subsequent byte seven bits of the value.
+func appendPackedInt(b []byte, n int64) []byte {
+	var (
+		first byte
+		u     = uint64(n)
+	)
+	if n < 0 {
+		first = 0x40
+		u = uint64(^n)
+	}
+	first |= byte(u & 0x3F)
+	u >>= 6
+
+	if u == 0 {
+		return append(b, first)
+	}
+	b = append(b, first|0x80)
+	for u >= 0x80 {
+		b = append(b, byte(u&0x7F)|0x80)
+		u >>= 7
+	}
+	return append(b, byte(u))
+}
+
+// appendPofValue appends a value, preceded by its type id.
+func appendPofValue(b []byte, v reflect.Value) ([]byte, error) {
+	if !v.IsValid() {
+		return appendPackedInt(b, pofValueReferenceNull), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return appendPackedInt(b, pofValueReferenceNull), nil
+		}
+		return appendPofValue(b, v.Elem())
+
+	case reflect.Bool:
+		if v.Bool() {
+			return appendPackedInt(b, pofValueBooleanTrue), nil
+		}
+		return appendPackedInt(b, pofValueBooleanFalse), nil
+
+	case reflect.Int8, reflect.Int16:
+		return appendPackedInt(appendPackedInt(b, pofTypeInt16), v.Int()), nil
+
+	case reflect.Int32:
+		if n := v.Int(); n >= -1 && n <= 22 {
+			return appendPackedInt(b, pofValueInt0-n), nil
+		}
+		return appendPackedInt(appendPackedInt(b, pofTypeInt32), v.Int()), nil
+
+	case reflect.Int, reflect.Int64:
+		return appendPackedInt(appendPackedInt(b, pofTypeInt64), v.Int()), nil
+
+	case reflect.Uint8:
+		return append(appendPackedInt(b, pofTypeOctet), byte(v.Uint())), nil
+
+	case reflect.Uint16:
+		return appendPackedInt(appendPackedInt(b, pofTypeInt32), int64(v.Uint())), nil
+
+	case reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: %d is too large for a long", ErrUnsupportedPofValue, v.Uint())
+		}
+		return appendPackedInt(appendPackedInt(b, pofTypeInt64), int64(v.Uint())), nil
+
+	case reflect.Float32:
+		return binary.BigEndian.AppendUint32(appendPackedInt(b, pofTypeFloat32), math.Float32bits(float32(v.Float()))), nil
+
+	case reflect.Float64:
+		return binary.BigEndian.AppendUint64(appendPackedInt(b, pofTypeFloat64), math.Float64bits(v.Float())), nil
+
+	case reflect.String:
+		if v.Len() == 0 {
+			return appendPackedInt(b, pofValueStringZeroLength), nil
+		}
+		b = appendPackedInt(appendPackedInt(b, pofTypeCharString), int64(v.Len()))
+		return append(b, v.String()...), nil
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return appendPackedInt(b, pofValueReferenceNull), nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b = appendPackedInt(appendPackedInt(b, pofTypeOctetString), int64(v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				b = append(b, byte(v.Index(i).Uint()))
+			}
+			return b, nil
+		}
+		if v.Len() == 0 {
+			return appendPackedInt(b, pofValueCollectionEmpty), nil
+		}
+		b = appendPackedInt(appendPackedInt(b, pofTypeCollection), int64(v.Len()))
+		var err error
+		for i := 0; i < v.Len(); i++ {
+			if b, err = appendPofValue(b, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+
+	case reflect.Map:
+		return appendPofMap(b, v)
+
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return appendPofDateTime(b, v.Interface().(time.Time)), nil
+		}
+		return appendPofUserType(b, v)
+
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedPofValue, v.Type())
+	}
+}
+
+// appendPofMap appends a map, with the entries ordered by their serialized keys so that
+// equal maps are always serialized the same way.
+func appendPofMap(b []byte, v reflect.Value) ([]byte, error) {
+	if v.IsNil() {
+		return appendPackedInt(b, pofValueReferenceNull), nil
+	}
+
+	type entry struct {
+		key, value []byte
+	}
+	var (
+		entries = make([]entry, 0, v.Len())
+		err     error
+	)
+	for iter := v.MapRange(); iter.Next(); {
+		var e entry
+		if e.key, err = appendPofValue(nil, iter.Key()); err != nil {
+			return nil, err
+		}
+		if e.value, err = appendPofValue(nil, iter.Value()); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	b = appendPackedInt(appendPackedInt(b, pofTypeMap), int64(len(entries)))
+	for _, e := range entries {
+		b = append(append(b, e.key...), e.value...)
+	}
+	return b, nil
+}
+
+// appendPofDateTime appends a time as a date-time in UTC.
+func appendPofDateTime(b []byte, t time.Time) []byte {
+	t = t.UTC()
+	b = appendPackedInt(b, pofTypeDateTime)
+	b = appendPackedInt(b, int64(t.Year()))
+	b = appendPackedInt(b, int64(t.Month()))
+	b = appendPackedInt(b, int64(t.Day()))
+	b = appendPackedInt(b, int64(t.Hour()))
+	b = appendPackedInt(b, int64(t.Minute()))
+	b = appendPackedInt(b, int64(t.Second()))
+
+	// the fraction is either positive milliseconds or negative nanoseconds
+	nanos := int64(t.Nanosecond())
+	if nanos%pofFractionMillisMultiplier == 0 {
+		b = appendPackedInt(b, nanos/pofFractionMillisMultiplier)
+	} else {
+		b = appendPackedInt(b, -nanos)
+	}
+	return appendPackedInt(b, pofTimeZoneUTC)
+}
+
+// appendPofUserType appends a struct registered using RegisterPofType.
+func appendPofUserType(b []byte, v reflect.Value) ([]byte, error) {
+	userType := pofTypes.forType(v.Type())
+	if userType == nil {
+		return nil, fmt.Errorf("%w: %v", ErrPofTypeNotRegistered, v.Type())
+	}
+
+	// the type id is followed by the version id and then each property index and value
+	b = appendPackedInt(appendPackedInt(b, int64(userType.typeID)), 0)
+	var err error
+	for _, f := range userType.fields {
+		b = appendPackedInt(b, f.index)
+		if b, err = appendPofValue(b, v.Field(f.field)); err != nil {
+			return nil, err
+		}
+	}
+	return appendPackedInt(b, -1), nil
+}
+
+// pofDecoder decodes POF serialized data into Go values.
+type pofDecoder struct {
+	data       []byte
+	position   int
+	identities map[int64]any
+}
+
+func (d *pofDecoder) readByte() (byte, error) {
+	if d.position >= len(d.data) {
+		return 0, fmt.Errorf("%w: unexpected end of data", ErrInvalidPofData)
+	}
+	b := d.data[d.position]
+	d.position++
+	return b, nil
+}
+
+func (d *pofDecoder) readBytes(n int64) ([]byte, error) {
+	if n < 0 || n > int64(len(d.data)-d.position) {
+		return nil, fmt.Errorf("%w: unexpected end of data", ErrInvalidPofData)
+	}
+	b := d.data[d.position : d.position+int(n)]
+	d.position += int(n)
+	return b, nil
+}
+
+func (d *pofDecoder) readPackedInt() (int64, error) {
+	b, err := d.readByte()
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		negative = b&0x40 != 0
+		n        = uint64(b & 0x3F)
+		shift    = 6
+	)
+	for b&0x80 != 0 {
+		if shift > 63 {
+			return 0, fmt.Errorf("%w: packed integer is too long", ErrInvalidPofData)
+		}
+		if b, err = d.readByte(); err != nil {
+			return 0, err
+		}
+		n |= uint64(b&0x7F) << shift
+		shift += 7
+	}
+
+	if negative {
+		return ^int64(n), nil
+	}
+	return int64(n), nil
+}
+
+// readSize reads the size of a collection or map.
+func (d *pofDecoder) readSize() (int, error) {
+	n, err := d.readPackedInt()
+	if err != nil {
+		return 0, err
+	}
+	// each element is at least one byte
+	if n < 0 || n > int64(len(d.data)) {
+		return 0, fmt.Errorf("%w: invalid size %d", ErrInvalidPofData, n)
+	}
+	return int(n), nil
+}
+
+// decode reads a type id and the value which follows it.
+func (d *pofDecoder) decode() (any, error) {
+	typeID, err := d.readPackedInt()
+	if err != nil {
+		return nil, err
+	}
+	return d.decodeValue(typeID)
+}
+
+// decodeValue reads a value of the type.
+func (d *pofDecoder) decodeValue(typeID int64) (any, error) {
+	if typeID >= 0 {
+		return d.decodeUserType(typeID)
+	}
+	if typeID <= pofValueIntNeg1 && typeID >= pofValueInt22 {
+		return int32(pofValueInt0 - typeID), nil
+	}
+
+	switch typeID {
+	case pofValueReferenceNull:
+		return nil, nil
+	case pofValueBooleanFalse:
+		return false, nil
+	case pofValueBooleanTrue:
+		return true, nil
+	case pofValueStringZeroLength:
+		return "", nil
+	case pofValueCollectionEmpty:
+		return []any{}, nil
+	case pofValueFloatPosInfinity:
+		return math.Inf(1), nil
+	case pofValueFloatNegInfinity:
+		return math.Inf(-1), nil
+	case pofValueFloatNaN:
+		return math.NaN(), nil
+
+	case pofTypeInt16:
+		n, err := d.readPackedInt()
+		return int16(n), err
+	case pofTypeInt32:
+		n, err := d.readPackedInt()
+		return int32(n), err
+	case pofTypeInt64:
+		return d.readPackedInt()
+	case pofTypeBoolean:
+		n, err := d.readPackedInt()
+		return n != 0, err
+	case pofTypeOctet:
+		return d.readByte()
+	case pofTypeFloat32:
+		b, err := d.readBytes(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(b)), nil
+	case pofTypeFloat64:
+		b, err := d.readBytes(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+
+	case pofTypeOctetString:
+		n, err := d.readPackedInt()
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.readBytes(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case pofTypeChar:
+		return d.readChar()
+	case pofTypeCharString:
+		n, err := d.readPackedInt()
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.readBytes(n)
+		return string(b), err
+
+	case pofTypeDate:
+		return d.readDateTime(true, false)
+	case pofTypeTime:
+		return d.readDateTime(false, true)
+	case pofTypeDateTime:
+		return d.readDateTime(true, true)
+
+	case pofTypeCollection, pofTypeArray:
+		return d.readCollection(false)
+	case pofTypeUniformCollection, pofTypeUniformArray:
+		return d.readCollection(true)
+	case pofTypeSparseArray:
+		return d.readSparseArray(false)
+	case pofTypeUniformSparseArray:
+		return d.readSparseArray(true)
+	case pofTypeMap:
+		return d.readMap(false, false)
+	case pofTypeUniformKeysMap:
+		return d.readMap(true, false)
+	case pofTypeUniformMap:
+		return d.readMap(true, true)
+
+	case pofTypeIdentity:
+		id, err := d.readPackedInt()
+		if err != nil {
+			return nil, err
+		}
+		value, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		if d.identities == nil {
+			d.identities = make(map[int64]any)
+		}
+		d.identities[id] = value
+		return value, nil
+	case pofTypeReference:
+		id, err := d.readPackedInt()
+		if err != nil {
+			return nil, err
+		}
+		value, ok := d.identities[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown reference %d", ErrInvalidPofData, id)
+		}
+		return value, nil
+
+	case pofTypeInt128, pofTypeFloat128, pofTypeDecimal32, pofTypeDecimal64, pofTypeDecimal128,
+		pofTypeYearMonthInterval, pofTypeTimeInterval, pofTypeDayTimeInterval:
+		return nil, fmt.Errorf("%w: POF type %d", ErrUnsupportedPofValue, typeID)
+	}
+
+	return nil, fmt.Errorf("%w: unknown POF type %d", ErrInvalidPofData, typeID)
+}
+
+// readChar reads a single UTF-8 encoded character.
+func (d *pofDecoder) readChar() (rune, error) {
+	r, size := utf8.DecodeRune(d.data[d.position:])
+	if r == utf8.RuneError && size <= 1 {
+		return 0, fmt.Errorf("%w: invalid character", ErrInvalidPofData)
+	}
+	d.position += size
+	return r, nil
+}
+
+// readDateTime reads a date, a time or a date-time, returned as a time.Time.
+func (d *pofDecoder) readDateTime(date, clock bool) (time.Time, error) {
+	var (
+		fields   [6]int64
+		from, to = 0, 6
+		err      error
+	)
+	fields[0], fields[1], fields[2] = 1, 1, 1
+	if !date {
+		from = 3
+	}
+	if !clock {
+		to = 3
+	}
+	for i := from; i < to; i++ {
+		if fields[i], err = d.readPackedInt(); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if !clock {
+		return time.Date(int(fields[0]), time.Month(fields[1]), int(fields[2]), 0, 0, 0, 0, time.UTC), nil
+	}
+
+	fraction, err := d.readPackedInt()
+	if err != nil {
+		return time.Time{}, err
+	}
+	nanos := -fraction
+	if fraction > 0 {
+		nanos = fraction * pofFractionMillisMultiplier
+	}
+
+	location := time.Local
+	zone, err := d.readPackedInt()
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch zone {
+	case pofTimeZoneNone:
+	case pofTimeZoneUTC:
+		location = time.UTC
+	case pofTimeZoneOffset:
+		hours, err := d.readPackedInt()
+		if err != nil {
+			return time.Time{}, err
+		}
+		minutes, err := d.readPackedInt()
+		if err != nil {
+			return time.Time{}, err
+		}
+		location = time.FixedZone("", int(hours*3600+minutes*60))
+	default:
+		return time.Time{}, fmt.Errorf("%w: invalid time zone type %d", ErrInvalidPofData, zone)
+	}
+
+	return time.Date(int(fields[0]), time.Month(fields[1]), int(fields[2]), int(fields[3]), int(fields[4]),
+		int(fields[5]), int(nanos), location), nil
+}
+
+// readCollection reads a collection or array, optionally with a single element type.
+func (d *pofDecoder) readCollection(uniform bool) ([]any, error) {
+	var (
+		elementType int64
+		err         error
+	)
+	if uniform {
+		if elementType, err = d.readPackedInt(); err != nil {
+			return nil, err
+		}
+	}
+	size, err := d.readSize()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]any, size)
+	for i := range result {
+		if uniform {
+			result[i], err = d.decodeValue(elementType)
+		} else {
+			result[i], err = d.decode()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// readSparseArray reads a sparse array, optionally with a single element type.
+func (d *pofDecoder) readSparseArray(uniform bool) ([]any, error) {
+	var (
+		elementType int64
+		err         error
+	)
+	if uniform {
+		if elementType, err = d.readPackedInt(); err != nil {
+			return nil, err
+		}
+	}
+	size, err := d.readSize()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]any, size)
+	for {
+		index, err := d.readPackedInt()
+		if err != nil {
+			return nil, err
+		}
+		if index < 0 {
+			return result, nil
+		}
+		if index >= int64(size) {
+			return nil, fmt.Errorf("%w: sparse array index %d out of range", ErrInvalidPofData, index)
+		}
+		if uniform {
+			result[index], err = d.decodeValue(elementType)
+		} else {
+			result[index], err = d.decode()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readMap reads a map, optionally with a single key type and a single value type.
+func (d *pofDecoder) readMap(uniformKeys, uniformValues bool) (map[any]any, error) {
+	var (
+		keyType, valueType int64
+		err                error
+	)
+	if uniformKeys {
+		if keyType, err = d.readPackedInt(); err != nil {
+			return nil, err
+		}
+	}
+	if uniformValues {
+		if valueType, err = d.readPackedInt(); err != nil {
+			return nil, err
+		}
+	}
+	size, err := d.readSize()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[any]any, size)
+	for i := 0; i < size; i++ {
+		var key, value any
+		if uniformKeys {
+			key, err = d.decodeValue(keyType)
+		} else {
+			key, err = d.decode()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if uniformValues {
+			value, err = d.decodeValue(valueType)
+		} else {
+			value, err = d.decode()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if key != nil && !reflect.TypeOf(key).Comparable() {
+			return nil, fmt.Errorf("%w: map key of type %T is not comparable", ErrUnsupportedPofValue, key)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// decodeUserType reads a user type registered using RegisterPofType, returning the struct value.
+func (d *pofDecoder) decodeUserType(typeID int64) (any, error) {
+	userType := pofTypes.forID(typeID)
+	if userType == nil {
+		return nil, fmt.Errorf("%w: type id %d", ErrPofTypeNotRegistered, typeID)
+	}
+
+	// the version id is not used as properties which are not known are ignored
+	if _, err := d.readPackedInt(); err != nil {
+		return nil, err
+	}
+
+	result := reflect.New(userType.typ).Elem()
+	for {
+		index, err := d.readPackedInt()
+		if err != nil {
+			return nil, err
+		}
+		if index < 0 {
+			return result.Interface(), nil
+		}
+
+		value, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range userType.fields {
+			if f.index == index {
+				if err = assignPofValue(result.Field(f.field), value); err != nil {
+					return nil, fmt.Errorf("%w, property %d of %v", err, index, userType.typ)
+				}
+				break
+			}
+		}
+	}
+}
+
+// assignPofValue sets target to a value returned by the decoder, converting it to the type of target.
+func assignPofValue(target reflect.Value, value any) error {
+	if value == nil {
+		target.SetZero()
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch target.Kind() {
+	case reflect.Interface:
+		if v.Type().AssignableTo(target.Type()) {
+			target.Set(v)
+			return nil
+		}
+
+	case reflect.Pointer:
+		element := reflect.New(target.Type().Elem())
+		if err := assignPofValue(element.Elem(), value); err != nil {
+			return err
+		}
+		target.Set(element)
+		return nil
+
+	case reflect.Bool:
+		if b, ok := value.(bool); ok {
+			target.SetBool(b)
+			return nil
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := pofInt(v); ok && !target.OverflowInt(n) {
+			target.SetInt(n)
+			return nil
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n, ok := pofInt(v); ok && n >= 0 && !target.OverflowUint(uint64(n)) {
+			target.SetUint(uint64(n))
+			return nil
+		}
+
+	case reflect.Float32, reflect.Float64:
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			target.SetFloat(v.Float())
+			return nil
+		default:
+			if n, ok := pofInt(v); ok {
+				target.SetFloat(float64(n))
+				return nil
+			}
+		}
+
+	case reflect.String:
+		switch s := value.(type) {
+		case string:
+			target.SetString(s)
+			return nil
+		case rune:
+			target.SetString(string(s))
+			return nil
+		}
+
+	case reflect.Slice:
+		if b, ok := value.([]byte); ok && target.Type().Elem().Kind() == reflect.Uint8 {
+			target.SetBytes(b)
+			return nil
+		}
+		if elements, ok := value.([]any); ok {
+			slice := reflect.MakeSlice(target.Type(), len(elements), len(elements))
+			for i, e := range elements {
+				if err := assignPofValue(slice.Index(i), e); err != nil {
+					return err
+				}
+			}
+			target.Set(slice)
+			return nil
+		}
+
+	case reflect.Array:
+		if elements, ok := value.([]any); ok && len(elements) <= target.Len() {
+			for i, e := range elements {
+				if err := assignPofValue(target.Index(i), e); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+	case reflect.Map:
+		if entries, ok := value.(map[any]any); ok {
+			result := reflect.MakeMapWithSize(target.Type(), len(entries))
+			for k, e := range entries {
+				key := reflect.New(target.Type().Key()).Elem()
+				if err := assignPofValue(key, k); err != nil {
+					return err
+				}
+				element := reflect.New(target.Type().Elem()).Elem()
+				if err := assignPofValue(element, e); err != nil {
+					return err
+				}
+				result.SetMapIndex(key, element)
+			}
+			target.Set(result)
+			return nil
+		}
+
+	case reflect.Struct:
+		if v.Type() == target.Type() {
+			target.Set(v)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: cannot deserialize %T into %v", ErrInvalidPofData, value, target.Type())
+}
+
+// pofInt returns the value of an integer returned by the decoder.
+func pofInt(v reflect.Value) (int64, bool) {
+	switch v.Kind() {
+	case reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint8:
+		return int64(v.Uint()), true
+	default:
+		return 0, false
+	}
+}
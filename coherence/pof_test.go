@@ -0,0 +1,247 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/aggregators"
+	"github.com/oracle/coherence-go-client/v2/coherence/extractors"
+	"github.com/oracle/coherence-go-client/v2/coherence/filters"
+	"github.com/oracle/coherence-go-client/v2/coherence/processors"
+	"github.com/oracle/coherence-go-client/v2/coherence/testing/fakeproxy"
+)
+
+type pofAddress struct {
+	City     string `pof:"0"`
+	Postcode string `pof:"1"`
+}
+
+type pofCustomer struct {
+	ID       int32             `pof:"0"`
+	Name     string            `pof:"1"`
+	Balance  float64           `pof:"2"`
+	Address  *pofAddress       `pof:"3"`
+	Tags     []string          `pof:"4"`
+	Scores   map[string]int64  `pof:"5"`
+	Created  time.Time         `pof:"6"`
+	Ignored  string            `pof:"-"`
+	Previous []pofAddress      `pof:"7"`
+	Extra    map[string]string `pof:"8"`
+}
+
+func registerPofTestTypes(t *testing.T) {
+	if err := RegisterPofType[pofAddress](1001); err != nil {
+		t.Fatalf("unable to register type: %v", err)
+	}
+	if err := RegisterPofType[pofCustomer](1002); err != nil {
+		t.Fatalf("unable to register type: %v", err)
+	}
+}
+
+func testPofSerialization[V any](t *testing.T, v V) {
+	serializer := NewSerializer[V]("pof")
+	if serializer.Format() != "pof" {
+		t.Fatalf("expected a pof serializer, got %s", serializer.Format())
+	}
+
+	value, err := serializer.Serialize(v)
+	if err != nil {
+		t.Fatalf("Serialize failed for value %#v: %v", v, err)
+	}
+
+	finalValue, err := serializer.Deserialize(value)
+	if err != nil {
+		t.Fatalf("Deserialize failed for value %#v: %v", v, err)
+	}
+
+	if !reflect.DeepEqual(*finalValue, v) {
+		t.Fatalf("expected deserialized value %#v to equal original %#v", *finalValue, v)
+	}
+}
+
+func TestPofSerializer(t *testing.T) {
+	registerPofTestTypes(t)
+
+	testPofSerialization(t, "hello")
+	testPofSerialization(t, "")
+	testPofSerialization(t, 123)
+	testPofSerialization(t, int32(-1))
+	testPofSerialization(t, int32(1_000_000))
+	testPofSerialization(t, int16(-300))
+	testPofSerialization(t, int64(-1<<40))
+	testPofSerialization(t, uint64(1<<40))
+	testPofSerialization(t, 123.123)
+	testPofSerialization(t, float32(1.5))
+	testPofSerialization(t, false)
+	testPofSerialization(t, true)
+	testPofSerialization(t, byte(1))
+	testPofSerialization(t, []byte{1, 2, 3, 4})
+	testPofSerialization(t, []string{"hello", "hello2", "hello3"})
+	testPofSerialization(t, []int{12, 12, 12, 4, 4, 4, 3, 5})
+	testPofSerialization(t, map[int]string{1: "one", 2: "two"})
+	testPofSerialization(t, time.Date(2025, 3, 4, 5, 6, 7, 8, time.UTC))
+	testPofSerialization(t, pofCustomer{
+		ID:       1,
+		Name:     "Tim",
+		Balance:  1000.5,
+		Address:  &pofAddress{City: "Perth", Postcode: "6000"},
+		Tags:     []string{"gold"},
+		Scores:   map[string]int64{"a": 1, "b": 2},
+		Created:  time.Date(2025, 3, 4, 5, 6, 7, 8_000_000, time.UTC),
+		Previous: []pofAddress{{City: "Sydney"}},
+	})
+
+	// registered types are returned as their struct when deserializing into an interface
+	data, err := NewSerializer[any]("pof").Serialize(pofAddress{City: "Perth"})
+	if err != nil {
+		t.Fatalf("unable to serialize: %v", err)
+	}
+	value, err := NewSerializer[any]("pof").Deserialize(data)
+	if err != nil || !reflect.DeepEqual(*value, pofAddress{City: "Perth"}) {
+		t.Fatalf("expected the address, got %v, %v", value, err)
+	}
+
+	// fields not tagged are not serialized
+	customer, err := roundTripPof(pofCustomer{ID: 2, Ignored: "ignored"})
+	if err != nil || customer.Ignored != "" || customer.ID != 2 {
+		t.Fatalf("expected the untagged field to be ignored, got %v, %v", customer, err)
+	}
+}
+
+func roundTripPof[V any](v V) (*V, error) {
+	serializer := NewSerializer[V]("pof")
+	data, err := serializer.Serialize(v)
+	if err != nil {
+		return nil, err
+	}
+	return serializer.Deserialize(data)
+}
+
+func TestPofEncoding(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected []byte
+	}{
+		{nil, []byte{21, 0x64}},
+		{true, []byte{21, 0x61}},
+		{int32(1), []byte{21, 0x6A}},
+		{int64(1000), []byte{21, 0x42, 0xA8, 0x0F}},
+		{int64(-1000), []byte{21, 0x42, 0xE7, 0x0F}},
+		{"ab", []byte{21, 0x4E, 0x02, 'a', 'b'}},
+		{[]byte{7}, []byte{21, 0x4C, 0x01, 0x07}},
+		{[]int32{0}, []byte{21, 0x55, 0x01, 0x69}},
+	}
+
+	serializer := NewSerializer[any]("pof")
+	for _, tt := range tests {
+		data, err := serializer.Serialize(tt.value)
+		if err != nil {
+			t.Fatalf("unable to serialize %v: %v", tt.value, err)
+		}
+		if !bytes.Equal(data, tt.expected) {
+			t.Fatalf("expected %v to be serialized as %x, got %x", tt.value, tt.expected, data)
+		}
+	}
+}
+
+func TestPofErrors(t *testing.T) {
+	type unregistered struct {
+		Name string `pof:"0"`
+	}
+	type duplicate struct {
+		A string `pof:"0"`
+		B string `pof:"0"`
+	}
+
+	if _, err := NewSerializer[unregistered]("pof").Serialize(unregistered{}); !errors.Is(err, ErrPofTypeNotRegistered) {
+		t.Fatalf("expected ErrPofTypeNotRegistered, got %v", err)
+	}
+	if err := RegisterPofType[duplicate](1100); !errors.Is(err, ErrInvalidPofType) {
+		t.Fatalf("expected ErrInvalidPofType, got %v", err)
+	}
+	if err := RegisterPofType[string](1101); !errors.Is(err, ErrInvalidPofType) {
+		t.Fatalf("expected ErrInvalidPofType, got %v", err)
+	}
+
+	registerPofTestTypes(t)
+	if err := RegisterPofType[unregistered](1001); !errors.Is(err, ErrInvalidPofType) {
+		t.Fatalf("expected ErrInvalidPofType for a type id already registered, got %v", err)
+	}
+
+	// truncated data
+	data, err := NewSerializer[string]("pof").Serialize("hello")
+	if err != nil {
+		t.Fatalf("unable to serialize: %v", err)
+	}
+	if _, err = NewSerializer[string]("pof").Deserialize(data[:len(data)-1]); !errors.Is(err, ErrInvalidPofData) {
+		t.Fatalf("expected ErrInvalidPofData, got %v", err)
+	}
+
+	// incompatible types
+	if _, err = NewSerializer[int](pofFormat).Deserialize(data); !errors.Is(err, ErrInvalidPofData) {
+		t.Fatalf("expected ErrInvalidPofData, got %v", err)
+	}
+}
+
+func TestPofSession(t *testing.T) {
+	ctx := context.Background()
+	registerPofTestTypes(t)
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	session, err := NewSession(ctx, WithAddress(address), WithPlainText(), WithFormat("pof"),
+		WithRequestTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	namedMap, err := GetNamedMap[int32, pofCustomer](session, "pof-customers")
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+
+	customer := pofCustomer{ID: 1, Name: "Tim", Address: &pofAddress{City: "Perth"}}
+	if _, err = namedMap.Put(ctx, customer.ID, customer); err != nil {
+		t.Fatalf("unable to put: %v", err)
+	}
+
+	value, err := namedMap.Get(ctx, 1)
+	if err != nil || value == nil || !reflect.DeepEqual(*value, customer) {
+		t.Fatalf("expected %v, got %v, %v", customer, value, err)
+	}
+
+	// filters, extractors, entry processors and aggregators are rejected rather than sent to the cluster
+	for e := range namedMap.EntrySetFilter(ctx, filters.Equal(extractors.Extract[string]("name"), "Tim")) {
+		if !errors.Is(e.Err, ErrPofAgentNotSupported) {
+			t.Fatalf("expected ErrPofAgentNotSupported for EntrySetFilter, got %v", e.Err)
+		}
+	}
+	if _, err = Invoke[int32, pofCustomer, int32](ctx, namedMap, 1, processors.Increment("id", 1)); !errors.Is(err, ErrPofAgentNotSupported) {
+		t.Fatalf("expected ErrPofAgentNotSupported for Invoke, got %v", err)
+	}
+	if _, err = Aggregate(ctx, namedMap, aggregators.Count()); !errors.Is(err, ErrPofAgentNotSupported) {
+		t.Fatalf("expected ErrPofAgentNotSupported for Aggregate, got %v", err)
+	}
+	if err = AddIndex(ctx, namedMap, extractors.Extract[string]("name"), false); !errors.Is(err, ErrPofAgentNotSupported) {
+		t.Fatalf("expected ErrPofAgentNotSupported for AddIndex, got %v", err)
+	}
+	if err = namedMap.AddFilterListener(ctx, NewMapListener[int32, pofCustomer](), filters.Always()); !errors.Is(err, ErrPofAgentNotSupported) {
+		t.Fatalf("expected ErrPofAgentNotSupported for AddFilterListener, got %v", err)
+	}
+}
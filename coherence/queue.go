@@ -186,7 +186,7 @@ func ensureV1StreamManagerQueue(session *Session) error {
 }
 
 func newBaseQueueClient[V any](ctx context.Context, session *Session, queueName string, queueType NamedQueueType, queueID int32) (*baseQueueClient[V], error) {
-	if session.closed {
+	if session.closed.Load() {
 		return nil, ErrClosed
 	}
 
@@ -294,10 +294,16 @@ func (nq *namedQueue[V]) OfferTail(ctx context.Context, value V) error {
 	return offerInternal[V](ctx, nq.baseQueueClient, value, pb1.NamedQueueRequestType_OfferTail)
 }
 
-func offerInternal[V any](ctx context.Context, bq *baseQueueClient[V], value V, offerType pb1.NamedQueueRequestType) error {
+func offerInternal[V any](ctx context.Context, bq *baseQueueClient[V], value V, offerType pb1.NamedQueueRequestType) (err error) {
+	ctx, op := bq.startOperation(ctx, offerType.String())
+	defer func() { op.end(err) }()
+
 	if bq.isDestroyed || bq.isReleased {
 		return ErrQueueDestroyedOrReleased
 	}
+	if bq.session.shuttingDown.Load() {
+		return ErrShuttingDown
+	}
 
 	binValue, err := bq.valueSerializer.Serialize(value)
 	if err != nil {
@@ -311,7 +317,7 @@ func offerInternal[V any](ctx context.Context, bq *baseQueueClient[V], value V,
 		return err
 	}
 
-	requestType, err := streamManager.submitQueueRequest(req, offerType)
+	requestType, err := streamManager.submitQueueRequest(ctx, req, offerType)
 	if err != nil {
 		return err
 	}
@@ -323,7 +329,7 @@ func offerInternal[V any](ctx context.Context, bq *baseQueueClient[V], value V,
 
 	defer streamManager.cleanupRequest(req.Id)
 
-	result, err1 := waitForResponse(newCtx, requestType.ch)
+	result, err1 := waitForResponse(newCtx, requestType)
 	if err1 != nil {
 		return err1
 	}
@@ -367,10 +373,16 @@ func (nq *namedQueue[V]) RemoveLifecycleListener(listener QueueLifecycleListener
 	return nil
 }
 
-func peekOrPollHead[V any](ctx context.Context, bq *baseQueueClient[V], reqType pb1.NamedQueueRequestType) (*V, error) {
+func peekOrPollHead[V any](ctx context.Context, bq *baseQueueClient[V], reqType pb1.NamedQueueRequestType) (_ *V, err error) {
+	ctx, op := bq.startOperation(ctx, reqType.String())
+	defer func() { op.end(err) }()
+
 	if bq.isDestroyed || bq.isReleased {
 		return nil, ErrQueueDestroyedOrReleased
 	}
+	if bq.session.shuttingDown.Load() {
+		return nil, ErrShuttingDown
+	}
 
 	streamManager := bq.session.v1StreamManagerQueue
 
@@ -379,7 +391,7 @@ func peekOrPollHead[V any](ctx context.Context, bq *baseQueueClient[V], reqType
 		return nil, err
 	}
 
-	requestType, err := streamManager.submitQueueRequest(req, reqType)
+	requestType, err := streamManager.submitQueueRequest(ctx, req, reqType)
 	if err != nil {
 		return nil, err
 	}
@@ -391,7 +403,7 @@ func peekOrPollHead[V any](ctx context.Context, bq *baseQueueClient[V], reqType
 
 	defer streamManager.cleanupRequest(req.Id)
 
-	result, err := waitForResponse(newCtx, requestType.ch)
+	result, err := waitForResponse(newCtx, requestType)
 	if err != nil {
 		return nil, err
 	}
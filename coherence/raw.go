@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+// rawSerializerFormat is the format of the serializers used by raw maps and caches, which is not a known format
+// so filters, entry processors and aggregators are serialized using the format of the session.
+const rawSerializerFormat = "raw"
+
+// rawKeySerializer passes through already serialized keys held in a string.
+type rawKeySerializer struct{}
+
+// Serialize returns the bytes of the key.
+func (rawKeySerializer) Serialize(object string) ([]byte, error) {
+	return []byte(object), nil
+}
+
+// Deserialize returns the bytes as a key.
+func (rawKeySerializer) Deserialize(data []byte) (*string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	result := string(data)
+	return &result, nil
+}
+
+// Format returns the format used for the serializer.
+func (rawKeySerializer) Format() string {
+	return rawSerializerFormat
+}
+
+// rawValueSerializer passes through already serialized values.
+type rawValueSerializer struct{}
+
+// Serialize returns the value.
+func (rawValueSerializer) Serialize(object []byte) ([]byte, error) {
+	return object, nil
+}
+
+// Deserialize returns the bytes as a value.
+func (rawValueSerializer) Deserialize(data []byte) (*[]byte, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return &data, nil
+}
+
+// Format returns the format used for the serializer.
+func (rawValueSerializer) Format() string {
+	return rawSerializerFormat
+}
+
+// GetRawNamedMap returns a [NamedMap] whose keys and values are passed to and from the cluster as is, without
+// being serialized or deserialized, for tools such as replication and backup which move entries between clusters.
+// As a []byte cannot be a map key, each key is the serialized key held in a string, which can be converted
+// using []byte(key). The keys and values must be serialized using the format of the session, and filters,
+// entry processors and aggregators are still serialized using it.
+//
+//	source, err := coherence.GetRawNamedMap(sourceSession, "orders")
+//	target, err := coherence.GetRawNamedMap(targetSession, "orders")
+//
+//	for e := range source.EntrySet(ctx) {
+//	    if e.Err != nil {
+//	        log.Fatal(e.Err)
+//	    }
+//	    if _, err = target.Put(ctx, e.Key, e.Value); err != nil {
+//	        log.Fatal(err)
+//	    }
+//	}
+//
+// As raw maps share the name of the cache with other maps from the same session, an error is returned
+// if the session already has a [NamedMap] with the same name and different type parameters.
+func GetRawNamedMap(session *Session, cacheName string, options ...func(session *CacheOptions)) (NamedMap[string, []byte], error) {
+	return getNamedMap[string, []byte](session, cacheName, session.sessOpts, rawCacheOptions(options)...)
+}
+
+// GetRawNamedCache returns a [NamedCache] whose keys and values are passed to and from the cluster as is,
+// as described for [GetRawNamedMap], so entries can be copied with their expiry using PutWithExpiry.
+func GetRawNamedCache(session *Session, cacheName string, options ...func(session *CacheOptions)) (NamedCache[string, []byte], error) {
+	return getNamedCache[string, []byte](session, cacheName, session.sessOpts, rawCacheOptions(options)...)
+}
+
+// rawCacheOptions returns the options with the raw serializers applied last so that they are always used.
+func rawCacheOptions(options []func(session *CacheOptions)) []func(session *CacheOptions) {
+	return append(options[:len(options):len(options)],
+		WithKeySerializer[string](rawKeySerializer{}),
+		WithValueSerializer[[]byte](rawValueSerializer{}))
+}
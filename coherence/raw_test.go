@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/testing/fakeproxy"
+)
+
+func TestRawNamedMap(t *testing.T) {
+	ctx := context.Background()
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	newSession := func() *Session {
+		session, err := NewSession(ctx, WithAddress(address), WithPlainText(), WithRequestTimeout(5*time.Second))
+		if err != nil {
+			t.Fatalf("unable to create session: %v", err)
+		}
+		t.Cleanup(session.Close)
+		return session
+	}
+	typedSession, rawSession := newSession(), newSession()
+
+	orders, err := GetNamedMap[int, string](typedSession, "orders")
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+	for i, v := range []string{"zero", "one", "two"} {
+		if _, err = orders.Put(ctx, i, v); err != nil {
+			t.Fatalf("unable to put: %v", err)
+		}
+	}
+
+	// a raw map cannot be created for an existing map with other types
+	if _, err = GetRawNamedMap(typedSession, "orders"); err == nil {
+		t.Fatalf("expected an error for a map with different types")
+	}
+
+	rawOrders, err := GetRawNamedMap(rawSession, "orders")
+	if err != nil {
+		t.Fatalf("unable to get raw map: %v", err)
+	}
+	rawBackup, err := GetRawNamedCache(rawSession, "orders-backup")
+	if err != nil {
+		t.Fatalf("unable to get raw cache: %v", err)
+	}
+
+	// entries are returned as their serialized bytes
+	binKey, _ := NewSerializer[int]("json").Serialize(1)
+	binValue, _ := NewSerializer[string]("json").Serialize("one")
+	value, err := rawOrders.Get(ctx, string(binKey))
+	if err != nil || value == nil || !bytes.Equal(*value, binValue) {
+		t.Fatalf("expected %s, got %v, %v", binValue, value, err)
+	}
+
+	// copy the entries without deserializing them and read them back typed
+	for e := range rawOrders.EntrySet(ctx) {
+		if e.Err != nil {
+			t.Fatalf("unable to get entries: %v", e.Err)
+		}
+		if _, err = rawBackup.PutWithExpiry(ctx, e.Key, e.Value, time.Hour); err != nil {
+			t.Fatalf("unable to put: %v", err)
+		}
+	}
+
+	backup, err := GetNamedMap[int, string](typedSession, "orders-backup")
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+	if size, err := backup.Size(ctx); err != nil || size != 3 {
+		t.Fatalf("expected 3 entries, got %d, %v", size, err)
+	}
+	if value, err := backup.Get(ctx, 2); err != nil || value == nil || *value != "two" {
+		t.Fatalf("expected two, got %v, %v", value, err)
+	}
+}
@@ -0,0 +1,270 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
+)
+
+const (
+	defaultReconnectInitialBackoff = time.Duration(1) * time.Second
+	defaultReconnectMaxBackoff     = time.Duration(3) * time.Second
+	defaultReconnectMultiplier     = 1.1
+)
+
+var (
+	// ErrInvalidReconnectPolicy indicates that the values specified for a [ReconnectPolicy] are not valid.
+	ErrInvalidReconnectPolicy = errors.New("reconnect policy values must not be negative, multiplier must be at least 1 and jitter between 0 and 1")
+
+	// ErrReconnectFailed indicates that a [Session] was unable to reconnect within the limits of its [ReconnectPolicy].
+	ErrReconnectFailed = errors.New("unable to reconnect within the limits of the reconnect policy")
+)
+
+// ReconnectPolicy controls how a [Session] attempts to reconnect after it has been disconnected.
+// The delay between attempts starts at InitialBackoff and is multiplied by Multiplier after each
+// failed attempt, up to MaxBackoff. Each delay is randomized by plus or minus the Jitter fraction.
+// Attempts stop, and the [Session] is closed, once either MaxAttempts or MaxElapsed is reached.
+type ReconnectPolicy struct {
+	// InitialBackoff is the delay after the first failed attempt, defaults to 1 second.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between attempts, defaults to 3 seconds.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor the delay is multiplied by after each failed attempt, defaults to 1.1.
+	Multiplier float64
+
+	// Jitter is the fraction, between 0 and 1, by which each delay is randomized, defaults to 0.
+	Jitter float64
+
+	// MaxAttempts is the maximum number of attempts, zero means there is no limit on the number of attempts.
+	MaxAttempts int
+
+	// MaxElapsed is the maximum total time to attempt to reconnect, zero means the session disconnect timeout is used.
+	MaxElapsed time.Duration
+}
+
+// DefaultReconnectPolicy returns the [ReconnectPolicy] used when none is specified.
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		InitialBackoff: defaultReconnectInitialBackoff,
+		MaxBackoff:     defaultReconnectMaxBackoff,
+		Multiplier:     defaultReconnectMultiplier,
+	}
+}
+
+// WithReconnectPolicy returns a function to set the [ReconnectPolicy] for a [Session].
+// Any zero backoff or multiplier values are replaced with the defaults from [DefaultReconnectPolicy].
+func WithReconnectPolicy(policy ReconnectPolicy) func(sessionOptions *SessionOptions) {
+	return func(s *SessionOptions) {
+		s.ReconnectPolicy = &policy
+	}
+}
+
+// validate validates the policy and applies defaults for any zero values.
+func (p *ReconnectPolicy) validate() error {
+	if p.InitialBackoff < 0 || p.MaxBackoff < 0 || p.MaxAttempts < 0 || p.MaxElapsed < 0 ||
+		p.Jitter < 0 || p.Jitter > 1 || (p.Multiplier != 0 && p.Multiplier < 1) {
+		return ErrInvalidReconnectPolicy
+	}
+
+	if p.InitialBackoff == 0 {
+		p.InitialBackoff = defaultReconnectInitialBackoff
+	}
+	if p.MaxBackoff == 0 {
+		p.MaxBackoff = max(defaultReconnectMaxBackoff, p.InitialBackoff)
+	}
+	if p.Multiplier == 0 {
+		p.Multiplier = defaultReconnectMultiplier
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		return ErrInvalidReconnectPolicy
+	}
+
+	return nil
+}
+
+// backoff returns the delay to wait after the given failed attempt, starting at 1.
+func (p *ReconnectPolicy) backoff(attempt int) time.Duration {
+	return exponentialBackoff(p.InitialBackoff, p.MaxBackoff, p.Multiplier, p.Jitter, attempt)
+}
+
+// exponentialBackoff returns the delay to wait after the given failed attempt, starting at 1, which starts at
+// initial and is multiplied by multiplier after each attempt, up to maxBackoff, randomized by the jitter fraction.
+func exponentialBackoff(initial, maxBackoff time.Duration, multiplier, jitter float64, attempt int) time.Duration {
+	delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if delay > float64(maxBackoff) {
+		delay = float64(maxBackoff)
+	}
+
+	if jitter > 0 {
+		//nolint:gosec // no need for secure random here
+		delay *= 1 + jitter*(rand.Float64()*2-1)
+	}
+
+	return time.Duration(delay)
+}
+
+// exhausted returns true if the given attempt, starting at 1, may not be made after the elapsed time.
+func (p *ReconnectPolicy) exhausted(attempt int, elapsed time.Duration, disconnectTimeout time.Duration) bool {
+	if p.MaxAttempts > 0 && attempt > p.MaxAttempts {
+		return true
+	}
+
+	maxElapsed := p.MaxElapsed
+	if maxElapsed == 0 {
+		maxElapsed = disconnectTimeout
+	}
+
+	return attempt > 1 && elapsed >= maxElapsed
+}
+
+// String returns a string representation of a ReconnectPolicy.
+func (p *ReconnectPolicy) String() string {
+	return fmt.Sprintf("ReconnectPolicy{initialBackoff=%v, maxBackoff=%v, multiplier=%v, jitter=%v, maxAttempts=%v, maxElapsed=%v}",
+		p.InitialBackoff, p.MaxBackoff, p.Multiplier, p.Jitter, p.MaxAttempts, p.MaxElapsed)
+}
+
+// ReconnectAttemptEvent is the [SessionLifecycleEvent] raised with type [ReconnectAttempt] before each
+// attempt to reconnect a disconnected [Session].
+type ReconnectAttemptEvent interface {
+	SessionLifecycleEvent
+
+	// Attempt returns the attempt number, starting at 1.
+	Attempt() int
+
+	// LastError returns the error from the previous attempt, or nil if this is the first attempt.
+	LastError() error
+}
+
+type reconnectAttemptEvent struct {
+	sessionLifecycleEvent
+	attempt   int
+	lastError error
+}
+
+func newReconnectAttemptEvent(session *Session, attempt int, lastError error) ReconnectAttemptEvent {
+	return &reconnectAttemptEvent{
+		sessionLifecycleEvent: sessionLifecycleEvent{source: session, eventType: ReconnectAttempt},
+		attempt:               attempt,
+		lastError:             lastError,
+	}
+}
+
+func (e *reconnectAttemptEvent) Attempt() int {
+	return e.attempt
+}
+
+func (e *reconnectAttemptEvent) LastError() error {
+	return e.lastError
+}
+
+func (e *reconnectAttemptEvent) String() string {
+	return fmt.Sprintf("ReconnectAttemptEvent{source=%v, attempt=%d, lastError=%v}", e.Source(), e.attempt, e.lastError)
+}
+
+// reconnect calls the attempt function, governed by the session [ReconnectPolicy], until it succeeds,
+// raising a [ReconnectAttempt] event before each attempt. An error is returned if the session is
+// closed or the policy has been exhausted.
+func (s *Session) reconnect(description string, attempt func() error) error {
+	var (
+		policy  = s.sessOpts.ReconnectPolicy
+		start   = time.Now()
+		lastErr error
+	)
+
+	for n := 1; ; n++ {
+		if s.IsClosed() {
+			return ErrClosed
+		}
+
+		if policy.exhausted(n, time.Since(start), s.GetDisconnectTimeout()) {
+			return fmt.Errorf("%w: %s after %d attempts and %v, last error: %v", ErrReconnectFailed,
+				description, n-1, time.Since(start).Truncate(time.Millisecond), lastErr)
+		}
+
+		s.dispatch(ReconnectAttempt, func() SessionLifecycleEvent {
+			return newReconnectAttemptEvent(s, n, lastErr)
+		})
+
+		if lastErr = attempt(); lastErr == nil {
+			return nil
+		}
+
+		s.debug("%s attempt %d failed: %v", description, n, lastErr)
+		time.Sleep(policy.backoff(n))
+	}
+}
+
+// reconnectConnection attempts to reconnect the underlying gRPC connection after it has been lost and,
+// if a gRPC v1 proxy is being used, re-establishes the streams. The session is closed if this is not
+// possible within the limits of the [ReconnectPolicy].
+func (s *Session) reconnectConnection() {
+	if !s.reconnecting.CompareAndSwap(false, true) {
+		// already reconnecting
+		return
+	}
+	defer s.reconnecting.Store(false)
+
+	err := s.reconnect("reconnect to "+s.sessOpts.Address, func() error {
+		if err := s.waitForConnection(s.sessOpts.RequestTimeout); err != nil {
+			return err
+		}
+		if s.GetProtocolVersion() > 0 {
+			if err := s.reconnectStream(cacheServiceProtocol); err != nil {
+				return err
+			}
+			return s.reconnectStream(queueServiceProtocol)
+		}
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, ErrClosed) {
+		s.log(ERROR, "Session [%s] %v, closing session.", s.sessionID, err)
+		s.Close()
+	}
+}
+
+// waitForConnection requests the gRPC connection to connect, without waiting for any gRPC backoff,
+// and waits up to the timeout for the connection to either become ready or fail.
+func (s *Session) waitForConnection(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	state := s.conn.GetState()
+	switch state {
+	case connectivity.Ready:
+		return nil
+	case connectivity.Idle:
+		s.conn.Connect()
+	case connectivity.TransientFailure:
+		s.conn.ResetConnectBackoff()
+	}
+
+	for s.conn.WaitForStateChange(ctx, state) {
+		state = s.conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Shutdown:
+			return ErrClosed
+		case connectivity.TransientFailure:
+			return fmt.Errorf("connection to %s failed", s.sessOpts.Address)
+		case connectivity.Idle:
+			s.conn.Connect()
+		}
+	}
+
+	return fmt.Errorf("connection to %s is %v after %v", s.sessOpts.Address, s.conn.GetState(), timeout)
+}
@@ -33,17 +33,25 @@ var (
 )
 
 type nsLookupResolverBuilder struct {
+	session *Session // the session to log to, if nil the resolver debug setting is used
 }
 
 func (b *nsLookupResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	checkResolverDebug()
+
 	r := &nsLookupResolver{
 		target: target,
 		cc:     cc,
-		addrStore: map[string][]string{
-			nsLookupScheme: generateNSAddresses(target.Endpoint()),
-		},
+		debug:  resolverDebug,
+	}
+	if b.session != nil && (b.session.logger != nil || resolverDebugEnabled()) {
+		r.debug = func(format string, v ...any) {
+			b.session.log(DEBUG, format, v...)
+		}
+	}
+	r.addrStore = map[string][]string{
+		nsLookupScheme: generateNSAddresses(target.Endpoint(), r.debug),
 	}
-	checkResolverDebug()
 
 	// set the number of resolver retried
 	retries := getStringValueFromEnvVarOrDefault(envResolverRetries, "20")
@@ -52,7 +60,7 @@ func (b *nsLookupResolverBuilder) Build(target resolver.Target, cc resolver.Clie
 		retriesValue = defaultRetries
 	}
 
-	resolverDebug("resolver retries=%v", retriesValue)
+	r.debug("resolver retries=%v", retriesValue)
 	r.resolverRetries = retriesValue
 
 	r.start()
@@ -66,19 +74,20 @@ type nsLookupResolver struct {
 	mutex           sync.Mutex
 	addrStore       map[string][]string
 	resolverRetries int
+	debug           func(string, ...any)
 }
 
 func (r *nsLookupResolver) resolve() {
 	r.mutex.Lock()
-	grpcEndpoints := generateNSAddresses(r.target.Endpoint())
+	grpcEndpoints := generateNSAddresses(r.target.Endpoint(), r.debug)
 	defer r.mutex.Unlock()
 
 	if len(grpcEndpoints) == 0 {
 		// try r.resolverRetries; times over 2 seconds to get gRPC addresses as we may be in the middle of fail-over
 		for i := 1; i <= r.resolverRetries; i++ {
-			resolverDebug("retrying NSLookup attempt: %v", i)
+			r.debug("retrying NSLookup attempt: %v", i)
 			time.Sleep(time.Duration(defaultResolverDelay) * time.Millisecond)
-			grpcEndpoints = generateNSAddresses(r.target.Endpoint())
+			grpcEndpoints = generateNSAddresses(r.target.Endpoint(), r.debug)
 			if len(grpcEndpoints) != 0 {
 				break
 			}
@@ -86,7 +95,7 @@ func (r *nsLookupResolver) resolve() {
 
 		if len(grpcEndpoints) == 0 {
 			msg := "resolver produced zero addresses"
-			resolverDebug(msg)
+			r.debug(msg)
 			r.cc.ReportError(errors.New(msg))
 			return
 		}
@@ -105,10 +114,10 @@ func (r *nsLookupResolver) resolve() {
 		})
 	}
 
-	resolverDebug("resolver produced the following addresses: %v, randomize=%v", addresses, randomizeAddresses)
+	r.debug("resolver produced the following addresses: %v, randomize=%v", addresses, randomizeAddresses)
 	_ = r.cc.UpdateState(resolver.State{Addresses: addresses})
 	if len(addresses) > 0 {
-		resolverDebug("resolver chose address: %s", addresses[0])
+		r.debug("resolver chose address: %s", addresses[0])
 	}
 }
 
@@ -122,10 +131,10 @@ func (r *nsLookupResolver) ResolveNow(_ resolver.ResolveNowOptions) {
 func (*nsLookupResolver) Close() {
 }
 
-func generateNSAddresses(endpoint string) []string {
-	addresses, err := NsLookupGrpcAddresses(endpoint)
+func generateNSAddresses(endpoint string, debug func(string, ...any)) []string {
+	addresses, err := nsLookupGrpcAddresses(endpoint, debug)
 	if err != nil {
-		resolverDebug("NSlookup returned error: %v", err)
+		debug("NSlookup returned error: %v", err)
 		return emptyAddresses
 	}
 	return addresses
@@ -134,6 +143,11 @@ func generateNSAddresses(endpoint string) []string {
 // NsLookupGrpcAddresses looks up grpc proxy server addresses based upon the provided
 // name service address provided as host:port, e.g. localhost:7574[/cluster].
 func NsLookupGrpcAddresses(address string) ([]string, error) {
+	return nsLookupGrpcAddresses(address, resolverDebug)
+}
+
+// nsLookupGrpcAddresses looks up the grpc proxy server addresses, logging using the debug function.
+func nsLookupGrpcAddresses(address string, debug func(string, ...any)) ([]string, error) {
 	var (
 		addrString     string
 		_              []string
@@ -154,7 +168,7 @@ func NsLookupGrpcAddresses(address string) ([]string, error) {
 		defer nsF.Close()
 
 		query := discovery.NSPrefix + discovery.ClusterForeignLookup + "/" + foreignCluster + discovery.NSLocalPort
-		resolverDebug("lookup for foreign cluster NS port using %s", query)
+		debug("lookup for foreign cluster NS port using %s", query)
 		port, err := nsF.Lookup(query)
 		if err != nil {
 			return emptyAddresses, fmt.Errorf("unable to lookup foreign clsuter NS port: %v", err)
@@ -164,7 +178,7 @@ func NsLookupGrpcAddresses(address string) ([]string, error) {
 		s1 := strings.Split(address, ":")
 		address = fmt.Sprintf("%s:%v", s1[0], port)
 
-		resolverDebug("NS port for %s is %s", foreignCluster, address)
+		debug("NS port for %s is %s", foreignCluster, address)
 		// fall through and do the actual lookup using new address
 	}
 
@@ -218,14 +232,22 @@ func parseNsLookupString(addresses string) ([]string, error) {
 	return results, nil
 }
 
+// resolverDebugEnabled returns true if resolver debugging has been enabled using COHERENCE_RESOLVER_DEBUG
+// or the log level.
+func resolverDebugEnabled() bool {
+	return getBoolValueFromEnvVarOrDefault(envResolverDebug, false) || currentLogLevel.Load() >= int32(DEBUG)
+}
+
+// checkResolverDebug enables the resolver debug messages which are logged without a session,
+// if resolver debugging has been enabled.
 func checkResolverDebug() {
-	if getBoolValueFromEnvVarOrDefault(envResolverDebug, false) || currentLogLevel >= int(DEBUG) {
-		// enable session debugging
+	if resolverDebugEnabled() {
+		// enable resolver debugging
 		resolverDebug = func(s string, v ...any) {
 			logMessage(DEBUG, s, v...)
 		}
-		if currentLogLevel <= int(DEBUG) {
-			currentLogLevel = int(DEBUG)
+		if currentLogLevel.Load() <= int32(DEBUG) {
+			currentLogLevel.Store(int32(DEBUG))
 		}
 	}
 }
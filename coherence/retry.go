@@ -0,0 +1,261 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 2 * time.Second
+	defaultRetryMultiplier     = 2.0
+)
+
+var (
+	// ErrStreamDisconnected indicates that the gRPC v1 stream a request was sent on was disconnected
+	// before the response was received.
+	ErrStreamDisconnected = errors.New("the gRPC v1 stream was disconnected while waiting for a response")
+
+	// ErrInvalidRetryPolicy indicates that the values specified for a [RetryPolicy] are not valid.
+	ErrInvalidRetryPolicy = errors.New("retry policy values must not be negative, multiplier must be at least 1 and jitter between 0 and 1")
+)
+
+// RetryPolicy controls how idempotent read operations, such as Get, GetAll, ContainsKey, Size, KeySetFilter and
+// Aggregate, are retried when they fail with a transient error: the gRPC stream being reset or disconnected while
+// waiting for a response, or the proxy being unavailable. Operations which mutate a [NamedMap] or [NamedCache]
+// are never retried. The delay between attempts starts at InitialBackoff and is multiplied by Multiplier after each
+// failed attempt, up to MaxBackoff, and each delay is randomized by plus or minus the Jitter fraction.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first, defaults to 3.
+	MaxAttempts int
+
+	// InitialBackoff is the delay after the first failed attempt, defaults to 100 milliseconds.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between attempts, defaults to 2 seconds.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor the delay is multiplied by after each failed attempt, defaults to 2.
+	Multiplier float64
+
+	// Jitter is the fraction, between 0 and 1, by which each delay is randomized, defaults to 0.
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns the [RetryPolicy] with the default values.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    defaultRetryMaxAttempts,
+		InitialBackoff: defaultRetryInitialBackoff,
+		MaxBackoff:     defaultRetryMaxBackoff,
+		Multiplier:     defaultRetryMultiplier,
+	}
+}
+
+// WithRetryPolicy returns a function to set the [RetryPolicy] used to retry idempotent read operations for all
+// the [NamedMap] and [NamedCache] instances created by a [Session]. Any zero values are replaced with the defaults
+// from [DefaultRetryPolicy].
+func WithRetryPolicy(policy RetryPolicy) func(sessionOptions *SessionOptions) {
+	return func(s *SessionOptions) {
+		s.RetryPolicy = &policy
+	}
+}
+
+// WithCacheRetryPolicy returns a function to set the [RetryPolicy] for a [NamedMap] or [NamedCache],
+// overriding any policy set using [WithRetryPolicy].
+func WithCacheRetryPolicy(policy RetryPolicy) func(cacheOptions *CacheOptions) {
+	return func(c *CacheOptions) {
+		c.RetryPolicy = &policy
+	}
+}
+
+// validate validates the policy and applies defaults for any zero values.
+func (p *RetryPolicy) validate() error {
+	if p.MaxAttempts < 0 || p.InitialBackoff < 0 || p.MaxBackoff < 0 || p.Jitter < 0 || p.Jitter > 1 ||
+		(p.Multiplier != 0 && p.Multiplier < 1) {
+		return ErrInvalidRetryPolicy
+	}
+
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if p.InitialBackoff == 0 {
+		p.InitialBackoff = defaultRetryInitialBackoff
+	}
+	if p.MaxBackoff == 0 {
+		p.MaxBackoff = max(defaultRetryMaxBackoff, p.InitialBackoff)
+	}
+	if p.Multiplier == 0 {
+		p.Multiplier = defaultRetryMultiplier
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		return ErrInvalidRetryPolicy
+	}
+
+	return nil
+}
+
+// backoff returns the delay to wait after the given failed attempt, starting at 1.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	return exponentialBackoff(p.InitialBackoff, p.MaxBackoff, p.Multiplier, p.Jitter, attempt)
+}
+
+func (p RetryPolicy) String() string {
+	return fmt.Sprintf("RetryPolicy{maxAttempts=%d, initialBackoff=%v, maxBackoff=%v, multiplier=%v, jitter=%v}",
+		p.MaxAttempts, p.InitialBackoff, p.MaxBackoff, p.Multiplier, p.Jitter)
+}
+
+// RetryError is the error returned when an operation which was retried fails. The error from the final
+// attempt can be obtained using [errors.Unwrap], [errors.Is] or [errors.As].
+type RetryError struct {
+	// Attempts is the number of attempts made.
+	Attempts int
+
+	// Err is the error from the final attempt.
+	Err error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("failed after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// isRetryable returns true if the error is transient and an idempotent operation may be retried.
+func isRetryable(err error) bool {
+	return errors.Is(err, ErrStreamDisconnected) || errors.Is(err, io.EOF) || status.Code(err) == codes.Unavailable
+}
+
+// retryPolicy returns the retry policy for the map or cache, or nil if operations are not retried.
+func (bc *baseClient[K, V]) retryPolicy() *RetryPolicy {
+	if bc.cacheOpts != nil && bc.cacheOpts.RetryPolicy != nil {
+		return bc.cacheOpts.RetryPolicy
+	}
+	if bc.sessionOpts == nil {
+		return nil
+	}
+	return bc.sessionOpts.RetryPolicy
+}
+
+// waitToRetry waits before retrying after the given failed attempt, returning false if the context is done first.
+func (bc *baseClient[K, V]) waitToRetry(ctx context.Context, policy *RetryPolicy, attempt int, err error) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	delay := policy.backoff(attempt)
+	bc.session.logAttrs(DEBUG, cacheAttrs(bc.name), "attempt %d of %d failed, retrying in %v: %v", attempt, policy.MaxAttempts, delay, err)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// retryRead calls the idempotent read, retrying it according to the retry policy for the map or cache.
+func retryRead[K comparable, V any, T any](ctx context.Context, bc *baseClient[K, V], read func() (T, error)) (T, error) {
+	result, err := read()
+
+	policy := bc.retryPolicy()
+	if policy == nil {
+		return result, err
+	}
+
+	attempt := 1
+	for ; err != nil && isRetryable(err) && attempt < policy.MaxAttempts; attempt++ {
+		if !bc.waitToRetry(ctx, policy, attempt, err) {
+			break
+		}
+		result, err = read()
+	}
+
+	if err != nil && attempt > 1 {
+		err = &RetryError{Attempts: attempt, Err: err}
+	}
+	return result, err
+}
+
+// retryStream calls the idempotent streaming read, retrying it according to the retry policy for the map or
+// cache if the first result is a transient error. Once a result has been received the read is not retried.
+func retryStream[K comparable, V any, T retryableResult](ctx context.Context, bc *baseClient[K, V], read func() <-chan T) <-chan T {
+	policy := bc.retryPolicy()
+	if policy == nil {
+		return read()
+	}
+
+	out := make(chan T)
+	go func() {
+		defer close(out)
+
+		for attempt := 1; ; attempt++ {
+			ch := read()
+
+			first, ok := <-ch
+			if !ok {
+				return
+			}
+
+			err := first.streamErr()
+			if err != nil && isRetryable(err) && attempt < policy.MaxAttempts && bc.waitToRetry(ctx, policy, attempt, err) {
+				go drain(ch)
+				continue
+			}
+
+			if err != nil && attempt > 1 {
+				first.setStreamErr(&RetryError{Attempts: attempt, Err: err})
+			}
+
+			out <- first
+			for v := range ch {
+				out <- v
+			}
+			return
+		}
+	}()
+
+	return out
+}
+
+// drain discards any remaining results from an abandoned stream.
+func drain[T any](ch <-chan T) {
+	for range ch { //nolint:revive // discard the results
+	}
+}
+
+// retryableResult is implemented by the results sent on the channels returned by streaming reads.
+type retryableResult interface {
+	streamedResult
+	setStreamErr(err error)
+}
+
+func (e *StreamedEntry[K, V]) setStreamErr(err error) {
+	e.Err = err
+}
+
+func (k *StreamedKey[K]) setStreamErr(err error) {
+	k.Err = err
+}
+
+func (v *StreamedValue[V]) setStreamErr(err error) {
+	v.Err = err
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newRetryTestClient(t *testing.T) *baseClient[int, string] {
+	policy := RetryPolicy{InitialBackoff: time.Millisecond}
+	if err := policy.validate(); err != nil {
+		t.Fatalf("unable to validate retry policy: %v", err)
+	}
+
+	return &baseClient[int, string]{
+		session:     newTestSession(),
+		name:        "test",
+		sessionOpts: &SessionOptions{RetryPolicy: &policy},
+	}
+}
+
+func TestRetryPolicyDefaults(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Millisecond}
+	if err := policy.validate(); err != nil || policy.MaxAttempts != defaultRetryMaxAttempts ||
+		policy.InitialBackoff != time.Millisecond || policy.MaxBackoff != defaultRetryMaxBackoff ||
+		policy.Multiplier != defaultRetryMultiplier {
+		t.Fatalf("expected defaults to be applied, got %v, %v", policy, err)
+	}
+
+	policy = RetryPolicy{}
+	if err := policy.validate(); err != nil || policy.InitialBackoff != defaultRetryInitialBackoff {
+		t.Fatalf("expected the default initial backoff, got %v, %v", policy, err)
+	}
+
+	policy = RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Millisecond}
+	if err := policy.validate(); !errors.Is(err, ErrInvalidRetryPolicy) {
+		t.Fatalf("expected ErrInvalidRetryPolicy, got %v", err)
+	}
+}
+
+func TestRetryRead(t *testing.T) {
+	var (
+		bc    = newRetryTestClient(t)
+		calls int
+	)
+
+	value, err := retryRead(context.Background(), bc, func() (int, error) {
+		calls++
+		if calls < 3 {
+			return 0, status.Error(codes.Unavailable, "unavailable")
+		}
+		return 42, nil
+	})
+	if err != nil || value != 42 || calls != 3 {
+		t.Fatalf("expected success after 3 attempts, got %v, %v after %d calls", value, err, calls)
+	}
+
+	calls = 0
+	_, err = retryRead(context.Background(), bc, func() (int, error) {
+		calls++
+		return 0, ErrStreamDisconnected
+	})
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 3 || !errors.Is(err, ErrStreamDisconnected) || calls != 3 {
+		t.Fatalf("expected a RetryError after 3 attempts, got %v after %d calls", err, calls)
+	}
+
+	// errors which are not transient are not retried
+	calls = 0
+	failed := errors.New("failed")
+	if _, err = retryRead(context.Background(), bc, func() (int, error) {
+		calls++
+		return 0, failed
+	}); err != failed || calls != 1 {
+		t.Fatalf("expected the error to be returned without retrying, got %v after %d calls", err, calls)
+	}
+
+	// a cache policy overrides the session policy
+	bc.cacheOpts = &CacheOptions{RetryPolicy: &RetryPolicy{MaxAttempts: 1}}
+	calls = 0
+	if _, err = retryRead(context.Background(), bc, func() (int, error) {
+		calls++
+		return 0, ErrStreamDisconnected
+	}); err != ErrStreamDisconnected || calls != 1 {
+		t.Fatalf("expected a single attempt, got %v after %d calls", err, calls)
+	}
+}
+
+func TestRetryStream(t *testing.T) {
+	var (
+		bc    = newRetryTestClient(t)
+		calls int
+	)
+
+	ch := retryStream(context.Background(), bc, func() <-chan *StreamedKey[int] {
+		calls++
+		out := make(chan *StreamedKey[int], 2)
+		if calls == 1 {
+			out <- &StreamedKey[int]{Err: ErrStreamDisconnected}
+		} else {
+			out <- &StreamedKey[int]{Key: 1}
+			out <- &StreamedKey[int]{Key: 2}
+		}
+		close(out)
+		return out
+	})
+
+	var keys []int
+	for k := range ch {
+		if k.Err != nil {
+			t.Fatalf("unexpected error %v", k.Err)
+		}
+		keys = append(keys, k.Key)
+	}
+	if len(keys) != 2 || calls != 2 {
+		t.Fatalf("expected 2 keys after 2 attempts, got %v after %d calls", keys, calls)
+	}
+}
+
+func TestAbandonRequests(t *testing.T) {
+	m := &streamManagerV1{requests: make(map[int64]proxyRequestChannel)}
+	r, streaming := newProxyRequestChannel(nil), newProxyRequestChannel(nil)
+	m.requests[1], m.requests[2] = r, streaming
+
+	// the requests are abandoned before they are waiting for a response, and more than once
+	m.abandonRequests()
+	m.abandonRequests()
+
+	if _, err := waitForResponse(context.Background(), r); !errors.Is(err, ErrStreamDisconnected) {
+		t.Fatalf("expected ErrStreamDisconnected, got %v", err)
+	}
+	if resp := <-waitForStreamingResponse(context.Background(), streaming); !resp.disconnected || !resp.complete {
+		t.Fatalf("expected a disconnected response, got %v", resp)
+	}
+}
@@ -7,20 +7,100 @@
 package coherence
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
-	"strings"
+	"slices"
+	"sync"
 )
 
 const (
 	jsonSerializationPrefix = 21
+
+	// maxPooledBufferSize is the maximum capacity of a buffer returned to a pool, so that
+	// an occasional large value does not cause a large buffer to be retained.
+	maxPooledBufferSize = 64 * 1024
 )
 
 var (
 	_           Serializer[string] = JSONSerializer[string]{"json"}
-	conversions                    = [2]string{"\"@class\":\"math.BigDec\",", "\"@class\":\"math.BigInt\","}
+	conversions                    = [2][]byte{[]byte("\"@class\":\"math.BigDec\","), []byte("\"@class\":\"math.BigInt\",")}
+	jsonNull                       = []byte("null")
+
+	jsonEncoders = sync.Pool{
+		New: func() any {
+			e := &jsonEncoder{}
+			e.encoder = json.NewEncoder(&e.buffer)
+			return e
+		},
+	}
+
+	// buffers holds the buffers which keys, values and requests are serialized into
+	// for frequently issued requests, which are only needed until the request is complete.
+	buffers = sync.Pool{
+		New: func() any {
+			return &pooledBuffer{}
+		},
+	}
 )
 
+// pooledBuffer is a buffer from a pool which must not be released while the data serialized into it is referenced.
+type pooledBuffer struct {
+	data []byte
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *pooledBuffer {
+	return buffers.Get().(*pooledBuffer)
+}
+
+// release returns the buffer to the pool unless it has grown too large.
+func (b *pooledBuffer) release() {
+	if cap(b.data) > maxPooledBufferSize {
+		return
+	}
+	b.data = b.data[:0]
+	buffers.Put(b)
+}
+
+// serializeTo serializes the object into the buffer if the serializer can append to an existing slice,
+// otherwise the serialized object is allocated.
+func serializeTo[T any](buffer *pooledBuffer, serializer Serializer[T], object T) ([]byte, error) {
+	s, ok := serializer.(appendingSerializer[T])
+	if !ok {
+		return serializer.Serialize(object)
+	}
+
+	start := len(buffer.data)
+	data, err := s.appendSerialize(buffer.data, object)
+	if err != nil {
+		return nil, err
+	}
+	buffer.data = data
+	return data[start:len(data):len(data)], nil
+}
+
+// jsonEncoder is a pooled JSON encoder and the buffer it writes to.
+type jsonEncoder struct {
+	buffer  bytes.Buffer
+	encoder *json.Encoder
+}
+
+// release returns the encoder to the pool unless its buffer has grown too large.
+func (e *jsonEncoder) release() {
+	if e.buffer.Cap() > maxPooledBufferSize {
+		return
+	}
+	e.buffer.Reset()
+	jsonEncoders.Put(e)
+}
+
+// appendingSerializer is implemented by serializers which can append to an existing slice,
+// so that multiple values can be serialized into a single allocation.
+type appendingSerializer[T any] interface {
+	appendSerialize(dst []byte, object T) ([]byte, error)
+}
+
 // mathValue is used to extract the value of math.BigDec or math.BigInt.
 type mathValue[T any] struct {
 	Value T `json:"value"`
@@ -40,10 +120,13 @@ type Serializer[T any] interface {
 
 // NewSerializer returns a new [Serializer] based upon the format and the type.
 func NewSerializer[T any](format string) Serializer[T] {
-	// currently only "json" serialization is supported. If another
-	// serialization format is used, it will default to "json".
-	if format == "json" {
-		return JSONSerializer[T]{format: "json"}
+	// "json", "pof" and formats registered using RegisterSerializer are supported.
+	// If another serialization format is used, it will default to "json".
+	if format == pofFormat {
+		return PofSerializer[T]{format: pofFormat}
+	}
+	if serializer := customSerializers.get(format); serializer != nil {
+		return customSerializer[T]{serializer: serializer}
 	}
 	return JSONSerializer[T]{format: "json"}
 }
@@ -55,21 +138,28 @@ type JSONSerializer[T any] struct {
 
 // Serialize serializes an object of type T and returns the []byte representation.
 func (s JSONSerializer[T]) Serialize(object T) ([]byte, error) {
-	data, err := json.Marshal(object)
-	if err != nil {
+	return s.appendSerialize(nil, object)
+}
+
+// appendSerialize appends the serialized object to dst, growing it at most once.
+func (s JSONSerializer[T]) appendSerialize(dst []byte, object T) ([]byte, error) {
+	e := jsonEncoders.Get().(*jsonEncoder)
+	defer e.release()
+
+	if err := e.encoder.Encode(object); err != nil {
 		return nil, err
 	}
 
-	finalData := make([]byte, 1)
-	finalData[0] = jsonSerializationPrefix
-	finalData = append(finalData, data...)
-	return finalData, nil
+	// the encoder terminates the value with a newline, which is replaced by the prefix
+	data := e.buffer.Bytes()
+	dst = slices.Grow(dst, len(data))
+	dst = append(dst, jsonSerializationPrefix)
+	return append(dst, data[:len(data)-1]...), nil
 }
 
 // Deserialize deserialized an object and returns the correct type of T.
 func (s JSONSerializer[T]) Deserialize(data []byte) (*T, error) {
 	var (
-		finalData   []byte
 		finalResult T
 		err         error
 		zeroValue   T
@@ -79,21 +169,32 @@ func (s JSONSerializer[T]) Deserialize(data []byte) (*T, error) {
 	}
 
 	if data[0] == jsonSerializationPrefix {
-		// ensure we return the correct type of instance based upon the generic type
-		finalResult = *new(T)
-		finalData = append(finalData, data[1:]...)
-		if string(finalData) == "null" {
+		// the data is not retained by json.Unmarshal so there is no need to copy it
+		finalData := data[1:]
+		if bytes.Equal(finalData, jsonNull) {
 			return nil, nil
 		}
 
+		// decode values with a registered @class into their type when T is an interface
+		if isInterface[T]() {
+			value, err := classes.decodeValue(finalData)
+			if err != nil {
+				return &zeroValue, err
+			}
+			result, ok := value.(T)
+			if !ok {
+				return &zeroValue, fmt.Errorf("%w: %T is not a %v", ErrInvalidClass, value, typeName[T]())
+			}
+			return &result, nil
+		}
+
 		// check for deserialization of math.BigDec or math.BigInt
-		finalDataString := string(finalData)
 		converted := false
 		for _, v := range conversions {
 			// if the serialized data contains the conversions then just remove them as the
 			// serializer will sort them out
-			if strings.Contains(finalDataString, v) {
-				finalData = []byte(strings.Replace(finalDataString, v, "", 1))
+			if bytes.Contains(finalData, v) {
+				finalData = bytes.Replace(finalData, v, nil, 1)
 				converted = true
 			}
 		}
@@ -7,6 +7,7 @@
 package coherence
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 )
@@ -74,3 +75,61 @@ func testSerialization[V any](t *testing.T, v V) {
 		t.Fatalf("expected deserialized value %#v to equal original %#v", *finalValue, v)
 	}
 }
+
+type benchmarkPerson struct {
+	ID      int               `json:"id"`
+	Name    string            `json:"name"`
+	Email   string            `json:"email"`
+	Tags    []string          `json:"tags"`
+	Details map[string]string `json:"details"`
+}
+
+var benchmarkValue = benchmarkPerson{
+	ID:      1,
+	Name:    "Tim",
+	Email:   "tim@example.com",
+	Tags:    []string{"gold", "early-adopter"},
+	Details: map[string]string{"city": "Perth", "country": "Australia"},
+}
+
+func BenchmarkJSONSerialize(b *testing.B) {
+	serializer := NewSerializer[benchmarkPerson]("json")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := serializer.Serialize(benchmarkValue); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkJSONDeserialize(b *testing.B) {
+	serializer := NewSerializer[benchmarkPerson]("json")
+	data, err := serializer.Serialize(benchmarkValue)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err = serializer.Deserialize(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSerializeKeys(b *testing.B) {
+	var (
+		serializer = NewSerializer[string]("json")
+		keys       = make([]string, 100)
+	)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := serializeKeys(serializer, keys); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -13,14 +13,20 @@ import (
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/oracle/coherence-go-client/v2/coherence/capture"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/status"
+	"hash/fnv"
+	"io"
+	"log/slog"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
@@ -29,15 +35,17 @@ import (
 	"time"
 )
 
-// ErrInvalidFormat indicates that the serialization format can only be JSON.
+// ErrInvalidFormat indicates that the serialization format can only be JSON, POF or a registered format.
 var (
-	ErrInvalidFormat             = errors.New("format can only be 'json'")
+	ErrInvalidFormat             = errors.New("format can only be 'json', 'pof' or a format registered using RegisterSerializer")
 	ErrInvalidNearCache          = errors.New("you must specify at least one near cache option")
 	ErrInvalidNearCacheWithTTL   = errors.New("when using TTL for near cache you can only specify highUnits or highUnitsMemory")
 	ErrInvalidNearCacheTTL       = errors.New("minimum near cache TTL is 1/4 of a second")
 	ErrInvalidNearCacheWithNoTTL = errors.New("you can only specify highUnits or highUnitsMemory, not both")
 	ErrNegativeNearCacheOptions  = errors.New("you cannot specify negative values for near cache options")
 	ErrInvalidPruneFactor        = errors.New("prune factor must be between 0.1 and 1.0")
+	ErrInvalidHeartbeat          = errors.New("heartbeat interval and missed limit must both be positive")
+	ErrInvalidStreamCount        = errors.New("stream count must be at least 1")
 )
 
 const (
@@ -58,7 +66,7 @@ type Session struct {
 	sessOpts              *SessionOptions
 	conn                  *grpc.ClientConn
 	dialOptions           []grpc.DialOption
-	closed                bool
+	closed                atomic.Bool // read without mapMutex by background goroutines
 	mapMutex              sync.RWMutex
 	caches                map[string]interface{}
 	maps                  map[string]interface{}
@@ -76,7 +84,21 @@ type Session struct {
 	requestID             int64                // request id for gRPC v1
 	filterID              int64                // filter id for gRPC v1
 	v1StreamManagerCache  *streamManagerV1
+	v1StreamManagersCache []*streamManagerV1 // all cache streams, the first being v1StreamManagerCache
 	v1StreamManagerQueue  *streamManagerV1
+	streamsMutex          sync.RWMutex  // protects v1StreamManagersCache
+	nextStream            atomic.Uint32 // used to round-robin requests across the cache streams
+	reconnecting          atomic.Bool   // indicates a reconnect of the connection is in progress
+	logger                *slog.Logger  // the logger set via WithLogger, if any
+	metrics               *sessionMetrics
+	credentials           *credentialsManager // set if a CredentialsProvider has been specified
+	tlsReloader           *tlsReloader        // set if the TLS files are being reloaded
+	shuttingDown          atomic.Bool         // indicates Shutdown has been called and new requests are rejected
+	inFlight              inFlightOperations  // the number of in-flight operations, including streaming queries
+	limiter               *inFlightLimiter    // set if the maximum number of in-flight requests is limited
+	breaker               *circuitBreaker     // set if a circuit breaker has been enabled
+	recorder              *capture.Recorder   // set if gRPC v1 traffic is being captured
+	captureFile           *os.File            // set if the capture file was opened by the session
 }
 
 // SessionOptions holds the session attributes like host, port, tls attributes etc.
@@ -93,6 +115,51 @@ type SessionOptions struct {
 	DisconnectTimeout  time.Duration
 	ReadyTimeout       time.Duration
 	TlSConfig          *tls.Config
+
+	// HeartbeatInterval is the interval at which heartbeats are sent on gRPC v1 streams, zero disables heartbeats.
+	HeartbeatInterval time.Duration
+
+	// HeartbeatMissedLimit is the number of heartbeat intervals without a response after which a stream is considered dead.
+	HeartbeatMissedLimit int
+
+	// ReconnectPolicy controls how the session reconnects after it has been disconnected.
+	ReconnectPolicy *ReconnectPolicy
+
+	// StreamCount is the number of gRPC v1 streams cache requests are spread across, defaults to 1.
+	StreamCount int
+
+	// Logger is the structured logger used for all messages for the session, if not set the standard log package is used.
+	Logger *slog.Logger
+
+	// Tracer is notified when each operation starts and ends, set using [WithTracer].
+	Tracer Tracer
+
+	// CredentialsProvider provides the call credentials attached to requests, set using [WithCredentialsProvider].
+	CredentialsProvider CredentialsProvider
+
+	// TLSReloadInterval is the interval at which the TLS files are checked for changes, zero disables reloading.
+	TLSReloadInterval time.Duration
+
+	// MaxInFlight is the maximum number of concurrent requests, zero means no limit, set using [WithMaxInFlight].
+	MaxInFlight int
+
+	// MaxInFlightPolicy is the policy applied to requests once MaxInFlight has been reached.
+	MaxInFlightPolicy MaxInFlightPolicy
+
+	// CircuitBreakerPolicy enables a circuit breaker for the session, set using [WithCircuitBreaker].
+	CircuitBreakerPolicy *CircuitBreakerPolicy
+
+	// RetryPolicy controls how idempotent read operations are retried, set using [WithRetryPolicy].
+	RetryPolicy *RetryPolicy
+
+	// FaultInjector injects faults into the gRPC transport for testing, set using [WithFaultInjector].
+	FaultInjector *FaultInjector
+
+	// Capture is where gRPC v1 requests and responses are recorded, set using [WithCapture].
+	Capture io.Writer
+
+	// Metrics enables the collection of the metrics returned by [Session.Metrics], set using [WithMetrics].
+	Metrics bool
 }
 
 // NewSession creates a new [Session] with the specified sessionOptions.
@@ -159,7 +226,6 @@ func NewSession(ctx context.Context, options ...func(session *SessionOptions)) (
 	session := &Session{
 		sessionID:             uuid.New(),
 		sessionConnectCtx:     ctx,
-		closed:                false,
 		firstConnectAttempted: false,
 		hasConnected:          false,
 		debug: func(_ string, _ ...any) {
@@ -193,23 +259,23 @@ func NewSession(ctx context.Context, options ...func(session *SessionOptions)) (
 	// set the coherenceLogLevel
 	setLogLevel(getStringValueFromEnvVarOrDefault(envLogLevel, "3"))
 
-	if getBoolValueFromEnvVarOrDefault(envSessionDebug, false) || currentLogLevel >= int(DEBUG) {
+	if getBoolValueFromEnvVarOrDefault(envSessionDebug, false) || currentLogLevel.Load() >= int32(DEBUG) {
 		// enable session debugging
 		session.debug = func(format string, v ...any) {
-			logMessage(DEBUG, format, v...)
+			session.log(DEBUG, format, v...)
 		}
-		if currentLogLevel <= int(DEBUG) {
-			currentLogLevel = int(DEBUG)
+		if currentLogLevel.Load() <= int32(DEBUG) {
+			currentLogLevel.Store(int32(DEBUG))
 		}
 	}
 
 	messageDebug := getStringValueFromEnvVarOrDefault(envMessageDebug, "")
-	if messageDebug != "" || currentLogLevel == int(ALL) {
+	if messageDebug != "" || currentLogLevel.Load() == int32(ALL) {
 		// enable session debugging
-		session.debugConnection = func(s string, v ...any) {
-			logMessage(DEBUG, s, v...)
+		session.debugConnection = func(format string, v ...any) {
+			session.log(DEBUG, format, v...)
 		}
-		currentLogLevel = int(ALL)
+		currentLogLevel.Store(int32(ALL))
 	}
 
 	// apply any options
@@ -217,10 +283,59 @@ func NewSession(ctx context.Context, options ...func(session *SessionOptions)) (
 		f(session.sessOpts)
 	}
 
-	if session.sessOpts.Format != defaultFormat {
+	if session.sessOpts.Logger != nil {
+		session.setLogger(session.sessOpts.Logger)
+	}
+
+	if !isKnownFormat(session.sessOpts.Format) {
 		return nil, ErrInvalidFormat
 	}
 
+	if session.sessOpts.HeartbeatInterval < 0 || session.sessOpts.HeartbeatMissedLimit < 0 ||
+		(session.sessOpts.HeartbeatInterval > 0 && session.sessOpts.HeartbeatMissedLimit == 0) {
+		return nil, ErrInvalidHeartbeat
+	}
+
+	if session.sessOpts.TLSReloadInterval < 0 {
+		return nil, ErrInvalidTLSReloadInterval
+	}
+
+	if session.sessOpts.MaxInFlight < 0 {
+		return nil, ErrInvalidMaxInFlight
+	}
+	session.limiter = newInFlightLimiter(session, session.sessOpts.MaxInFlight, session.sessOpts.MaxInFlightPolicy)
+
+	if session.sessOpts.Metrics {
+		session.metrics = newSessionMetrics()
+	}
+
+	if session.sessOpts.CircuitBreakerPolicy != nil {
+		if err := session.sessOpts.CircuitBreakerPolicy.validate(); err != nil {
+			return nil, err
+		}
+		session.breaker = newCircuitBreaker(session, "", session.sessOpts.CircuitBreakerPolicy)
+	}
+
+	if session.sessOpts.RetryPolicy != nil {
+		if err := session.sessOpts.RetryPolicy.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if session.sessOpts.StreamCount == 0 {
+		session.sessOpts.StreamCount = 1
+	} else if session.sessOpts.StreamCount < 0 {
+		return nil, ErrInvalidStreamCount
+	}
+
+	if session.sessOpts.ReconnectPolicy == nil {
+		policy := DefaultReconnectPolicy()
+		session.sessOpts.ReconnectPolicy = &policy
+	}
+	if err := session.sessOpts.ReconnectPolicy.validate(); err != nil {
+		return nil, err
+	}
+
 	// if no address option sent in then use the env or defaults
 	if session.sessOpts.Address == "" {
 		session.sessOpts.Address = getStringValueFromEnvVarOrDefault(envHostName, "localhost:1408")
@@ -253,8 +368,34 @@ func NewSession(ctx context.Context, options ...func(session *SessionOptions)) (
 		session.sessOpts.ReadyTimeout = timeout
 	}
 
+	if session.sessOpts.Capture == nil {
+		if file := getStringValueFromEnvVarOrDefault(envCaptureFile, ""); file != "" {
+			file = captureFileName(file, session.sessionID)
+			f, err := os.Create(file)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create capture file %s: %w", file, err)
+			}
+			session.captureFile = f
+			session.sessOpts.Capture = f
+		}
+	}
+	if session.sessOpts.Capture != nil {
+		session.recorder = capture.NewRecorder(session.sessOpts.Capture)
+	}
+
 	// ensure initial connection
-	return session, session.ensureConnection()
+	if err := session.ensureConnection(); err != nil {
+		session.closeCapture()
+		return session, err
+	}
+	return session, nil
+}
+
+// captureFileName returns the name of the capture file for a session, which includes the session id before the
+// extension so that the sessions in a process do not overwrite each other's captures.
+func captureFileName(file string, sessionID uuid.UUID) string {
+	ext := filepath.Ext(file)
+	return strings.TrimSuffix(file, ext) + "-" + sessionID.String() + ext
 }
 
 // setLogLevel sets the log level from the COHERENCE_LOG_LEVEL environment variable.
@@ -264,7 +405,7 @@ func setLogLevel(envLevel string) {
 	// try to convert from integer first
 	if lvl, err := strconv.Atoi(envLevel); err == nil {
 		if lvl >= 1 && lvl <= 5 {
-			currentLogLevel = lvl
+			currentLogLevel.Store(int32(lvl))
 			return
 		}
 	}
@@ -285,7 +426,7 @@ func setLogLevel(envLevel string) {
 		level = 3 // INFO
 	}
 
-	currentLogLevel = level
+	currentLogLevel.Store(int32(level))
 }
 
 func getTimeoutValue(envVar, defaultValue, description string) (time.Duration, error) {
@@ -304,7 +445,11 @@ func WithAddress(host string) func(sessionOptions *SessionOptions) {
 	}
 }
 
-// WithFormat returns a function to set the format for a session. Currently, only "json" is supported.
+// WithFormat returns a function to set the format for a session, either "json", the default, or "pof".
+// When using "pof", the structs stored must be registered using [RegisterPofType], and the filters, extractors,
+// entry processors and aggregators in this module cannot be used, so operations such as EntrySetFilter, Invoke,
+// Aggregate, AddIndex and AddFilterListener return [ErrPofAgentNotSupported]. A custom format can also be used
+// once its serializer has been registered using [RegisterSerializer].
 func WithFormat(format string) func(sessionOptions *SessionOptions) {
 	return func(s *SessionOptions) {
 		s.Format = format
@@ -386,6 +531,38 @@ func WithTLSConfig(tlsConfig *tls.Config) func(sessionOptions *SessionOptions) {
 	}
 }
 
+// WithHeartbeat returns a function to enable heartbeats on gRPC v1 connections. The client sends a heartbeat
+// on each stream every interval and asks the server to do the same. If nothing is received from the server
+// for missedLimit intervals, the stream is considered dead, a [Disconnected] event is raised and the
+// [Session] will attempt to reconnect.
+func WithHeartbeat(interval time.Duration, missedLimit int) func(sessionOptions *SessionOptions) {
+	return func(s *SessionOptions) {
+		s.HeartbeatInterval = interval
+		s.HeartbeatMissedLimit = missedLimit
+	}
+}
+
+// WithCapture returns a function to record every request sent and response received on the gRPC v1 streams
+// of a [Session] to w, with timestamps, in the format described in the [capture] package. A capture can be
+// replayed using a [capture.ReplayServer] or compared against a proxy using [capture.Compare]. The
+// COHERENCE_CAPTURE_FILE environment variable can also be set to the name of a file to capture to, in which case
+// the session id is added to the name, before the extension, so each session writes its own file.
+// This option has no effect when connecting to a gRPC v0 proxy.
+func WithCapture(w io.Writer) func(sessionOptions *SessionOptions) {
+	return func(s *SessionOptions) {
+		s.Capture = w
+	}
+}
+
+// WithStreamCount returns a function to set the number of gRPC v1 streams that cache requests
+// for a [Session] are spread across. All the events for a [NamedMap] or [NamedCache] are received on a
+// single stream. This option has no effect when connecting to a gRPC v0 proxy.
+func WithStreamCount(count int) func(sessionOptions *SessionOptions) {
+	return func(s *SessionOptions) {
+		s.StreamCount = count
+	}
+}
+
 func (s *Session) NextRequestID() int64 {
 	return atomic.AddInt64(&s.requestID, 1)
 }
@@ -402,8 +579,12 @@ func (s *Session) getQueueID(queue string) *int32 {
 	return s.queueIDMap.Get(queue)
 }
 
-func (s *Session) getCacheNameFromCacheID(cacheID int32) *string {
-	return s.cacheIDMap.KeyFromValue(cacheID)
+// removeCacheID removes the id for the cache from all cache streams.
+func (s *Session) removeCacheID(cache string) {
+	for _, m := range s.getCacheStreams() {
+		m.cacheIDMap.Remove(cache)
+	}
+	s.cacheIDMap.Remove(cache)
 }
 
 func (s *Session) getNamedMapClient(name string) interface{} {
@@ -434,37 +615,76 @@ func (s *Session) ID() string {
 // Close closes a connection.
 func (s *Session) Close() {
 	s.mapMutex.Lock()
-	if !s.closed {
+	if !s.closed.Load() {
 		s.maps = make(map[string]interface{}, 0)
 		s.caches = make(map[string]interface{}, 0)
 		err := s.conn.Close()
-		s.closed = true
+		s.closed.Store(true)
+
+		if s.tlsReloader != nil {
+			s.tlsReloader.stop()
+		}
 
 		s.mapMutex.Unlock()
 
 		if s.GetProtocolVersion() > 0 {
-			_ = s.v1StreamManagerCache.eventStream.grpcStream.CloseSend()
+			streams := append([]*streamManagerV1{}, s.getCacheStreams()...)
 			if s.v1StreamManagerQueue != nil {
-				_ = s.v1StreamManagerQueue.eventStream.grpcStream.CloseSend()
+				streams = append(streams, s.v1StreamManagerQueue)
+			}
+			for _, m := range streams {
+				_ = m.eventStream.grpcStream.CloseSend()
+			}
+			if s.recorder != nil {
+				// wait for the streams to end so that the last responses are captured
+				waitForStreams(streams, s.GetDisconnectTimeout())
 			}
 		}
+		s.closeCapture()
+
 		s.dispatch(Closed, func() SessionLifecycleEvent {
 			return newSessionLifecycleEvent(s, Closed)
 		})
 		if err != nil {
-			logMessage(WARNING, "unable to close session %s %v", s.sessionID, err)
+			s.log(WARNING, "unable to close session %s %v", s.sessionID, err)
 		}
 	} else {
 		defer s.mapMutex.Unlock()
 	}
 }
 
+// closeCapture logs any error capturing the gRPC v1 traffic and closes the capture file if it was
+// opened by the session.
+func (s *Session) closeCapture() {
+	if s.recorder == nil {
+		return
+	}
+	if captureErr := s.recorder.Err(); captureErr != nil {
+		s.log(WARNING, "unable to capture traffic for session %s: %v", s.sessionID, captureErr)
+	}
+	if s.captureFile != nil {
+		_ = s.captureFile.Close()
+	}
+}
+
+// waitForStreams waits, up to the timeout, for the gRPC v1 streams to end.
+func waitForStreams(streams []*streamManagerV1, timeout time.Duration) {
+	deadline := time.After(timeout)
+	for _, m := range streams {
+		select {
+		case <-m.eventStream.done:
+		case <-deadline:
+			return
+		}
+	}
+}
+
 func (s *Session) String() string {
 	var serverProtocolVersion int32
-	if s.v1StreamManagerCache != nil {
-		serverProtocolVersion = s.v1StreamManagerCache.serverProtocolVersion
+	if m := s.primaryCacheStream(); m != nil {
+		serverProtocolVersion = m.serverInfo.ProtocolVersion
 	}
-	return fmt.Sprintf("Session{id=%s, closed=%v, caches=%d, maps=%d, serverProtocolVersion=%v, options=%v}", s.sessionID.String(), s.closed,
+	return fmt.Sprintf("Session{id=%s, closed=%v, caches=%d, maps=%d, serverProtocolVersion=%v, options=%v}", s.sessionID.String(), s.closed.Load(),
 		len(s.caches), len(s.maps), serverProtocolVersion, s.sessOpts)
 }
 
@@ -495,7 +715,7 @@ func (s *Session) ensureConnection() error {
 			if s.GetReadyTimeout() != 0 {
 				return waitForReady(s)
 			}
-			logMessage(INFO, "Session: [%s] attempting connection to address %s", s.sessionID, s.sessOpts.Address)
+			s.log(INFO, "Session: [%s] attempting connection to address %s", s.sessionID, s.sessOpts.Address)
 			s.conn.Connect()
 			return nil
 		}
@@ -504,24 +724,54 @@ func (s *Session) ensureConnection() error {
 
 	s.dialOptions = []grpc.DialOption{}
 
-	tlsOpt, err := s.sessOpts.createTLSOption()
+	tlsOpt, err := s.createTLSOption()
 	if err != nil {
 		errString := fmt.Sprintf("error while setting up channel credentials: %v", err)
 		return errors.New(errString)
 	}
+
+	if s.sessOpts.TLSReloadInterval > 0 && s.sessOpts.usesTLSFiles() {
+		reloader, err1 := newTLSReloader(s)
+		if err1 != nil {
+			return fmt.Errorf("error while setting up channel credentials: %v", err1)
+		}
+		reloader.start()
+		s.tlsReloader = reloader
+		tlsOpt = grpc.WithTransportCredentials(reloader)
+	}
 	s.dialOptions = append(s.dialOptions, tlsOpt)
 
+	policy := s.sessOpts.ReconnectPolicy
 	connOpt := grpc.WithConnectParams(grpc.ConnectParams{
 		Backoff: backoff.Config{
-			BaseDelay:  1.0 * time.Second,
-			Multiplier: 1.1,
-			Jitter:     0.0,
-			MaxDelay:   3.0 * time.Second,
+			BaseDelay:  policy.InitialBackoff,
+			Multiplier: policy.Multiplier,
+			Jitter:     policy.Jitter,
+			MaxDelay:   policy.MaxBackoff,
 		},
 		MinConnectTimeout: 10 * time.Second,
 	})
 	s.dialOptions = append(s.dialOptions, connOpt)
 
+	if provider := s.sessOpts.CredentialsProvider; provider != nil {
+		s.credentials = newCredentialsManager(s, provider)
+		s.dialOptions = append(s.dialOptions, s.credentials.dialOptions()...)
+	}
+
+	if injector := s.sessOpts.FaultInjector; injector != nil {
+		s.dialOptions = append(s.dialOptions, injector.dialOptions()...)
+	}
+
+	if s.recorder != nil {
+		// added after the fault injector so that the traffic is captured as it is sent and received
+		s.dialOptions = append(s.dialOptions, grpc.WithChainStreamInterceptor(s.recorder.StreamInterceptor))
+	}
+
+	if s.logger != nil {
+		// use a resolver for this connection which logs to the session logger
+		s.dialOptions = append(s.dialOptions, grpc.WithResolvers(&nsLookupResolverBuilder{session: s}))
+	}
+
 	newCtx, cancel := s.ensureContext(s.sessionConnectCtx)
 	if cancel != nil {
 		defer cancel()
@@ -531,7 +781,7 @@ func (s *Session) ensureConnection() error {
 	conn, err := grpc.DialContext(newCtx, s.sessOpts.Address, s.dialOptions...)
 
 	if err != nil {
-		logMessage(WARNING, "Session: [%s] could not connect. Reason: %v", s.sessionID, err)
+		s.log(WARNING, "Session: [%s] could not connect. Reason: %v", s.sessionID, err)
 		return err
 	}
 
@@ -542,9 +792,13 @@ func (s *Session) ensureConnection() error {
 
 	// attempt to connect to V1 gRPC endpoint first and fallback if not available
 	manager, err1 := newStreamManagerV1(s, cacheServiceProtocol)
+	if err1 != nil && s.credentials != nil && status.Code(err1) == codes.Unauthenticated {
+		// the credentials were rejected, so retry once with new credentials
+		manager, err1 = newStreamManagerV1(s, cacheServiceProtocol)
+	}
 	if err1 == nil {
-		// save the stream manager for a successful V1 client connection
-		s.v1StreamManagerCache = manager
+		// save the stream managers for a successful V1 client connection
+		s.setCacheStreams(s.addCacheStreams(manager))
 		apiMessage = fmt.Sprintf(" %v", manager)
 	} else {
 		// check if this is a gRPC status error
@@ -557,18 +811,17 @@ func (s *Session) ensureConnection() error {
 		}
 	}
 
-	logMessage(INFO, "Session [%s] connected to [%s]%s", s.sessionID, s.sessOpts.Address, apiMessage)
+	s.log(INFO, "Session [%s] connected to [%s]%s", s.sessionID, s.sessOpts.Address, apiMessage)
 
 	// register for state change events - This uses an experimental gRPC API
 	// so may not be reliable or may change in the future.
 	// refer: https://grpc.github.io/grpc/core/md_doc_connectivity-semantics-and-api.html
 	go func(session *Session) {
 		var (
-			firstConnect   = true
-			connected      = false
-			ctx            = context.Background()
-			lastState      = session.conn.GetState()
-			disconnectTime int64
+			firstConnect = true
+			connected    = false
+			ctx          = context.Background()
+			lastState    = session.conn.GetState()
 		)
 
 		for {
@@ -586,7 +839,7 @@ func (s *Session) ensureConnection() error {
 			session.debug("connection: %v => %v", lastState, newState)
 
 			if newState == connectivity.Shutdown {
-				logMessage(INFO, "Session [%s] closed", session.sessionID)
+				session.log(INFO, "Session [%s] closed", session.sessionID)
 				session.Close()
 				return
 			}
@@ -594,11 +847,10 @@ func (s *Session) ensureConnection() error {
 			if newState == connectivity.Ready {
 				if !firstConnect && !connected {
 					// Reconnected
-					disconnectTime = 0
-					session.closed = false
+					session.closed.Store(false)
 					connected = true
 
-					logMessage(INFO, "Session [%s] re-connected to address %s (%v)", session.sessionID, session.sessOpts.Address, newState)
+					session.log(INFO, "Session [%s] re-connected to address %s (%v)", session.sessionID, session.sessOpts.Address, newState)
 					session.dispatch(Reconnected, func() SessionLifecycleEvent {
 						return newSessionLifecycleEvent(session, Reconnected)
 					})
@@ -607,37 +859,24 @@ func (s *Session) ensureConnection() error {
 					firstConnect = false
 					connected = true
 					session.hasConnected = true
-					logMessage(INFO, "Session [%s] connected to address %s", session.sessionID, session.sessOpts.Address)
+					session.log(INFO, "Session [%s] connected to address %s", session.sessionID, session.sessOpts.Address)
 					session.dispatch(Connected, func() SessionLifecycleEvent {
 						return newSessionLifecycleEvent(session, Connected)
 					})
 				}
 			} else {
 				if connected {
-					disconnectTime = -1
-					logMessage(WARNING, "Session [%s] disconnected from address %s", session.sessionID, session.sessOpts.Address)
+					session.disconnectStreams()
+					session.log(WARNING, "Session [%s] disconnected from address %s", session.sessionID, session.sessOpts.Address)
 					session.dispatch(Disconnected, func() SessionLifecycleEvent {
 						return newSessionLifecycleEvent(session, Disconnected)
 					})
 					connected = false
-				}
-
-				if disconnectTime != 0 {
-					if disconnectTime == -1 {
-						disconnectTime = time.Now().UnixMilli()
-					} else {
-						waited := time.Now().UnixMilli() - disconnectTime
-						if waited >= session.GetDisconnectTimeout().Milliseconds() {
-							logMessage(ERROR, "Session [%s] unable to reconnect within disconnect timeout of [%s], closing session.",
-								session.sessionID, session.GetDisconnectTimeout().String())
-							session.Close()
-							return
-						}
-					}
-				}
 
-				// trigger a reconnection on state change
-				if newState != connectivity.Connecting {
+					// attempt to reconnect as governed by the reconnect policy
+					go session.reconnectConnection()
+				} else if !session.hasConnected && newState != connectivity.Connecting {
+					// trigger a connection on state change
 					conn.Connect()
 				}
 			}
@@ -648,12 +887,315 @@ func (s *Session) ensureConnection() error {
 	return nil
 }
 
+// disconnectStreams marks any gRPC v1 streams as disconnected so that they are re-created on reconnect.
+func (s *Session) disconnectStreams() {
+	for _, m := range s.getCacheStreams() {
+		m.disconnect()
+	}
+	if s.v1StreamManagerQueue != nil {
+		s.v1StreamManagerQueue.disconnect()
+	}
+}
+
+// streamDisconnected is called when a gRPC v1 stream has ended while the session is still open.
+// If the underlying connection is still ready then only the stream was lost, so it is re-established here,
+// otherwise the connection state watcher in ensureConnection will handle the reconnect.
+func (s *Session) streamDisconnected(m *streamManagerV1) {
+	if m.retired.Load() || s.IsClosed() || s.conn.GetState() != connectivity.Ready {
+		return
+	}
+
+	s.log(WARNING, "Session [%s] %s stream disconnected from address %s", s.sessionID, m.proxyProtocol, s.sessOpts.Address)
+	s.dispatch(Disconnected, func() SessionLifecycleEvent {
+		return newSessionLifecycleEvent(s, Disconnected)
+	})
+
+	err := s.reconnect(fmt.Sprintf("re-establish %s stream", m.proxyProtocol), func() error {
+		return s.reconnectStream(m.proxyProtocol)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrClosed) {
+			s.log(ERROR, "Session [%s] %v, closing session.", s.sessionID, err)
+			s.Close()
+		}
+		return
+	}
+
+	s.log(INFO, "Session [%s] %s stream re-connected to address %s", s.sessionID, m.proxyProtocol, s.sessOpts.Address)
+	s.dispatch(Reconnected, func() SessionLifecycleEvent {
+		return newSessionLifecycleEvent(s, Reconnected)
+	})
+}
+
+// reconnectStream re-creates the gRPC v1 stream for the given protocol if it has been disconnected.
+func (s *Session) reconnectStream(proxyProtocol V1ProxyProtocol) error {
+	if proxyProtocol == queueServiceProtocol {
+		return s.reconnectQueueStream()
+	}
+	return s.reconnectCacheStream()
+}
+
+// reconnectCacheStream re-creates the cache stream managers if any of the current streams have been
+// disconnected and re-ensures all the caches, as the cache ids are only valid for a single stream.
+// All the streams are re-created together so that the caches and listeners are rebalanced across them.
+func (s *Session) reconnectCacheStream() error {
+	s.connectMutex.Lock()
+	defer s.connectMutex.Unlock()
+
+	current := s.getCacheStreams()
+	if len(current) > 0 && !anyDisconnected(current) {
+		return nil
+	}
+
+	// save the cache names
+	cacheNames := s.cacheIDMap.Keys()
+
+	for _, m := range current {
+		m.retire()
+	}
+
+	manager, err := newStreamManagerV1(s, cacheServiceProtocol)
+	if err != nil {
+		return err
+	}
+	if len(current) > 0 {
+		s.metrics.streamReconnected()
+	}
+	managers := s.addCacheStreams(manager)
+	s.setCacheStreams(managers)
+
+	for _, c := range cacheNames {
+		for _, m := range managers {
+			cacheID, err1 := m.ensureCache(context.Background(), c)
+			if err1 != nil {
+				return err1
+			}
+			s.debugConnection("re-ensureCache cacheId=%v for cache=%v", *cacheID, c)
+		}
+	}
+
+	return nil
+}
+
+// reconnectQueueStream re-creates the queue stream manager if the current stream has been
+// disconnected and re-ensures all the queues.
+func (s *Session) reconnectQueueStream() error {
+	s.connectMutex.Lock()
+	defer s.connectMutex.Unlock()
+
+	if s.v1StreamManagerQueue == nil || !s.v1StreamManagerQueue.isDisconnected() {
+		return nil
+	}
+
+	manager, err := newStreamManagerV1(s, queueServiceProtocol)
+	if err != nil {
+		return err
+	}
+	s.v1StreamManagerQueue = manager
+	s.metrics.streamReconnected()
+
+	s.mapMutex.RLock()
+	queues := make(map[string]NamedQueueType, len(s.queues))
+	for name, q := range s.queues {
+		if typed, ok := q.(interface{ GetType() NamedQueueType }); ok {
+			queues[name] = typed.GetType()
+		}
+	}
+	s.mapMutex.RUnlock()
+
+	for name, queueType := range queues {
+		queueID, err1 := manager.ensureQueue(context.Background(), name, queueType)
+		if err1 != nil {
+			return err1
+		}
+		s.debugConnection("re-ensureQueue queueId=%v for queue=%v", *queueID, name)
+	}
+
+	return nil
+}
+
+// addCacheStreams creates additional cache streams, up to the configured stream count, returning
+// all the streams with the primary stream first. If a stream cannot be created a warning is logged
+// and fewer streams are used.
+func (s *Session) addCacheStreams(primary *streamManagerV1) []*streamManagerV1 {
+	managers := []*streamManagerV1{primary}
+
+	for i := 1; i < s.sessOpts.StreamCount; i++ {
+		manager, err := newStreamManagerV1WithCacheIDs(s, cacheServiceProtocol, newSafeIDMap())
+		if err != nil {
+			s.log(WARNING, "Session [%s] unable to create cache stream %d of %d, using %d streams: %v",
+				s.sessionID, i+1, s.sessOpts.StreamCount, len(managers), err)
+			break
+		}
+		managers = append(managers, manager)
+	}
+
+	return managers
+}
+
+// setCacheStreams sets the current cache streams, the first being the primary stream.
+func (s *Session) setCacheStreams(managers []*streamManagerV1) {
+	s.streamsMutex.Lock()
+	defer s.streamsMutex.Unlock()
+
+	s.v1StreamManagerCache = managers[0]
+	s.v1StreamManagersCache = managers
+}
+
+// primaryCacheStream returns the current primary cache stream, which is replaced when the session reconnects.
+func (s *Session) primaryCacheStream() *streamManagerV1 {
+	s.streamsMutex.RLock()
+	defer s.streamsMutex.RUnlock()
+
+	return s.v1StreamManagerCache
+}
+
+// getCacheStreams returns the current cache streams.
+func (s *Session) getCacheStreams() []*streamManagerV1 {
+	s.streamsMutex.RLock()
+	defer s.streamsMutex.RUnlock()
+
+	return s.v1StreamManagersCache
+}
+
+// ensureCacheStreams ensures the cache on all the cache streams, returning the cache id from the primary stream.
+func (s *Session) ensureCacheStreams(ctx context.Context, cache string) (*int32, error) {
+	var primaryID *int32
+
+	for i, m := range s.getCacheStreams() {
+		cacheID, err := m.ensureCache(ctx, cache)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			primaryID = cacheID
+		}
+	}
+
+	return primaryID, nil
+}
+
+// cacheStream returns the next connected cache stream, in round-robin order, on which the cache has been ensured.
+func (s *Session) cacheStream(cache string) *streamManagerV1 {
+	managers := s.getCacheStreams()
+	count := uint32(len(managers))
+	if count <= 1 {
+		return s.primaryCacheStream()
+	}
+
+	start := s.nextStream.Add(1)
+	for i := uint32(0); i < count; i++ {
+		m := managers[(start+i)%count]
+		if !m.isDisconnected() && m.cacheIDMap.Get(cache) != nil {
+			return m
+		}
+	}
+
+	return managers[0]
+}
+
+// cacheListenerStream returns the cache stream used for all listener registrations, events and other
+// requests for the cache that must always use the same stream.
+func (s *Session) cacheListenerStream(cache string) *streamManagerV1 {
+	managers := s.getCacheStreams()
+	count := uint32(len(managers))
+	if count <= 1 {
+		return s.primaryCacheStream()
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(cache))
+	if m := managers[h.Sum32()%count]; m.cacheIDMap.Get(cache) != nil {
+		return m
+	}
+
+	return managers[0]
+}
+
+// anyDisconnected returns true if any of the streams have been disconnected.
+func anyDisconnected(managers []*streamManagerV1) bool {
+	for _, m := range managers {
+		if m.isDisconnected() {
+			return true
+		}
+	}
+	return false
+}
+
 // GetProtocolVersion returns the protocol version used by the server.
 func (s *Session) GetProtocolVersion() int32 {
-	if s.v1StreamManagerCache == nil {
+	m := s.primaryCacheStream()
+	if m == nil {
 		return 0
 	}
-	return s.v1StreamManagerCache.serverProtocolVersion
+	return m.serverInfo.ProtocolVersion
+}
+
+// ServerInfo describes the gRPC proxy a [Session] is connected to, as returned by the proxy when
+// the gRPC v1 stream was initialized.
+type ServerInfo struct {
+	// Version is the Coherence version of the proxy.
+	Version string
+
+	// EncodedVersion is the encoded Coherence version of the proxy.
+	EncodedVersion int32
+
+	// ProtocolVersion is the gRPC v1 protocol version in use.
+	ProtocolVersion int32
+
+	// ProxyMemberID is the cluster member id of the proxy.
+	ProxyMemberID int32
+
+	// ProxyMemberUUID is the cluster member UUID of the proxy.
+	ProxyMemberUUID []byte
+
+	// ClientUUID is the UUID the proxy assigned to this client connection.
+	ClientUUID []byte
+}
+
+// String returns a string representation of a ServerInfo.
+func (si ServerInfo) String() string {
+	return fmt.Sprintf("ServerInfo{version=%s, encodedVersion=%d, protocolVersion=%d, proxyMemberId=%d, proxyMemberUuid=%x, clientUuid=%x}",
+		si.Version, si.EncodedVersion, si.ProtocolVersion, si.ProxyMemberID, si.ProxyMemberUUID, si.ClientUUID)
+}
+
+// ServerInfo returns the [ServerInfo] for the gRPC proxy the [Session] is currently connected to.
+// [ErrNotSupported] is returned if the proxy does not support gRPC v1.
+func (s *Session) ServerInfo() (ServerInfo, error) {
+	if s.IsClosed() {
+		return ServerInfo{}, ErrClosed
+	}
+
+	manager := s.primaryCacheStream()
+	if manager == nil {
+		return ServerInfo{}, ErrNotSupported
+	}
+	return manager.serverInfo, nil
+}
+
+// Ping sends a heartbeat to the gRPC proxy the [Session] is connected to, and waits for it to be acknowledged,
+// returning the round-trip latency. If the context does not contain a deadline the session request timeout is
+// applied. [ErrNotSupported] is returned if the proxy does not support gRPC v1.
+func (s *Session) Ping(ctx context.Context) (time.Duration, error) {
+	if s.IsClosed() {
+		return 0, ErrClosed
+	}
+
+	if err := s.ensureConnection(); err != nil {
+		return 0, err
+	}
+
+	manager := s.primaryCacheStream()
+	if manager == nil {
+		return 0, ErrNotSupported
+	}
+
+	newCtx, cancel := s.ensureContext(ctx)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	return manager.ping(newCtx)
 }
 
 // waitForReady waits until the connection is ready up to the ready session timeout and will
@@ -684,7 +1226,7 @@ func waitForReady(s *Session) error {
 		}
 
 		if !messageLogged {
-			logMessage(INFO, "Session [%s] State is %v, waiting until ready timeout of %v for valid connection",
+			s.log(INFO, "Session [%s] State is %v, waiting until ready timeout of %v for valid connection",
 				s.sessionID, state, readyTimeout)
 			messageLogged = true
 		}
@@ -774,7 +1316,7 @@ func GetNamedCache[K comparable, V any](session *Session, cacheName string, opti
 
 // IsClosed returns true if the Session is closed. Returns false otherwise.
 func (s *Session) IsClosed() bool {
-	return s.closed
+	return s.closed.Load()
 }
 
 // IsPlainText returns true if plain text, e.g. Non TLS. Returns false otherwise.
@@ -782,38 +1324,32 @@ func (s *SessionOptions) IsPlainText() bool {
 	return s.PlainText
 }
 
-func (s *SessionOptions) createTLSOption() (grpc.DialOption, error) {
-	if s.PlainText {
+func (s *Session) createTLSOption() (grpc.DialOption, error) {
+	opts := s.sessOpts
+	if opts.PlainText {
 		return grpc.WithTransportCredentials(insecure.NewCredentials()), nil
 	}
 
 	// check if a tls.Config has been set and use this, otherwise continue to check for env and other options
-	if s.TlSConfig != nil {
-		if s.TlSConfig.InsecureSkipVerify {
-			logMessage(WARNING, insecureWarning)
+	if opts.TlSConfig != nil {
+		if opts.TlSConfig.InsecureSkipVerify {
+			s.log(WARNING, insecureWarning)
 		}
-		return grpc.WithTransportCredentials(credentials.NewTLS(s.TlSConfig)), nil
+		return grpc.WithTransportCredentials(credentials.NewTLS(opts.TlSConfig)), nil
 	}
 
-	var (
-		err          error
-		cp           *x509.CertPool
-		certData     []byte
-		certificates = make([]tls.Certificate, 0)
-	)
-
 	// check whether to ignore invalid certs, check env then option
 	ignoreInvalidCertsEnv := getStringValueFromEnvVarOrDefault(envIgnoreInvalidCerts, "")
 	if ignoreInvalidCertsEnv == "" {
 		// get value from options
-		ignoreInvalidCertsEnv = fmt.Sprintf("%v", s.IgnoreInvalidCerts)
+		ignoreInvalidCertsEnv = fmt.Sprintf("%v", opts.IgnoreInvalidCerts)
 	}
 
 	ignoreInvalidCerts := ignoreInvalidCertsEnv == "true"
 	if ignoreInvalidCerts {
-		logMessage(WARNING, insecureWarning)
+		s.log(WARNING, insecureWarning)
 	}
-	s.IgnoreInvalidCerts = ignoreInvalidCerts
+	opts.IgnoreInvalidCerts = ignoreInvalidCerts
 
 	// search TLS options in ENV first
 	certPathEnv := getStringValueFromEnvVarOrDefault(envTLSCertPath, "")
@@ -822,28 +1358,47 @@ func (s *SessionOptions) createTLSOption() (grpc.DialOption, error) {
 
 	// If the env options are empty then populate them from the options
 	if certPathEnv == "" {
-		certPathEnv = s.CaCertPath
+		certPathEnv = opts.CaCertPath
 	}
 	if clientCertEnv == "" {
-		clientCertEnv = s.ClientCertPath
+		clientCertEnv = opts.ClientCertPath
 	}
 	if clientCertKeyEnv == "" {
-		clientCertKeyEnv = s.ClientKeyPath
+		clientCertKeyEnv = opts.ClientKeyPath
+	}
+
+	opts.CaCertPath = certPathEnv
+	opts.ClientKeyPath = clientCertKeyEnv
+	opts.ClientCertPath = clientCertEnv
+
+	config, err := s.loadTLSConfig()
+	if err != nil {
+		return nil, err
 	}
 
-	s.CaCertPath = certPathEnv
-	s.ClientKeyPath = clientCertKeyEnv
-	s.ClientCertPath = clientCertEnv
+	return grpc.WithTransportCredentials(credentials.NewTLS(config)), nil
+}
 
-	if s.CaCertPath != "" {
+// loadTLSConfig loads the CA certificate and client certificate and key from the configured paths
+// and returns the [tls.Config] to use for the connection.
+func (s *Session) loadTLSConfig() (*tls.Config, error) {
+	var (
+		opts         = s.sessOpts
+		err          error
+		cp           *x509.CertPool
+		certData     []byte
+		certificates = make([]tls.Certificate, 0)
+	)
+
+	if opts.CaCertPath != "" {
 		cp = x509.NewCertPool()
 
-		logMessage(DEBUG, "loading CA certificate")
-		if err = validateFilePath(s.CaCertPath); err != nil {
+		s.log(DEBUG, "loading CA certificate")
+		if err = validateFilePath(opts.CaCertPath); err != nil {
 			return nil, err
 		}
 
-		certData, err = os.ReadFile(s.CaCertPath)
+		certData, err = os.ReadFile(opts.CaCertPath)
 		if err != nil {
 			return nil, err
 		}
@@ -853,29 +1408,27 @@ func (s *SessionOptions) createTLSOption() (grpc.DialOption, error) {
 		}
 	}
 
-	if s.ClientCertPath != "" && s.ClientKeyPath != "" {
-		logMessage(DEBUG, "loading client certificate and key paths, cert=%s, key=%s", s.ClientCertPath, s.ClientKeyPath)
-		if err = validateFilePath(s.ClientCertPath); err != nil {
+	if opts.ClientCertPath != "" && opts.ClientKeyPath != "" {
+		s.log(DEBUG, "loading client certificate and key paths, cert=%s, key=%s", opts.ClientCertPath, opts.ClientKeyPath)
+		if err = validateFilePath(opts.ClientCertPath); err != nil {
 			return nil, err
 		}
-		if err = validateFilePath(s.ClientKeyPath); err != nil {
+		if err = validateFilePath(opts.ClientKeyPath); err != nil {
 			return nil, err
 		}
 		var clientCert tls.Certificate
-		clientCert, err = tls.LoadX509KeyPair(s.ClientCertPath, s.ClientKeyPath)
+		clientCert, err = tls.LoadX509KeyPair(opts.ClientCertPath, opts.ClientKeyPath)
 		if err != nil {
 			return nil, err
 		}
 		certificates = []tls.Certificate{clientCert}
 	}
 
-	config := &tls.Config{
-		InsecureSkipVerify: ignoreInvalidCerts, //nolint
+	return &tls.Config{
+		InsecureSkipVerify: opts.IgnoreInvalidCerts, //nolint
 		RootCAs:            cp,
 		Certificates:       certificates,
-	}
-
-	return grpc.WithTransportCredentials(credentials.NewTLS(config)), nil
+	}, nil
 }
 
 // validateFilePath checks to see if a file path is valid.
@@ -893,6 +1446,26 @@ func (s *SessionOptions) String() string {
 	sb.WriteString(fmt.Sprintf("SessionOptions{address=%v, plainText=%v, scope=%v, format=%v, requestTimeout=%v, disconnectTimeout=%v, readyTimeout=%v",
 		s.Address, s.PlainText, s.Scope, s.Format, s.RequestTimeout, s.DisconnectTimeout, s.ReadyTimeout))
 
+	if s.ReconnectPolicy != nil {
+		sb.WriteString(fmt.Sprintf(", reconnectPolicy=%v", s.ReconnectPolicy))
+	}
+
+	if s.StreamCount > 1 {
+		sb.WriteString(fmt.Sprintf(", streamCount=%v", s.StreamCount))
+	}
+
+	if s.HeartbeatInterval > 0 {
+		sb.WriteString(fmt.Sprintf(", heartbeatInterval=%v, heartbeatMissedLimit=%v", s.HeartbeatInterval, s.HeartbeatMissedLimit))
+	}
+
+	if s.FaultInjector != nil {
+		sb.WriteString(", faultInjector=set")
+	}
+
+	if s.Capture != nil {
+		sb.WriteString(", capture=set")
+	}
+
 	if !s.PlainText {
 		if s.TlSConfig == nil {
 			sb.WriteString(fmt.Sprintf(" clientCertPath=%v, clientKeyPath=%v, caCertPath=%v, igoreInvalidCerts=%v",
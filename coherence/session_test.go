@@ -8,11 +8,34 @@ package coherence
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"testing"
 	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/testing/fakeproxy"
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
 )
 
+// newTestSession returns a Session, which is not connected, for unit tests of the session internals.
+// The options are applied to its SessionOptions and metrics are always collected.
+func newTestSession(options ...func(sessionOptions *SessionOptions)) *Session {
+	sessOpts := &SessionOptions{}
+	for _, f := range options {
+		f(sessOpts)
+	}
+
+	return &Session{
+		debug:           func(string, ...any) {},
+		debugConnection: func(string, ...any) {},
+		sessOpts:        sessOpts,
+		metrics:         newSessionMetrics(),
+		maps:            make(map[string]interface{}),
+		caches:          make(map[string]interface{}),
+		queues:          make(map[string]interface{}),
+	}
+}
+
 func TestSessionValidation(t *testing.T) {
 	ctx := context.Background()
 
@@ -136,3 +159,381 @@ func TestSessionTimeout(t *testing.T) {
 		t.Fatal("expected error due to invalid request timeout, got nil")
 	}
 }
+
+func TestSessionHeartbeatValidation(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewSession(ctx, WithHeartbeat(-1*time.Second, 3))
+	if err != ErrInvalidHeartbeat {
+		t.Fatalf("expected ErrInvalidHeartbeat, got %v", err)
+	}
+
+	_, err = NewSession(ctx, WithHeartbeat(time.Second, 0))
+	if err != ErrInvalidHeartbeat {
+		t.Fatalf("expected ErrInvalidHeartbeat, got %v", err)
+	}
+
+	s, err := NewSession(ctx, WithHeartbeat(5*time.Second, 3))
+	if err != nil {
+		t.Fatalf("unexpected error creating session: %v", err)
+	}
+	if s.sessOpts.HeartbeatInterval != 5*time.Second || s.sessOpts.HeartbeatMissedLimit != 3 {
+		t.Fatalf("expected heartbeat 5s/3, got %v/%v", s.sessOpts.HeartbeatInterval, s.sessOpts.HeartbeatMissedLimit)
+	}
+}
+
+func TestHeartbeatRequests(t *testing.T) {
+	m := &streamManagerV1{session: &Session{sessOpts: &SessionOptions{}}}
+
+	if hb := m.newInitRequest().GetInit().Heartbeat; hb != nil {
+		t.Fatalf("expected no heartbeat in init request, got %v", *hb)
+	}
+
+	m.session.sessOpts.HeartbeatInterval = 2500 * time.Millisecond
+	hb := m.newInitRequest().GetInit().Heartbeat
+	if hb == nil || *hb != 2500 {
+		t.Fatalf("expected heartbeat of 2500 millis in init request, got %v", hb)
+	}
+
+	req := m.newHeartbeatRequest(0)
+	if req.GetId() != 0 {
+		t.Fatalf("expected periodic heartbeat request to have id 0, got %v", req.GetId())
+	}
+	if req = m.newHeartbeatRequest(5); req.GetId() != 5 {
+		t.Fatalf("expected ping heartbeat request to have id 5, got %v", req.GetId())
+	}
+	if req.GetHeartbeat() == nil || !req.GetHeartbeat().Ack {
+		t.Fatalf("expected heartbeat request with ack, got %v", req)
+	}
+}
+
+func TestMissedHeartbeatsReconnect(t *testing.T) {
+	ctx := context.Background()
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	session, err := NewSession(ctx, WithAddress(address), WithPlainText(), WithRequestTimeout(5*time.Second),
+		WithHeartbeat(100*time.Millisecond, 2))
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	disconnected, reconnected := make(chan struct{}, 10), make(chan struct{}, 10)
+	session.AddSessionLifecycleListener(NewSessionLifecycleListener().
+		OnDisconnected(func(SessionLifecycleEvent) { disconnected <- struct{}{} }).
+		OnReconnected(func(SessionLifecycleEvent) { reconnected <- struct{}{} }))
+
+	namedMap, err := GetNamedMap[int, string](session, "heartbeats")
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+	if _, err = namedMap.Put(ctx, 1, "one"); err != nil {
+		t.Fatalf("unable to put: %v", err)
+	}
+	stream := session.cacheStream("heartbeats")
+
+	// the stream is cancelled once heartbeats have not been acknowledged for the missed limit
+	proxy.SuspendHeartbeats(true)
+	select {
+	case <-disconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the stream to be disconnected after missed heartbeats")
+	}
+	proxy.SuspendHeartbeats(false)
+
+	select {
+	case <-reconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the stream to be reconnected")
+	}
+	if session.cacheStream("heartbeats") == stream {
+		t.Fatalf("expected a new stream after reconnecting")
+	}
+
+	value, err := namedMap.Get(ctx, 1)
+	if err != nil || value == nil || *value != "one" {
+		t.Fatalf("expected one after reconnecting, got %v, %v", value, err)
+	}
+}
+
+// blockingStream is a stream whose sends block until the stream is cancelled, as if flow control
+// was preventing requests being sent to a peer which has silently died.
+type blockingStream struct {
+	pb1.ProxyService_SubChannelClient
+	ctx context.Context
+}
+
+func (s blockingStream) Send(*pb1.ProxyRequest) error {
+	<-s.ctx.Done()
+	return s.ctx.Err()
+}
+
+func TestMissedHeartbeatsBlockedSend(t *testing.T) {
+	session := newTestSession(WithHeartbeat(20*time.Millisecond, 2))
+	m := &streamManagerV1{session: session, requests: make(map[int64]proxyRequestChannel)}
+	m.recordResponse()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &eventStreamV1{grpcStream: blockingStream{ctx: ctx}, cancel: cancel, done: make(chan struct{})}
+	m.eventStream = stream
+	m.monitorStream(stream)
+
+	// the first heartbeat blocks, but the stream is still cancelled once the missed limit is reached
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the stream to be cancelled while a heartbeat send was blocked")
+	}
+
+	session.closed.Store(true)
+	close(stream.done)
+}
+
+func TestReconnectPolicyValidation(t *testing.T) {
+	ctx := context.Background()
+
+	invalid := []ReconnectPolicy{
+		{InitialBackoff: -1},
+		{MaxAttempts: -1},
+		{MaxElapsed: -1},
+		{Jitter: 1.5},
+		{Multiplier: 0.5},
+		{InitialBackoff: 5 * time.Second, MaxBackoff: time.Second},
+	}
+
+	for _, p := range invalid {
+		if _, err := NewSession(ctx, WithReconnectPolicy(p)); err != ErrInvalidReconnectPolicy {
+			t.Fatalf("expected ErrInvalidReconnectPolicy for %v, got %v", p, err)
+		}
+	}
+
+	s, err := NewSession(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error creating session: %v", err)
+	}
+	if *s.sessOpts.ReconnectPolicy != DefaultReconnectPolicy() {
+		t.Fatalf("expected default reconnect policy, got %v", s.sessOpts.ReconnectPolicy)
+	}
+
+	s, err = NewSession(ctx, WithReconnectPolicy(ReconnectPolicy{MaxAttempts: 5}))
+	if err != nil {
+		t.Fatalf("unexpected error creating session: %v", err)
+	}
+	if p := s.sessOpts.ReconnectPolicy; p.MaxAttempts != 5 || p.InitialBackoff != defaultReconnectInitialBackoff || p.Multiplier != defaultReconnectMultiplier {
+		t.Fatalf("expected defaults to be applied to reconnect policy, got %v", p)
+	}
+}
+
+func TestReconnectPolicyBackoff(t *testing.T) {
+	p := ReconnectPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2, MaxAttempts: 3}
+
+	expected := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, e := range expected {
+		if got := p.backoff(i + 1); got != e*time.Millisecond {
+			t.Fatalf("expected backoff for attempt %d to be %v, got %v", i+1, e*time.Millisecond, got)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 1; i < 100; i++ {
+		if got := p.backoff(1); got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("expected backoff with jitter to be between 50ms and 150ms, got %v", got)
+		}
+	}
+
+	if p.exhausted(3, 0, time.Second) {
+		t.Fatalf("expected attempt 3 to be allowed")
+	}
+	if !p.exhausted(4, 0, time.Second) {
+		t.Fatalf("expected attempt 4 to exceed max attempts")
+	}
+	if !p.exhausted(2, 2*time.Second, time.Second) {
+		t.Fatalf("expected disconnect timeout to be used when max elapsed not set")
+	}
+	p.MaxElapsed = 5 * time.Second
+	if p.exhausted(2, 2*time.Second, time.Second) {
+		t.Fatalf("expected max elapsed to override disconnect timeout")
+	}
+}
+
+func TestReconnectAttemptEvents(t *testing.T) {
+	s := &Session{sessOpts: &SessionOptions{DisconnectTimeout: time.Minute,
+		ReconnectPolicy: &ReconnectPolicy{InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1, MaxAttempts: 3}},
+		debug: func(string, ...any) {}}
+
+	var events []ReconnectAttemptEvent
+	listener := NewSessionLifecycleListener().OnReconnectAttempt(func(e SessionLifecycleEvent) {
+		events = append(events, e.(ReconnectAttemptEvent))
+	})
+	s.lifecycleListeners = []*SessionLifecycleListener{&listener}
+
+	attemptErr := errors.New("connection refused")
+	err := s.reconnect("test", func() error { return attemptErr })
+	if !errors.Is(err, ErrReconnectFailed) {
+		t.Fatalf("expected ErrReconnectFailed, got %v", err)
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("expected 3 reconnect attempt events, got %d", len(events))
+	}
+	for i, e := range events {
+		if e.Type() != ReconnectAttempt || e.Attempt() != i+1 {
+			t.Fatalf("unexpected event %v", e)
+		}
+		if (i == 0 && e.LastError() != nil) || (i > 0 && e.LastError() != attemptErr) {
+			t.Fatalf("unexpected last error for event %v", e)
+		}
+	}
+}
+
+func TestServerInfo(t *testing.T) {
+	s := &Session{sessOpts: &SessionOptions{}}
+
+	if _, err := s.ServerInfo(); err != ErrNotSupported {
+		t.Fatalf("expected ErrNotSupported for a v0 session, got %v", err)
+	}
+
+	m := &streamManagerV1{session: s}
+	m.setServerInfo(&pb1.InitResponse{Version: "25.03", EncodedVersion: 2503, ProtocolVersion: 1,
+		ProxyMemberId: 3, ProxyMemberUuid: []byte{1, 2}, Uuid: []byte{3, 4}})
+	s.v1StreamManagerCache = m
+
+	info, err := s.ServerInfo()
+	if err != nil {
+		t.Fatalf("unexpected error getting server info: %v", err)
+	}
+	if info.Version != "25.03" || info.EncodedVersion != 2503 || info.ProtocolVersion != 1 || info.ProxyMemberID != 3 ||
+		string(info.ProxyMemberUUID) != string([]byte{1, 2}) || string(info.ClientUUID) != string([]byte{3, 4}) {
+		t.Fatalf("unexpected server info %v", info)
+	}
+	if s.GetProtocolVersion() != 1 {
+		t.Fatalf("expected protocol version 1, got %v", s.GetProtocolVersion())
+	}
+}
+
+func TestPingWithHeartbeats(t *testing.T) {
+	ctx := context.Background()
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	// periodic heartbeats are acknowledged while each ping waits for its own acknowledgement
+	const delay = 300 * time.Millisecond
+	proxy.DelayHeartbeats(delay)
+	session, err := NewSession(ctx, WithAddress(address), WithPlainText(), WithRequestTimeout(5*time.Second),
+		WithHeartbeat(20*time.Millisecond, 100))
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	time.Sleep(2 * delay)
+	latency, err := session.Ping(ctx)
+	if err != nil {
+		t.Fatalf("unable to ping: %v", err)
+	}
+	if latency < delay {
+		t.Fatalf("expected a latency of at least %v, got %v", delay, latency)
+	}
+
+	// the late acknowledgement of a ping which timed out does not acknowledge the next ping
+	timeoutCtx, cancel := context.WithTimeout(ctx, delay/4)
+	defer cancel()
+	if _, err = session.Ping(timeoutCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the ping to time out, got %v", err)
+	}
+	if latency, err = session.Ping(ctx); err != nil || latency < delay {
+		t.Fatalf("expected a latency of at least %v, got %v, %v", delay, latency, err)
+	}
+
+	manager := session.primaryCacheStream()
+	manager.pingMutex.Lock()
+	defer manager.pingMutex.Unlock()
+	if len(manager.pings) != 0 {
+		t.Fatalf("expected no outstanding pings, got %v", manager.pings)
+	}
+}
+
+func TestSessionStreamCountValidation(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := NewSession(ctx, WithStreamCount(-1)); err != ErrInvalidStreamCount {
+		t.Fatalf("expected ErrInvalidStreamCount, got %v", err)
+	}
+
+	s, err := NewSession(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error creating session: %v", err)
+	}
+	if s.sessOpts.StreamCount != 1 {
+		t.Fatalf("expected default stream count of 1, got %v", s.sessOpts.StreamCount)
+	}
+
+	s, err = NewSession(ctx, WithStreamCount(4))
+	if err != nil {
+		t.Fatalf("unexpected error creating session: %v", err)
+	}
+	if s.sessOpts.StreamCount != 4 {
+		t.Fatalf("expected stream count of 4, got %v", s.sessOpts.StreamCount)
+	}
+}
+
+func TestCacheStreamSelection(t *testing.T) {
+	const cache = "my-cache"
+
+	s := &Session{sessOpts: &SessionOptions{}, cacheIDMap: newSafeIDMap()}
+	managers := make([]*streamManagerV1, 3)
+	for i := range managers {
+		managers[i] = &streamManagerV1{session: s, cacheIDMap: newSafeIDMap(), eventStream: &eventStreamV1{}}
+		managers[i].cacheIDMap.Add(cache, int32(i+10))
+	}
+	managers[0].cacheIDMap = s.cacheIDMap
+	s.cacheIDMap.Add(cache, 10)
+	s.setCacheStreams(managers)
+
+	// requests should be spread across all streams
+	used := make(map[*streamManagerV1]int)
+	for i := 0; i < 6; i++ {
+		used[s.cacheStream(cache)]++
+	}
+	if len(used) != 3 {
+		t.Fatalf("expected requests to be spread across 3 streams, used %d", len(used))
+	}
+
+	// disconnected streams should not be used
+	managers[1].disconnected.Store(true)
+	for i := 0; i < 6; i++ {
+		if s.cacheStream(cache) == managers[1] {
+			t.Fatalf("expected disconnected stream not to be used")
+		}
+	}
+
+	// the listener stream should always be the same
+	listenerStream := s.cacheListenerStream(cache)
+	for i := 0; i < 6; i++ {
+		if s.cacheListenerStream(cache) != listenerStream {
+			t.Fatalf("expected the listener stream to be the same for each call")
+		}
+	}
+
+	// removing the cache id should remove it from all streams
+	s.removeCacheID(cache)
+	for i, m := range managers {
+		if m.cacheIDMap.Get(cache) != nil {
+			t.Fatalf("expected cache id to be removed from stream %d", i)
+		}
+	}
+	if s.cacheStream(cache) != managers[0] || s.cacheListenerStream(cache) != managers[0] {
+		t.Fatalf("expected the primary stream to be used when the cache has not been ensured")
+	}
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// shutdownMap is implemented by the NamedMap and NamedCache clients held by a session.
+type shutdownMap interface {
+	removeAllListeners(ctx context.Context) error
+	Release()
+}
+
+// Shutdown gracefully closes the session. New requests are rejected with [ErrShuttingDown] and Shutdown
+// waits for in-flight requests and streaming queries to complete, or for the context to be done. All
+// [MapListener]s are then unsubscribed, all maps, caches and queues are released and the session is closed.
+//
+// If the context is done before the in-flight requests complete, the session is still closed and the context
+// error is returned. Calling Shutdown on a closed session has no effect.
+//
+// The example below shows how to wait up to 30 seconds for in-flight requests before closing a session.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//
+//	if err := session.Shutdown(ctx); err != nil {
+//	    log.Println("session closed before all requests completed:", err)
+//	}
+func (s *Session) Shutdown(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if s.closed.Load() || !s.shuttingDown.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	s.log(INFO, "Session [%s] shutting down, waiting for %d in-flight requests", s.sessionID, s.inFlight.Load())
+
+	err := s.waitForInFlight(ctx)
+	if err != nil {
+		s.log(WARNING, "Session [%s] closing with %d requests still in-flight: %v", s.sessionID, s.inFlight.Load(), err)
+	}
+
+	// listeners are unsubscribed even if the context is done, bounded by the request timeout
+	newCtx, cancel := s.ensureContext(context.WithoutCancel(ctx))
+	if cancel != nil {
+		defer cancel()
+	}
+
+	s.mapMutex.RLock()
+	maps := make([]interface{}, 0, len(s.maps)+len(s.caches))
+	for _, m := range s.maps {
+		maps = append(maps, m)
+	}
+	for _, c := range s.caches {
+		maps = append(maps, c)
+	}
+	queues := make([]interface{ Release() }, 0, len(s.queues))
+	for _, q := range s.queues {
+		if queue, ok := q.(interface{ Release() }); ok {
+			queues = append(queues, queue)
+		}
+	}
+	s.mapMutex.RUnlock()
+
+	for _, m := range maps {
+		if namedMap, ok := m.(shutdownMap); ok {
+			if err1 := namedMap.removeAllListeners(newCtx); err1 != nil {
+				s.log(WARNING, "Session [%s] unable to unsubscribe listeners: %v", s.sessionID, err1)
+			}
+			namedMap.Release()
+		}
+	}
+
+	for _, q := range queues {
+		q.Release()
+	}
+
+	s.Close()
+
+	return err
+}
+
+// waitForInFlight waits until there are no in-flight operations or the context is done.
+func (s *Session) waitForInFlight(ctx context.Context) error {
+	select {
+	case <-s.inFlight.idle():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// inFlightOperations counts the in-flight operations of a session and signals when there are none.
+type inFlightOperations struct {
+	mutex   sync.Mutex
+	count   int64
+	waiting chan struct{} // closed when the count drops to zero, set only while Shutdown is waiting
+}
+
+// Add adds delta, which may be negative, to the number of in-flight operations.
+func (o *inFlightOperations) Add(delta int64) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.count += delta
+	if o.count == 0 && o.waiting != nil {
+		close(o.waiting)
+		o.waiting = nil
+	}
+}
+
+// Load returns the number of in-flight operations.
+func (o *inFlightOperations) Load() int64 {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return o.count
+}
+
+// idle returns a channel which is closed once there are no in-flight operations.
+func (o *inFlightOperations) idle() <-chan struct{} {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.waiting == nil {
+		o.waiting = make(chan struct{})
+		if o.count == 0 {
+			close(o.waiting)
+			ch := o.waiting
+			o.waiting = nil
+			return ch
+		}
+	}
+	return o.waiting
+}
+
+// removeAllListeners unsubscribes all the [MapListener]s registered against the map or cache.
+func (bc *baseClient[K, V]) removeAllListeners(ctx context.Context) error {
+	var errs []error
+
+	if bc.getProtocolVersion() > 0 {
+		bc.mutex.Lock()
+		defer bc.mutex.Unlock()
+
+		for _, group := range bc.keyListenersV1 {
+			errs = append(errs, group.removeAll(ctx))
+		}
+		for _, group := range bc.filterListenersV1 {
+			errs = append(errs, group.removeAll(ctx))
+		}
+	} else if manager := bc.eventManager; manager != nil {
+		manager.mutex.Lock()
+		defer manager.mutex.Unlock()
+
+		for _, group := range manager.keyListeners {
+			errs = append(errs, group.removeAll(ctx))
+		}
+		for _, group := range manager.filterListeners {
+			errs = append(errs, group.removeAll(ctx))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// removeAll removes all the listeners from the group.
+func (lg *listenerGroupV1[K, V]) removeAll(ctx context.Context) error {
+	var errs []error
+	for _, listener := range lg.currentListeners() {
+		errs = append(errs, lg.removeListener(ctx, listener))
+	}
+	return errors.Join(errs...)
+}
+
+func (lg *listenerGroupV1[K, V]) currentListeners() []MapListener[K, V] {
+	lg.mutex.RLock()
+	defer lg.mutex.RUnlock()
+
+	listeners := make([]MapListener[K, V], 0, len(lg.listeners))
+	for listener := range lg.listeners {
+		listeners = append(listeners, listener)
+	}
+	return listeners
+}
+
+// removeAll removes all the listeners from the group.
+func (lg *listenerGroup[K, V]) removeAll(ctx context.Context) error {
+	var errs []error
+	for _, listener := range lg.currentListeners() {
+		errs = append(errs, lg.removeListener(ctx, listener))
+	}
+	return errors.Join(errs...)
+}
+
+func (lg *listenerGroup[K, V]) currentListeners() []MapListener[K, V] {
+	lg.mutex.RLock()
+	defer lg.mutex.RUnlock()
+
+	listeners := make([]MapListener[K, V], 0, len(lg.listeners))
+	for listener := range lg.listeners {
+		listeners = append(listeners, listener)
+	}
+	return listeners
+}
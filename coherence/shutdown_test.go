@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func newShutdownTestSession(t *testing.T) *Session {
+	conn, err := grpc.NewClient("passthrough:///localhost:1408", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("unable to create client: %v", err)
+	}
+
+	session := newTestSession(WithRequestTimeout(time.Second))
+	session.conn = conn
+	return session
+}
+
+func TestShutdownWaitsForInFlight(t *testing.T) {
+	session := newShutdownTestSession(t)
+
+	_, op := session.startOperation(context.Background(), "test", "Get", false)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Shutdown(context.Background())
+	}()
+
+	// wait for Shutdown to reject new requests before completing the in-flight one
+	for !session.shuttingDown.Load() {
+		time.Sleep(time.Millisecond)
+	}
+
+	bc := &baseClient[int, string]{session: session, name: "test"}
+	if err := bc.ensureClientConnection(); !errors.Is(err, ErrShuttingDown) {
+		t.Fatalf("expected ErrShuttingDown for a new map request, got %v", err)
+	}
+	bq := &baseQueueClient[string]{session: session, name: "queue"}
+	if err := offerInternal[string](context.Background(), bq, "value", pb1.NamedQueueRequestType_OfferTail); !errors.Is(err, ErrShuttingDown) {
+		t.Fatalf("expected ErrShuttingDown for a new queue request, got %v", err)
+	}
+
+	// Shutdown only completes once the in-flight request has ended
+	select {
+	case err := <-done:
+		t.Fatalf("expected Shutdown to wait for the in-flight request, got %v", err)
+	default:
+	}
+	op.end(nil)
+
+	if err := <-done; err != nil {
+		t.Fatalf("expected Shutdown to complete after the in-flight request, got %v", err)
+	}
+	if !session.IsClosed() {
+		t.Fatalf("expected session to be closed")
+	}
+
+	// a second Shutdown has no effect
+	if err := session.Shutdown(context.Background()); err != nil {
+		t.Fatalf("expected no error from a closed session, got %v", err)
+	}
+}
+
+func TestShutdownContextDone(t *testing.T) {
+	session := newShutdownTestSession(t)
+
+	_, op := session.startOperation(context.Background(), "test", "Get", false)
+	defer op.end(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := session.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if !session.IsClosed() {
+		t.Fatalf("expected session to be closed after the context was done")
+	}
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package fakeproxy
+
+import (
+	"github.com/oracle/coherence-go-client/v2/coherence/internal/evaluator"
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// invoke runs an entry processor against each of the entries, applying any updates, and returns
+// a BinaryKeyAndValue containing the serialized result for each entry.
+func (nc *namedCache) invoke(agentData []byte, entries []*evaluator.Entry) ([]proto.Message, error) {
+	agent, err := evaluator.ParseObject(agentData)
+	if err != nil {
+		return nil, err
+	}
+	if agent == nil {
+		return nil, evaluator.ErrMissingAgent
+	}
+
+	results := make([]proto.Message, 0, len(entries))
+	for _, e := range entries {
+		result, err := evaluator.Process(agent, e)
+		if err != nil {
+			return nil, err
+		}
+		if e.Changed() {
+			if e.Present {
+				nc.put(e.Key, e.Value, 0)
+			} else {
+				nc.remove(e.Key, false)
+			}
+		}
+		results = append(results, &pb1.BinaryKeyAndValue{Key: e.Key, Value: result})
+	}
+	return results, nil
+}
@@ -0,0 +1,551 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package fakeproxy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence/internal/evaluator"
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// map event ids
+const (
+	entryInserted = evaluator.EntryInserted
+	entryUpdated  = evaluator.EntryUpdated
+	entryDeleted  = evaluator.EntryDeleted
+)
+
+var errMissingMessage = errors.New("the request message is missing")
+
+// namedCache is an in-memory cache.
+type namedCache struct {
+	server    *Server
+	id        int32
+	name      string
+	entries   map[string]*entry
+	channels  map[*channel]struct{}
+	listeners map[*channel]*registrations
+}
+
+// entry is a cache entry, expiry is zero if the entry does not expire.
+type entry struct {
+	key    []byte
+	value  []byte
+	expiry time.Time
+}
+
+// registrations are the map listeners registered for a cache on a channel.
+type registrations struct {
+	keys    map[string]bool // the serialized key to whether the listener is lite
+	filters map[int64]*filterListener
+}
+
+// filterListener is a map listener registered with a filter.
+type filterListener struct {
+	filter evaluator.Object
+	lite   bool
+}
+
+// handleCacheMessage handles a NamedCacheRequest, the server mutex must be held.
+func (s *Server) handleCacheMessage(c *channel, id int64, message *anypb.Any) {
+	var request pb1.NamedCacheRequest
+	if err := message.UnmarshalTo(&request); err != nil {
+		_ = c.sendError(id, err)
+		return
+	}
+
+	cacheID, results, err := s.handleCacheRequest(c, &request)
+	if err != nil {
+		_ = c.sendError(id, err)
+		return
+	}
+
+	for _, result := range results {
+		if err = c.sendCacheResponse(id, cacheID, pb1.ResponseType_Message, result); err != nil {
+			return
+		}
+	}
+	_ = c.complete(id)
+}
+
+// handleCacheRequest handles a NamedCacheRequest and returns the cache id and the result messages.
+func (s *Server) handleCacheRequest(c *channel, request *pb1.NamedCacheRequest) (int32, []proto.Message, error) {
+	if request.GetType() == pb1.NamedCacheRequestType_EnsureCache {
+		var ensure pb1.EnsureCacheRequest
+		if err := unmarshal(request.GetMessage(), &ensure); err != nil {
+			return 0, nil, err
+		}
+		cache := s.ensureCache(ensure.GetCache())
+		cache.channels[c] = struct{}{}
+		return cache.id, []proto.Message{nil}, nil
+	}
+
+	cache := s.cacheByID(request.GetCacheId())
+	if cache == nil {
+		return 0, nil, fmt.Errorf("unknown cache id %d", request.GetCacheId())
+	}
+
+	cache.expire()
+
+	if request.GetType() == pb1.NamedCacheRequestType_Destroy {
+		delete(s.caches, cache.name)
+		cache.lifecycleEvent(pb1.ResponseType_Destroyed)
+		return cache.id, nil, nil
+	}
+
+	results, err := cache.handle(c, request.GetType(), request.GetMessage())
+	return cache.id, results, err
+}
+
+// ensureCache returns the cache with the name, creating it if it does not exist.
+func (s *Server) ensureCache(name string) *namedCache {
+	if cache, ok := s.caches[name]; ok {
+		return cache
+	}
+
+	cache := &namedCache{
+		server:    s,
+		id:        s.nextID(),
+		name:      name,
+		entries:   make(map[string]*entry),
+		channels:  make(map[*channel]struct{}),
+		listeners: make(map[*channel]*registrations),
+	}
+	s.caches[name] = cache
+	return cache
+}
+
+// cacheByID returns the cache with the id, or nil if it does not exist.
+func (s *Server) cacheByID(id int32) *namedCache {
+	for _, cache := range s.caches {
+		if cache.id == id {
+			return cache
+		}
+	}
+	return nil
+}
+
+// handle handles a request for the cache and returns the result messages.
+func (nc *namedCache) handle(c *channel, requestType pb1.NamedCacheRequestType, message *anypb.Any) ([]proto.Message, error) {
+	switch requestType {
+	case pb1.NamedCacheRequestType_Aggregate:
+		var request pb1.ExecuteRequest
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		entries, err := nc.selectEntries(request.GetKeys(), false)
+		if err != nil {
+			return nil, err
+		}
+		result, err := evaluator.Aggregate(request.GetAgent(), entries)
+		if err != nil {
+			return nil, err
+		}
+		return single(wrapperspb.Bytes(result))
+
+	case pb1.NamedCacheRequestType_Clear:
+		for _, e := range nc.sortedEntries() {
+			nc.remove(e.key, false)
+		}
+		return nil, nil
+
+	case pb1.NamedCacheRequestType_ContainsEntry:
+		var request pb1.BinaryKeyAndValue
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		e, ok := nc.entries[string(request.GetKey())]
+		return single(wrapperspb.Bool(ok && bytes.Equal(e.value, request.GetValue())))
+
+	case pb1.NamedCacheRequestType_ContainsKey:
+		var request wrapperspb.BytesValue
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		_, ok := nc.entries[string(request.GetValue())]
+		return single(wrapperspb.Bool(ok))
+
+	case pb1.NamedCacheRequestType_ContainsValue:
+		var request wrapperspb.BytesValue
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		found := false
+		for _, e := range nc.entries {
+			found = found || bytes.Equal(e.value, request.GetValue())
+		}
+		return single(wrapperspb.Bool(found))
+
+	case pb1.NamedCacheRequestType_IsEmpty:
+		return single(wrapperspb.Bool(len(nc.entries) == 0))
+
+	case pb1.NamedCacheRequestType_IsReady:
+		return single(wrapperspb.Bool(true))
+
+	case pb1.NamedCacheRequestType_Get:
+		var request wrapperspb.BytesValue
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		if e, ok := nc.entries[string(request.GetValue())]; ok {
+			return single(&pb1.OptionalValue{Present: true, Value: e.value})
+		}
+		return single(&pb1.OptionalValue{})
+
+	case pb1.NamedCacheRequestType_GetAll:
+		var request pb1.CollectionOfBytesValues
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		var results []proto.Message
+		for _, key := range request.GetValues() {
+			if e, ok := nc.entries[string(key)]; ok {
+				results = append(results, &pb1.BinaryKeyAndValue{Key: e.key, Value: e.value})
+			}
+		}
+		return results, nil
+
+	case pb1.NamedCacheRequestType_Index:
+		// indexes are not required as every query scans the entries
+		return nil, nil
+
+	case pb1.NamedCacheRequestType_Invoke:
+		var request pb1.ExecuteRequest
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		entries, err := nc.selectEntries(request.GetKeys(), true)
+		if err != nil {
+			return nil, err
+		}
+		return nc.invoke(request.GetAgent(), entries)
+
+	case pb1.NamedCacheRequestType_MapListener:
+		var request pb1.MapListenerRequest
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		return nil, nc.mapListener(c, &request)
+
+	case pb1.NamedCacheRequestType_PageOfEntries, pb1.NamedCacheRequestType_PageOfKeys:
+		// all the entries are returned in a single page, so the cookie is always empty
+		results := []proto.Message{&wrapperspb.BytesValue{}}
+		for _, e := range nc.sortedEntries() {
+			if requestType == pb1.NamedCacheRequestType_PageOfKeys {
+				results = append(results, wrapperspb.Bytes(e.key))
+			} else {
+				results = append(results, &pb1.BinaryKeyAndValue{Key: e.key, Value: e.value})
+			}
+		}
+		return results, nil
+
+	case pb1.NamedCacheRequestType_Put, pb1.NamedCacheRequestType_PutIfAbsent:
+		var request pb1.PutRequest
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		if e, ok := nc.entries[string(request.GetKey())]; ok && requestType == pb1.NamedCacheRequestType_PutIfAbsent {
+			return single(wrapperspb.Bytes(e.value))
+		}
+		return single(wrapperspb.Bytes(nc.put(request.GetKey(), request.GetValue(), request.GetTtl())))
+
+	case pb1.NamedCacheRequestType_PutAll:
+		var request pb1.PutAllRequest
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		for _, e := range request.GetEntries() {
+			nc.put(e.GetKey(), e.GetValue(), request.GetTtl())
+		}
+		return nil, nil
+
+	case pb1.NamedCacheRequestType_QueryEntries, pb1.NamedCacheRequestType_QueryKeys, pb1.NamedCacheRequestType_QueryValues:
+		var request pb1.QueryRequest
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		entries, err := nc.query(request.GetFilter(), request.GetComparator())
+		if err != nil {
+			return nil, err
+		}
+		results := make([]proto.Message, 0, len(entries))
+		for _, e := range entries {
+			switch requestType {
+			case pb1.NamedCacheRequestType_QueryKeys:
+				results = append(results, wrapperspb.Bytes(e.Key))
+			case pb1.NamedCacheRequestType_QueryValues:
+				results = append(results, wrapperspb.Bytes(e.Value))
+			default:
+				results = append(results, &pb1.BinaryKeyAndValue{Key: e.Key, Value: e.Value})
+			}
+		}
+		return results, nil
+
+	case pb1.NamedCacheRequestType_Remove:
+		var request wrapperspb.BytesValue
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		previous, _ := nc.remove(request.GetValue(), false)
+		return single(wrapperspb.Bytes(previous))
+
+	case pb1.NamedCacheRequestType_RemoveMapping:
+		var request pb1.BinaryKeyAndValue
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		e, ok := nc.entries[string(request.GetKey())]
+		removed := ok && bytes.Equal(e.value, request.GetValue())
+		if removed {
+			nc.remove(request.GetKey(), false)
+		}
+		return single(wrapperspb.Bool(removed))
+
+	case pb1.NamedCacheRequestType_Replace:
+		var request pb1.BinaryKeyAndValue
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		if _, ok := nc.entries[string(request.GetKey())]; !ok {
+			return single(&wrapperspb.BytesValue{})
+		}
+		return single(wrapperspb.Bytes(nc.put(request.GetKey(), request.GetValue(), 0)))
+
+	case pb1.NamedCacheRequestType_ReplaceMapping:
+		var request pb1.ReplaceMappingRequest
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		e, ok := nc.entries[string(request.GetKey())]
+		replaced := ok && bytes.Equal(e.value, request.GetPreviousValue())
+		if replaced {
+			nc.put(request.GetKey(), request.GetNewValue(), 0)
+		}
+		return single(wrapperspb.Bool(replaced))
+
+	case pb1.NamedCacheRequestType_Size:
+		return single(wrapperspb.Int32(int32(len(nc.entries))))
+
+	case pb1.NamedCacheRequestType_Truncate:
+		nc.entries = make(map[string]*entry)
+		nc.lifecycleEvent(pb1.ResponseType_Truncated)
+		return nil, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported cache request type %v", requestType)
+	}
+}
+
+// put puts a value, raising an insert or update event, and returns the previous value.
+func (nc *namedCache) put(key, value []byte, ttl int64) []byte {
+	e := &entry{key: key, value: value}
+	if ttl > 0 {
+		e.expiry = nc.server.now().Add(time.Duration(ttl) * time.Millisecond)
+	}
+
+	previous, ok := nc.entries[string(key)]
+	nc.entries[string(key)] = e
+
+	if ok {
+		nc.mapEvent(entryUpdated, key, previous.value, value, false)
+		return previous.value
+	}
+	nc.mapEvent(entryInserted, key, nil, value, false)
+	return nil
+}
+
+// remove removes an entry, raising a delete event, and returns the previous value.
+func (nc *namedCache) remove(key []byte, expired bool) ([]byte, bool) {
+	previous, ok := nc.entries[string(key)]
+	if !ok {
+		return nil, false
+	}
+
+	delete(nc.entries, string(key))
+	nc.mapEvent(entryDeleted, key, previous.value, nil, expired)
+	return previous.value, true
+}
+
+// expire removes the entries whose time to live has passed.
+func (nc *namedCache) expire() {
+	now := nc.server.now()
+	for _, e := range nc.sortedEntries() {
+		if !e.expiry.IsZero() && !now.Before(e.expiry) {
+			nc.remove(e.key, true)
+		}
+	}
+}
+
+// sortedEntries returns the entries sorted by serialized key, so that results are deterministic.
+func (nc *namedCache) sortedEntries() []*entry {
+	entries := make([]*entry, 0, len(nc.entries))
+	for _, e := range nc.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+	return entries
+}
+
+// query returns the entries which match the filter, sorted using the comparator if one is specified.
+func (nc *namedCache) query(filterData, comparatorData []byte) ([]*evaluator.Entry, error) {
+	filter, err := evaluator.ParseObject(filterData)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*evaluator.Entry
+	for _, e := range nc.sortedEntries() {
+		v := evaluator.NewEntry(e.key, e.value, true)
+		matched, err := evaluator.Evaluate(filter, v)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			results = append(results, v)
+		}
+	}
+
+	comparator, err := evaluator.ParseObject(comparatorData)
+	if err != nil {
+		return nil, err
+	}
+	return results, evaluator.Sort(results, comparator)
+}
+
+// selectEntries returns the entries for the keys or filter, including entries which are not present
+// for the requested keys if includeAbsent is true.
+func (nc *namedCache) selectEntries(keysOrFilter *pb1.KeysOrFilter, includeAbsent bool) ([]*evaluator.Entry, error) {
+	var keys [][]byte
+	switch k := keysOrFilter.GetKeyOrFilter().(type) {
+	case *pb1.KeysOrFilter_Key:
+		keys = [][]byte{k.Key}
+	case *pb1.KeysOrFilter_Keys:
+		keys = k.Keys.GetValues()
+	case *pb1.KeysOrFilter_Filter:
+		return nc.query(k.Filter, nil)
+	default:
+		return nc.query(nil, nil)
+	}
+
+	var results []*evaluator.Entry
+	for _, key := range keys {
+		if e, ok := nc.entries[string(key)]; ok {
+			results = append(results, evaluator.NewEntry(e.key, e.value, true))
+		} else if includeAbsent {
+			results = append(results, evaluator.NewEntry(key, nil, false))
+		}
+	}
+	return results, nil
+}
+
+// mapListener adds or removes a key or filter listener for the channel.
+func (nc *namedCache) mapListener(c *channel, request *pb1.MapListenerRequest) error {
+	regs, ok := nc.listeners[c]
+	if !ok {
+		regs = &registrations{keys: make(map[string]bool), filters: make(map[int64]*filterListener)}
+		nc.listeners[c] = regs
+	}
+
+	switch k := request.GetKeyOrFilter().GetKeyOrFilter().(type) {
+	case *pb1.KeyOrFilter_Key:
+		if !request.GetSubscribe() {
+			delete(regs.keys, string(k.Key))
+			return nil
+		}
+		regs.keys[string(k.Key)] = request.GetLite()
+		if e, ok := nc.entries[string(k.Key)]; ok && request.GetPriming() {
+			event := &pb1.MapEventMessage{Id: entryUpdated, Key: e.key, NewValue: e.value, Synthetic: true, Priming: true}
+			return c.sendCacheResponse(0, nc.id, pb1.ResponseType_MapEvent, event)
+		}
+	default:
+		if !request.GetSubscribe() {
+			delete(regs.filters, request.GetFilterId())
+			return nil
+		}
+		var filterData []byte
+		if f, ok := k.(*pb1.KeyOrFilter_Filter); ok {
+			filterData = f.Filter
+		}
+		filter, err := evaluator.ParseObject(filterData)
+		if err != nil {
+			return err
+		}
+		regs.filters[request.GetFilterId()] = &filterListener{filter: filter, lite: request.GetLite()}
+	}
+	return nil
+}
+
+// mapEvent sends a map event to the channels with a key listener for the key or a filter listener
+// whose filter matches the event. The values are omitted if all the matching listeners are lite.
+func (nc *namedCache) mapEvent(eventID int32, key, oldValue, newValue []byte, expired bool) {
+	oldEntry := evaluator.NewEntry(key, oldValue, oldValue != nil)
+	newEntry := evaluator.NewEntry(key, newValue, newValue != nil)
+
+	for c, regs := range nc.listeners {
+		lite, keyMatched := regs.keys[string(key)]
+		allLite := !keyMatched || lite
+
+		var filterIDs []int64
+		for id, l := range regs.filters {
+			if evaluator.MatchesEvent(l.filter, eventID, oldEntry, newEntry) {
+				filterIDs = append(filterIDs, id)
+				allLite = allLite && l.lite
+			}
+		}
+		if !keyMatched && len(filterIDs) == 0 {
+			continue
+		}
+		slices.Sort(filterIDs)
+
+		event := &pb1.MapEventMessage{Id: eventID, Key: key, FilterIds: filterIDs, Synthetic: expired, Expired: expired}
+		if !allLite {
+			event.OldValue = oldValue
+			event.NewValue = newValue
+		}
+		_ = c.sendCacheResponse(0, nc.id, pb1.ResponseType_MapEvent, event)
+	}
+}
+
+// lifecycleEvent sends a destroyed or truncated event to every channel the cache has been ensured on.
+func (nc *namedCache) lifecycleEvent(responseType pb1.ResponseType) {
+	for c := range nc.channels {
+		_ = c.sendCacheResponse(0, nc.id, responseType, nil)
+	}
+}
+
+// sendCacheResponse sends a NamedCacheResponse for a request, or an event if the id is zero.
+func (c *channel) sendCacheResponse(id int64, cacheID int32, responseType pb1.ResponseType, message proto.Message) error {
+	anyMessage, err := anyOf(message)
+	if err != nil {
+		return err
+	}
+	return c.sendMessage(id, &pb1.NamedCacheResponse{CacheId: cacheID, Type: responseType, Message: anyMessage})
+}
+
+// unmarshal unmarshals a request message.
+func unmarshal(message *anypb.Any, m proto.Message) error {
+	if message == nil {
+		return errMissingMessage
+	}
+	return message.UnmarshalTo(m)
+}
+
+// single returns a single result message.
+func single(message proto.Message) ([]proto.Message, error) {
+	return []proto.Message{message}, nil
+}
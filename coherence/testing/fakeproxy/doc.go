@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+/*
+Package fakeproxy provides an in-process fake of the Coherence gRPC v1 proxy, for use in tests which
+need a NamedMap, NamedCache or NamedQueue without running a Coherence cluster.
+
+The fake implements the ProxyService bidirectional protocol against in-memory caches and queues. It supports
+all the NamedCache request types, map events for key and filter listeners, and the NamedQueue request
+types. Filters, extractors and comparators are evaluated against values serialized using the "json" format,
+for the common filters such as equals, comparisons, in, contains, like, regex, is null and the logical
+filters. Aggregators are limited to count, distinct, min, max, sum and average, and entry processors to
+the extractor, conditional, composite, increment, multiply, update, touch and preload processors.
+
+Example:
+
+	proxy := fakeproxy.New()
+	address, err := proxy.Start()
+	if err != nil {
+	    t.Fatal(err)
+	}
+	defer proxy.Stop()
+
+	session, err := coherence.NewSession(ctx, coherence.WithAddress(address), coherence.WithPlainText())
+	if err != nil {
+	    t.Fatal(err)
+	}
+	defer session.Close()
+
+To use an in-memory connection rather than a localhost port, pass a listener such as a bufconn.Listener
+to [Server.Serve] and dial it using the listener's Dial function.
+*/
+package fakeproxy
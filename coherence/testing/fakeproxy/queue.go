@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package fakeproxy
+
+import (
+	"fmt"
+
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// namedQueue is an in-memory queue or double-ended queue.
+type namedQueue struct {
+	id        int32
+	name      string
+	queueType pb1.NamedQueueType
+	values    [][]byte
+	lastIndex int64
+	channels  map[*channel]struct{}
+}
+
+// handleQueueMessage handles a NamedQueueRequest, the server mutex must be held.
+func (s *Server) handleQueueMessage(c *channel, id int64, message *anypb.Any) {
+	var request pb1.NamedQueueRequest
+	if err := message.UnmarshalTo(&request); err != nil {
+		_ = c.sendError(id, err)
+		return
+	}
+
+	queueID, results, err := s.handleQueueRequest(c, &request)
+	if err != nil {
+		_ = c.sendError(id, err)
+		return
+	}
+
+	for _, result := range results {
+		if err = c.sendQueueResponse(id, queueID, pb1.NamedQueueResponseType_Message, result); err != nil {
+			return
+		}
+	}
+	_ = c.complete(id)
+}
+
+// handleQueueRequest handles a NamedQueueRequest and returns the queue id and the result messages.
+func (s *Server) handleQueueRequest(c *channel, request *pb1.NamedQueueRequest) (int32, []proto.Message, error) {
+	if request.GetType() == pb1.NamedQueueRequestType_EnsureQueue {
+		var ensure pb1.EnsureQueueRequest
+		if err := unmarshal(request.GetMessage(), &ensure); err != nil {
+			return 0, nil, err
+		}
+		queue, err := s.ensureQueue(ensure.GetQueue(), ensure.GetType())
+		if err != nil {
+			return 0, nil, err
+		}
+		queue.channels[c] = struct{}{}
+		return queue.id, []proto.Message{nil}, nil
+	}
+
+	queue := s.queueByID(request.GetQueueId())
+	if queue == nil {
+		return 0, nil, fmt.Errorf("unknown queue id %d", request.GetQueueId())
+	}
+
+	if request.GetType() == pb1.NamedQueueRequestType_Destroy {
+		delete(s.queues, queue.name)
+		for ch := range queue.channels {
+			_ = ch.sendQueueResponse(0, queue.id, pb1.NamedQueueResponseType_Destroyed, nil)
+		}
+		return queue.id, nil, nil
+	}
+
+	results, err := queue.handle(request.GetType(), request.GetMessage())
+	return queue.id, results, err
+}
+
+// ensureQueue returns the queue with the name, creating it if it does not exist.
+func (s *Server) ensureQueue(name string, queueType pb1.NamedQueueType) (*namedQueue, error) {
+	if queue, ok := s.queues[name]; ok {
+		if queue.queueType != queueType {
+			return nil, fmt.Errorf("queue %s already exists with type %v", name, queue.queueType)
+		}
+		return queue, nil
+	}
+
+	queue := &namedQueue{
+		id:        s.nextID(),
+		name:      name,
+		queueType: queueType,
+		channels:  make(map[*channel]struct{}),
+	}
+	s.queues[name] = queue
+	return queue, nil
+}
+
+// queueByID returns the queue with the id, or nil if it does not exist.
+func (s *Server) queueByID(id int32) *namedQueue {
+	for _, queue := range s.queues {
+		if queue.id == id {
+			return queue
+		}
+	}
+	return nil
+}
+
+// handle handles a request for the queue and returns the result messages.
+func (q *namedQueue) handle(requestType pb1.NamedQueueRequestType, message *anypb.Any) ([]proto.Message, error) {
+	switch requestType {
+	case pb1.NamedQueueRequestType_Clear:
+		q.values = nil
+		return nil, nil
+
+	case pb1.NamedQueueRequestType_IsEmpty:
+		return single(wrapperspb.Bool(len(q.values) == 0))
+
+	case pb1.NamedQueueRequestType_IsReady:
+		return single(wrapperspb.Bool(true))
+
+	case pb1.NamedQueueRequestType_Size:
+		return single(wrapperspb.Int32(int32(len(q.values))))
+
+	case pb1.NamedQueueRequestType_OfferTail, pb1.NamedQueueRequestType_OfferHead:
+		if requestType == pb1.NamedQueueRequestType_OfferHead && q.queueType != pb1.NamedQueueType_Deque {
+			return nil, fmt.Errorf("%v is only supported for a Deque", requestType)
+		}
+		var request wrapperspb.BytesValue
+		if err := unmarshal(message, &request); err != nil {
+			return nil, err
+		}
+		if requestType == pb1.NamedQueueRequestType_OfferHead {
+			q.values = append([][]byte{request.GetValue()}, q.values...)
+		} else {
+			q.values = append(q.values, request.GetValue())
+		}
+		q.lastIndex++
+		return single(&pb1.QueueOfferResult{Succeeded: true, Index: q.lastIndex})
+
+	case pb1.NamedQueueRequestType_PeekHead, pb1.NamedQueueRequestType_PollHead,
+		pb1.NamedQueueRequestType_PeekTail, pb1.NamedQueueRequestType_PollTail:
+		tail := requestType == pb1.NamedQueueRequestType_PeekTail || requestType == pb1.NamedQueueRequestType_PollTail
+		if tail && q.queueType != pb1.NamedQueueType_Deque {
+			return nil, fmt.Errorf("%v is only supported for a Deque", requestType)
+		}
+		if len(q.values) == 0 {
+			return single(&pb1.OptionalValue{})
+		}
+
+		index := 0
+		if tail {
+			index = len(q.values) - 1
+		}
+		value := q.values[index]
+		if requestType == pb1.NamedQueueRequestType_PollHead || requestType == pb1.NamedQueueRequestType_PollTail {
+			q.values = append(q.values[:index], q.values[index+1:]...)
+		}
+		return single(&pb1.OptionalValue{Present: true, Value: value})
+
+	default:
+		return nil, fmt.Errorf("unsupported queue request type %v", requestType)
+	}
+}
+
+// sendQueueResponse sends a NamedQueueResponse for a request, or an event if the id is zero.
+func (c *channel) sendQueueResponse(id int64, queueID int32, responseType pb1.NamedQueueResponseType, message proto.Message) error {
+	anyMessage, err := anyOf(message)
+	if err != nil {
+		return err
+	}
+	return c.sendMessage(id, &pb1.NamedQueueResponse{QueueId: queueID, Type: responseType, Message: anyMessage})
+}
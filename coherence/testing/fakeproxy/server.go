@@ -0,0 +1,368 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package fakeproxy
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oracle/coherence-go-client/v2/coherence/internal/evaluator"
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	defaultVersion        = "14.1.2.0.0"
+	defaultEncodedVersion = 1410200
+	protocolVersion       = 1
+	proxyMemberID         = 1
+
+	cacheServiceProtocol = "CacheService"
+	queueServiceProtocol = "QueueService"
+)
+
+var (
+	// ErrNotStarted indicates that the [Server] has not been started.
+	ErrNotStarted = errors.New("the fake proxy has not been started")
+
+	// ErrUnsupportedFormat indicates that a value, filter or agent was not serialized using the "json" format.
+	ErrUnsupportedFormat = evaluator.ErrUnsupportedFormat
+
+	errDisconnected = errors.New("the channel was disconnected by the fake proxy")
+)
+
+// Server is an in-process fake of the Coherence gRPC v1 proxy which stores caches and queues in memory.
+type Server struct {
+	pb1.UnimplementedProxyServiceServer
+
+	version        string
+	uuid           uuid.UUID
+	now            func() time.Time
+	mutex          sync.Mutex
+	caches         map[string]*namedCache
+	queues         map[string]*namedQueue
+	lastID         int32
+	channels       map[*channel]struct{}
+	grpcServer     *grpc.Server
+	listener       net.Listener
+	noHeartbeats   atomic.Bool
+	heartbeatDelay atomic.Int64
+	serverOptions  []grpc.ServerOption
+}
+
+// Option configures a [Server].
+type Option func(s *Server)
+
+// WithVersion returns an [Option] to set the Coherence version reported by the [Server] to clients.
+func WithVersion(version string) Option {
+	return func(s *Server) {
+		s.version = version
+	}
+}
+
+// WithServerOptions returns an [Option] to set the options used to create the gRPC server, for example
+// interceptors to authenticate clients.
+func WithServerOptions(options ...grpc.ServerOption) Option {
+	return func(s *Server) {
+		s.serverOptions = append(s.serverOptions, options...)
+	}
+}
+
+// New returns a new [Server] configured with the options.
+func New(options ...Option) *Server {
+	s := &Server{
+		version:  defaultVersion,
+		uuid:     uuid.New(),
+		now:      time.Now,
+		caches:   make(map[string]*namedCache),
+		queues:   make(map[string]*namedQueue),
+		channels: make(map[*channel]struct{}),
+	}
+
+	for _, f := range options {
+		f(s)
+	}
+
+	return s
+}
+
+// Start starts the [Server] listening on a free localhost port and returns the address to connect to,
+// which can be passed to coherence.WithAddress along with coherence.WithPlainText.
+func (s *Server) Start() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+
+	s.Serve(listener)
+	return s.Address()
+}
+
+// Serve starts the [Server] serving requests received on the listener, for example a bufconn.Listener.
+func (s *Server) Serve(listener net.Listener) {
+	grpcServer := grpc.NewServer(s.serverOptions...)
+	pb1.RegisterProxyServiceServer(grpcServer, s)
+
+	s.mutex.Lock()
+	s.grpcServer = grpcServer
+	s.listener = listener
+	s.mutex.Unlock()
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+}
+
+// Address returns the address the [Server] is listening on.
+func (s *Server) Address() (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.listener == nil {
+		return "", ErrNotStarted
+	}
+	return s.listener.Addr().String(), nil
+}
+
+// Stop stops the [Server], closing all the channels from connected clients.
+func (s *Server) Stop() {
+	s.mutex.Lock()
+	grpcServer := s.grpcServer
+	s.grpcServer = nil
+	s.listener = nil
+	s.mutex.Unlock()
+
+	if grpcServer != nil {
+		s.Disconnect()
+		grpcServer.Stop()
+	}
+}
+
+// Disconnect closes all the channels from connected clients, as if the proxy had been restarted, while
+// keeping the contents of the caches and queues. Clients must ensure their caches and queues again.
+func (s *Server) Disconnect() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for c := range s.channels {
+		s.removeChannel(c)
+		c.close()
+	}
+}
+
+// SuspendHeartbeats stops the [Server] acknowledging heartbeats when suspend is true, as if the channels had
+// stopped responding, so that clients detect that their streams are dead. Other requests are still handled.
+func (s *Server) SuspendHeartbeats(suspend bool) {
+	s.noHeartbeats.Store(suspend)
+}
+
+// DelayHeartbeats delays the acknowledgement of each heartbeat by the delay, as if the network was slow,
+// without delaying the responses to other requests. A delay of zero acknowledges heartbeats immediately.
+func (s *Server) DelayHeartbeats(delay time.Duration) {
+	s.heartbeatDelay.Store(int64(delay))
+}
+
+// SubChannel implements the ProxyService bidirectional channel for cache and queue requests.
+func (s *Server) SubChannel(stream grpc.BidiStreamingServer[pb1.ProxyRequest, pb1.ProxyResponse]) error {
+	c := newChannel(stream)
+	requests := make(chan *pb1.ProxyRequest)
+	errs := make(chan error, 1)
+
+	go func() {
+		for {
+			request, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case requests <- request:
+			case <-c.done:
+				return
+			}
+		}
+	}()
+
+	defer func() {
+		s.mutex.Lock()
+		s.removeChannel(c)
+		s.mutex.Unlock()
+		c.close()
+		c.pending.Wait()
+	}()
+
+	for {
+		select {
+		case <-c.done:
+			return errDisconnected
+		case err := <-errs:
+			return err
+		case request := <-requests:
+			if err := s.handleRequest(c, request); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handleRequest handles a single request received on a channel.
+func (s *Server) handleRequest(c *channel, request *pb1.ProxyRequest) error {
+	switch r := request.GetRequest().(type) {
+	case *pb1.ProxyRequest_Init:
+		return s.handleInit(c, request.GetId(), r.Init)
+	case *pb1.ProxyRequest_Heartbeat:
+		if r.Heartbeat.GetAck() && !s.noHeartbeats.Load() {
+			return c.acknowledge(request.GetId(), time.Duration(s.heartbeatDelay.Load()))
+		}
+		return nil
+	case *pb1.ProxyRequest_Message:
+		if c.protocol == "" {
+			return c.sendError(request.GetId(), errors.New("the channel has not been initialized"))
+		}
+
+		s.mutex.Lock()
+		if c.protocol == queueServiceProtocol {
+			s.handleQueueMessage(c, request.GetId(), r.Message)
+		} else {
+			s.handleCacheMessage(c, request.GetId(), r.Message)
+		}
+		s.mutex.Unlock()
+		return nil
+	default:
+		return c.sendError(request.GetId(), fmt.Errorf("unsupported request %T", r))
+	}
+}
+
+// handleInit handles the InitRequest which must be the first request on a channel.
+func (s *Server) handleInit(c *channel, id int64, init *pb1.InitRequest) error {
+	if c.protocol != "" {
+		return c.sendError(id, errors.New("the channel has already been initialized"))
+	}
+
+	switch init.GetProtocol() {
+	case cacheServiceProtocol, queueServiceProtocol:
+	default:
+		return c.sendError(id, fmt.Errorf("unsupported protocol %q", init.GetProtocol()))
+	}
+
+	c.protocol = init.GetProtocol()
+
+	s.mutex.Lock()
+	s.channels[c] = struct{}{}
+	s.mutex.Unlock()
+
+	return c.send(&pb1.ProxyResponse{
+		Id: id,
+		Response: &pb1.ProxyResponse_Init{Init: &pb1.InitResponse{
+			Uuid:            s.uuid[:],
+			Version:         s.version,
+			EncodedVersion:  defaultEncodedVersion,
+			ProtocolVersion: protocolVersion,
+			ProxyMemberId:   proxyMemberID,
+			ProxyMemberUuid: s.uuid[:],
+		}},
+	})
+}
+
+// nextID returns the next cache or queue id, the mutex must be held.
+func (s *Server) nextID() int32 {
+	s.lastID++
+	return s.lastID
+}
+
+// removeChannel removes a channel and its listeners, the mutex must be held.
+func (s *Server) removeChannel(c *channel) {
+	delete(s.channels, c)
+	for _, cache := range s.caches {
+		delete(cache.channels, c)
+		delete(cache.listeners, c)
+	}
+	for _, queue := range s.queues {
+		delete(queue.channels, c)
+	}
+}
+
+// channel is a bidirectional channel from a client.
+type channel struct {
+	stream    grpc.BidiStreamingServer[pb1.ProxyRequest, pb1.ProxyResponse]
+	protocol  string
+	mutex     sync.Mutex
+	done      chan struct{}
+	closeOnce sync.Once
+	pending   sync.WaitGroup // delayed heartbeat acknowledgements which have not been sent
+}
+
+func newChannel(stream grpc.BidiStreamingServer[pb1.ProxyRequest, pb1.ProxyResponse]) *channel {
+	return &channel{stream: stream, done: make(chan struct{})}
+}
+
+// close closes the channel, ending the stream.
+func (c *channel) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+// acknowledge sends the response to a heartbeat with the id of the heartbeat request, after the delay.
+func (c *channel) acknowledge(id int64, delay time.Duration) error {
+	response := &pb1.ProxyResponse{Id: id, Response: &pb1.ProxyResponse_Heartbeat{Heartbeat: &pb1.HeartbeatMessage{}}}
+	if delay <= 0 {
+		return c.send(response)
+	}
+
+	c.pending.Add(1)
+	go func() {
+		defer c.pending.Done()
+		select {
+		case <-time.After(delay):
+			_ = c.send(response)
+		case <-c.done:
+		}
+	}()
+	return nil
+}
+
+// send sends a response, serializing sends from request handlers and events.
+func (c *channel) send(response *pb1.ProxyResponse) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.stream.Send(response)
+}
+
+// sendMessage sends a message response for a request, or an event if the id is zero.
+func (c *channel) sendMessage(id int64, message proto.Message) error {
+	anyMessage, err := anypb.New(message)
+	if err != nil {
+		return err
+	}
+	return c.send(&pb1.ProxyResponse{Id: id, Response: &pb1.ProxyResponse_Message{Message: anyMessage}})
+}
+
+// complete sends the Complete message for a request.
+func (c *channel) complete(id int64) error {
+	return c.send(&pb1.ProxyResponse{Id: id, Response: &pb1.ProxyResponse_Complete{Complete: &pb1.Complete{}}})
+}
+
+// sendError sends an error response for a request.
+func (c *channel) sendError(id int64, err error) error {
+	return c.send(&pb1.ProxyResponse{Id: id, Response: &pb1.ProxyResponse_Error{Error: &pb1.ErrorMessage{Message: err.Error()}}})
+}
+
+// anyOf wraps a message in an Any, returning nil if the message is nil.
+func anyOf(message proto.Message) (*anypb.Any, error) {
+	if message == nil {
+		return nil, nil
+	}
+	return anypb.New(message)
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package fakeproxy
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/oracle/coherence-go-client/v2/coherence"
+	"github.com/oracle/coherence-go-client/v2/coherence/aggregators"
+	"github.com/oracle/coherence-go-client/v2/coherence/extractors"
+	"github.com/oracle/coherence-go-client/v2/coherence/filters"
+	"github.com/oracle/coherence-go-client/v2/coherence/processors"
+)
+
+type person struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func newTestSession(t *testing.T) *coherence.Session {
+	proxy := New()
+	address, err := proxy.Start()
+	if err != nil {
+		t.Fatalf("unable to start fake proxy: %v", err)
+	}
+	t.Cleanup(proxy.Stop)
+
+	session, err := coherence.NewSession(context.Background(), coherence.WithAddress(address),
+		coherence.WithPlainText(), coherence.WithRequestTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("unable to create session: %v", err)
+	}
+	t.Cleanup(session.Close)
+
+	return session
+}
+
+func TestNamedCacheRequests(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		session = newTestSession(t)
+		age     = extractors.Extract[int]("age")
+	)
+
+	if session.GetProtocolVersion() != protocolVersion {
+		t.Fatalf("expected to be connected using gRPC v1, got protocol version %d", session.GetProtocolVersion())
+	}
+
+	namedCache, err := coherence.GetNamedCache[int, person](session, "people")
+	if err != nil {
+		t.Fatalf("unable to get cache: %v", err)
+	}
+
+	for _, p := range []person{{1, "Tim", 50}, {2, "Andrew", 40}, {3, "Helen", 30}} {
+		if _, err = namedCache.Put(ctx, p.ID, p); err != nil {
+			t.Fatalf("unable to put %v: %v", p, err)
+		}
+	}
+
+	if p, err := namedCache.Get(ctx, 2); err != nil || p == nil || p.Name != "Andrew" {
+		t.Fatalf("expected Andrew, got %v, %v", p, err)
+	}
+	if size, err := namedCache.Size(ctx); err != nil || size != 3 {
+		t.Fatalf("expected size 3, got %v, %v", size, err)
+	}
+
+	var names []string
+	for e := range namedCache.EntrySetFilter(ctx, filters.Greater(age, 35).And(filters.Like(extractors.Extract[string]("name"), "%i%", true))) {
+		if e.Err != nil {
+			t.Fatalf("unexpected error %v", e.Err)
+		}
+		names = append(names, e.Value.Name)
+	}
+	if len(names) != 1 || names[0] != "Tim" {
+		t.Fatalf("expected [Tim], got %v", names)
+	}
+
+	var keys []int
+	for k := range namedCache.KeySet(ctx) {
+		if k.Err != nil {
+			t.Fatalf("unexpected error %v", k.Err)
+		}
+		keys = append(keys, k.Key)
+	}
+	sort.Ints(keys)
+	if len(keys) != 3 || keys[0] != 1 || keys[2] != 3 {
+		t.Fatalf("expected keys [1 2 3], got %v", keys)
+	}
+
+	if count, err := coherence.AggregateFilter(ctx, namedCache, filters.Less(age, 45), aggregators.Count()); err != nil || *count != 2 {
+		t.Fatalf("expected count of 2, got %v, %v", count, err)
+	}
+	if sum, err := coherence.Aggregate(ctx, namedCache, aggregators.Sum(age)); err != nil || sum.FloatString(0) != "120" {
+		t.Fatalf("expected sum of 120, got %v, %v", sum, err)
+	}
+	if name, err := coherence.Invoke[int, person, string](ctx, namedCache, 3, processors.Extractor[string]("name")); err != nil || *name != "Helen" {
+		t.Fatalf("expected Helen, got %v, %v", name, err)
+	}
+
+	ages := make(map[int]int)
+	for e := range coherence.InvokeAllKeys[int, person, int](ctx, namedCache, []int{1, 2}, processors.Extractor[int]("age")) {
+		if e.Err != nil {
+			t.Fatalf("unexpected error %v", e.Err)
+		}
+		ages[e.Key] = e.Value
+	}
+	if len(ages) != 2 || ages[1] != 50 || ages[2] != 40 {
+		t.Fatalf("expected ages of 50 and 40, got %v", ages)
+	}
+
+	if previous, err := namedCache.Remove(ctx, 1); err != nil || previous == nil || previous.ID != 1 {
+		t.Fatalf("expected removed value, got %v, %v", previous, err)
+	}
+	if err = namedCache.Truncate(ctx); err != nil {
+		t.Fatalf("unable to truncate: %v", err)
+	}
+	if empty, err := namedCache.IsEmpty(ctx); err != nil || !empty {
+		t.Fatalf("expected cache to be empty, got %v, %v", empty, err)
+	}
+}
+
+func TestMapEvents(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		session = newTestSession(t)
+		events  = make(chan coherence.MapEvent[int, person], 10)
+	)
+
+	namedMap, err := coherence.GetNamedMap[int, person](session, "events")
+	if err != nil {
+		t.Fatalf("unable to get map: %v", err)
+	}
+
+	listener := coherence.NewMapListener[int, person]().OnAny(func(e coherence.MapEvent[int, person]) {
+		events <- e
+	})
+	if err = namedMap.AddFilterListener(ctx, listener, filters.Equal(extractors.Extract[string]("name"), "Tim")); err != nil {
+		t.Fatalf("unable to add listener: %v", err)
+	}
+
+	_, _ = namedMap.Put(ctx, 1, person{1, "Helen", 30})
+	_, _ = namedMap.Put(ctx, 2, person{2, "Tim", 50})
+	_, _ = namedMap.Remove(ctx, 2)
+
+	for _, expected := range []coherence.MapEventType{coherence.EntryInserted, coherence.EntryDeleted} {
+		select {
+		case e := <-events:
+			if e.Type() != expected {
+				t.Fatalf("expected %v event, got %v", expected, e.Type())
+			}
+			if key, err := e.Key(); err != nil || *key != 2 {
+				t.Fatalf("expected event for key 2, got %v, %v", key, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %v event", expected)
+		}
+	}
+}
+
+func TestNamedQueueRequests(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		session = newTestSession(t)
+	)
+
+	queue, err := coherence.GetNamedDeQueue[string](ctx, session, "queue")
+	if err != nil {
+		t.Fatalf("unable to get queue: %v", err)
+	}
+
+	for _, v := range []string{"b", "c"} {
+		if err = queue.OfferTail(ctx, v); err != nil {
+			t.Fatalf("unable to offer %s: %v", v, err)
+		}
+	}
+	if err = queue.OfferHead(ctx, "a"); err != nil {
+		t.Fatalf("unable to offer to head: %v", err)
+	}
+
+	if size, err := queue.Size(ctx); err != nil || size != 3 {
+		t.Fatalf("expected size 3, got %v, %v", size, err)
+	}
+	if v, err := queue.PollHead(ctx); err != nil || v == nil || *v != "a" {
+		t.Fatalf("expected a, got %v, %v", v, err)
+	}
+	if v, err := queue.PeekTail(ctx); err != nil || v == nil || *v != "c" {
+		t.Fatalf("expected c, got %v, %v", v, err)
+	}
+}
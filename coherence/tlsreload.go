@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// ErrInvalidTLSReloadInterval indicates that the TLS reload interval is negative.
+var ErrInvalidTLSReloadInterval = errors.New("TLS reload interval must not be negative")
+
+// WithTLSReload returns a function to re-read the CA certificate and client certificate and key files,
+// set using the COHERENCE_TLS_* environment variables or the WithTLS* options, every interval. When the
+// contents of any of the files change the certificates are reloaded and used for the next connection or
+// reconnect, and a [TLSReloaded] event is raised. If the files cannot be loaded, for example because a
+// rotation is only partially complete, the previous certificates continue to be used. This option has
+// no effect when a [tls.Config] is set using [WithTLSConfig] or when using a plain text connection.
+func WithTLSReload(interval time.Duration) func(sessionOptions *SessionOptions) {
+	return func(s *SessionOptions) {
+		s.TLSReloadInterval = interval
+	}
+}
+
+// usesTLSFiles returns true if the TLS configuration is loaded from files.
+func (s *SessionOptions) usesTLSFiles() bool {
+	return !s.PlainText && s.TlSConfig == nil && (s.CaCertPath != "" || (s.ClientCertPath != "" && s.ClientKeyPath != ""))
+}
+
+// tlsReloader is a [credentials.TransportCredentials] which delegates to the credentials created from
+// the most recently loaded TLS files, so that new connections use rotated certificates.
+type tlsReloader struct {
+	session     *Session
+	mutex       sync.RWMutex
+	current     credentials.TransportCredentials
+	fingerprint []byte
+	serverName  string
+	done        chan struct{}
+	stopOnce    sync.Once
+}
+
+// newTLSReloader creates a tlsReloader with the current contents of the TLS files.
+func newTLSReloader(session *Session) (*tlsReloader, error) {
+	r := &tlsReloader{session: session, done: make(chan struct{})}
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// start starts a goroutine to check the TLS files for changes every reload interval until stopped.
+func (r *tlsReloader) start() {
+	go func() {
+		ticker := time.NewTicker(r.session.sessOpts.TLSReloadInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.done:
+				return
+			case <-ticker.C:
+			}
+
+			reloaded, err := r.reload()
+			if err != nil {
+				r.session.log(WARNING, "Session [%s] unable to reload TLS certificates, continuing to use previous certificates: %v",
+					r.session.sessionID, err)
+				continue
+			}
+			if reloaded {
+				r.session.log(INFO, "Session [%s] reloaded TLS certificates", r.session.sessionID)
+				r.session.dispatch(TLSReloaded, func() SessionLifecycleEvent {
+					return newSessionLifecycleEvent(r.session, TLSReloaded)
+				})
+			}
+		}
+	}()
+}
+
+// stop stops checking the TLS files for changes.
+func (r *tlsReloader) stop() {
+	r.stopOnce.Do(func() {
+		close(r.done)
+	})
+}
+
+// reload loads the TLS files if their contents have changed and returns true if they were reloaded.
+func (r *tlsReloader) reload() (bool, error) {
+	opts := r.session.sessOpts
+
+	fingerprint, err := fingerprintFiles(opts.CaCertPath, opts.ClientCertPath, opts.ClientKeyPath)
+	if err != nil {
+		return false, err
+	}
+
+	r.mutex.RLock()
+	unchanged := bytes.Equal(fingerprint, r.fingerprint)
+	r.mutex.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	config, err := r.session.loadTLSConfig()
+	if err != nil {
+		return false, err
+	}
+
+	creds := credentials.NewTLS(config)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.serverName != "" {
+		//nolint:staticcheck // SA1019 - retain any server name override
+		_ = creds.OverrideServerName(r.serverName)
+	}
+	r.current = creds
+	r.fingerprint = fingerprint
+
+	return true, nil
+}
+
+// fingerprintFiles returns a hash of the contents of the files, ignoring any empty paths.
+func fingerprintFiles(paths ...string) ([]byte, error) {
+	hash := sha256.New()
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		hash.Write(data)
+	}
+	return hash.Sum(nil), nil
+}
+
+func (r *tlsReloader) get() credentials.TransportCredentials {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.current
+}
+
+func (r *tlsReloader) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return r.get().ClientHandshake(ctx, authority, conn)
+}
+
+func (r *tlsReloader) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return r.get().ServerHandshake(conn)
+}
+
+func (r *tlsReloader) Info() credentials.ProtocolInfo {
+	return r.get().Info()
+}
+
+// Clone returns the reloader itself, as all connections should use the most recently loaded certificates.
+func (r *tlsReloader) Clone() credentials.TransportCredentials {
+	return r
+}
+
+func (r *tlsReloader) OverrideServerName(serverName string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.serverName = serverName
+	//nolint:staticcheck // SA1019 - required to implement credentials.TransportCredentials
+	return r.current.OverrideServerName(serverName)
+}
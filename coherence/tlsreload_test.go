@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// writeTestCertificate writes a self-signed certificate and key with the given serial number to the paths.
+func writeTestCertificate(t *testing.T, certPath, keyPath string, serial int64) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "coherence-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %v", err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %v", err)
+	}
+
+	if err = os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatalf("unable to write certificate: %v", err)
+	}
+	if err = os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatalf("unable to write key: %v", err)
+	}
+}
+
+func TestTLSReloader(t *testing.T) {
+	var (
+		dir      = t.TempDir()
+		certPath = filepath.Join(dir, "tls.crt")
+		keyPath  = filepath.Join(dir, "tls.key")
+		reloads  atomic.Int32
+	)
+
+	writeTestCertificate(t, certPath, keyPath, 1)
+
+	session := &Session{
+		debug: func(string, ...any) {},
+		sessOpts: &SessionOptions{
+			CaCertPath:        certPath,
+			ClientCertPath:    certPath,
+			ClientKeyPath:     keyPath,
+			TLSReloadInterval: 10 * time.Millisecond,
+		},
+	}
+	if !session.sessOpts.usesTLSFiles() {
+		t.Fatalf("expected TLS files to be used")
+	}
+
+	listener := NewSessionLifecycleListener().OnTLSReloaded(func(_ SessionLifecycleEvent) {
+		reloads.Add(1)
+	})
+	session.AddSessionLifecycleListener(listener)
+
+	reloader, err := newTLSReloader(session)
+	if err != nil {
+		t.Fatalf("unable to create reloader: %v", err)
+	}
+	initial := reloader.get()
+
+	if reloaded, err1 := reloader.reload(); reloaded || err1 != nil {
+		t.Fatalf("expected no reload for unchanged files, got %v, %v", reloaded, err1)
+	}
+
+	// an invalid key should leave the previous credentials in use
+	if err = os.WriteFile(keyPath, []byte("invalid"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded, err1 := reloader.reload(); reloaded || err1 == nil || reloader.get() != initial {
+		t.Fatalf("expected reload to fail and retain credentials, got %v, %v", reloaded, err1)
+	}
+
+	reloader.start()
+	writeTestCertificate(t, certPath, keyPath, 2)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for reloads.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if reloads.Load() != 1 || reloader.get() == initial {
+		t.Fatalf("expected a single TLSReloaded event and new credentials, got %d events", reloads.Load())
+	}
+
+	reloader.stop()
+}
+
+func TestTLSReloadOptions(t *testing.T) {
+	if (&SessionOptions{PlainText: true, CaCertPath: "ca.pem"}).usesTLSFiles() {
+		t.Fatalf("expected plain text not to use TLS files")
+	}
+	if (&SessionOptions{ClientCertPath: "tls.crt"}).usesTLSFiles() {
+		t.Fatalf("expected a client certificate without a key not to use TLS files")
+	}
+	if _, err := NewSession(nil, WithTLSReload(-time.Second)); err != ErrInvalidTLSReloadInterval {
+		t.Fatalf("expected ErrInvalidTLSReloadInterval, got %v", err)
+	}
+}
@@ -0,0 +1,270 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Tracer is notified by a [Session] when each [NamedMap], [NamedCache] and [NamedQueue] operation starts and ends,
+// allowing operations to be traced. Use [WithTracer] to set the Tracer for a [Session]. The package
+// github.com/oracle/coherence-go-client/v2/coherence/oteltrace contains a Tracer which creates OpenTelemetry spans.
+type Tracer interface {
+	// Start is called when an operation starts and returns the [context.Context] to use for the operation and
+	// the [OperationSpan] to end when the operation ends. Any request attributes added to the returned context
+	// using [WithRequestAttributes] are sent to a gRPC v1 proxy, so can be used to propagate trace context.
+	Start(ctx context.Context, operation Operation) (context.Context, OperationSpan)
+}
+
+// OperationSpan is returned by [Tracer.Start] and ended once when the operation ends.
+type OperationSpan interface {
+	// End is called when the operation ends with the result of the operation.
+	End(result OperationResult)
+}
+
+// Operation describes a [NamedMap], [NamedCache] or [NamedQueue] operation.
+type Operation struct {
+	// Name is the name of the cache or queue.
+	Name string
+
+	// Type is the operation, for example "Get", "Put" or "OfferTail".
+	Type string
+
+	// Queue is true if the operation is against a [NamedQueue] rather than a [NamedMap] or [NamedCache].
+	Queue bool
+}
+
+// OperationResult describes the result of a [NamedMap], [NamedCache] or [NamedQueue] operation.
+// The request id and sizes are only available when connected to a gRPC v1 proxy.
+type OperationResult struct {
+	// RequestID is the id of the first request sent for the operation, or zero if no request was sent.
+	RequestID int64
+
+	// Requests is the number of requests sent for the operation, which is greater than one for operations
+	// that retrieve results a page at a time.
+	Requests int
+
+	// RequestBytes is the total serialized size of the requests sent for the operation.
+	RequestBytes int
+
+	// ResponseBytes is the total serialized size of the responses received for the operation.
+	ResponseBytes int
+
+	// Err is the error the operation failed with, or nil if it succeeded.
+	Err error
+}
+
+// WithTracer returns a function to set the [Tracer] which is notified when each operation starts and ends.
+func WithTracer(tracer Tracer) func(sessionOptions *SessionOptions) {
+	return func(s *SessionOptions) {
+		s.Tracer = tracer
+	}
+}
+
+type operationKey struct{}
+
+// operationRecord records the requests and responses for an operation, which are reported to the
+// session metrics and the [Tracer], if enabled, when the operation ends.
+type operationRecord struct {
+	mutex     sync.Mutex
+	start     time.Time
+	metrics   *operationMetrics
+	span      OperationSpan
+	result    OperationResult
+	ended     bool
+	streamErr error               // the first error streamed by a streaming operation
+	inFlight  *inFlightOperations // the in-flight operations for the session, waited on by Shutdown
+	limiter   *inFlightLimiter    // limits the concurrent requests for the operation, if set
+	breaker   *circuitBreaker     // the circuit breaker for the operation, if set
+	admitted  bool                // indicates the circuit breaker allowed the operation and is waiting for the result
+}
+
+// startOperation starts recording an operation, which is counted as in-flight until it ends and is subject
+// to the in-flight limit and circuit breaker, if any. The operation is also reported to the session metrics
+// and the [Tracer] if they have been enabled.
+func (s *Session) startOperation(ctx context.Context, name string, operationType string, queue bool) (context.Context, *operationRecord) {
+	tracer := s.sessOpts.Tracer
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	op := &operationRecord{start: time.Now(), metrics: s.metrics.operation(name, operationType, queue), inFlight: &s.inFlight,
+		limiter: s.limiter, breaker: s.breaker}
+	op.metrics.started()
+	op.inFlight.Add(1)
+
+	if tracer != nil {
+		ctx, op.span = tracer.Start(ctx, Operation{Name: name, Type: operationType, Queue: queue})
+	}
+
+	return context.WithValue(ctx, operationKey{}, op), op
+}
+
+// startOperation starts recording an operation against the map or cache.
+func (bc *baseClient[K, V]) startOperation(ctx context.Context, operationType string) (context.Context, *operationRecord) {
+	ctx, op := bc.session.startOperation(ctx, bc.name, operationType, false)
+	if bc.limiter != nil {
+		op.limiter = bc.limiter
+	}
+	if bc.breaker != nil {
+		op.breaker = bc.breaker
+	}
+	return ctx, op
+}
+
+// startOperation starts recording an operation against the queue.
+func (bq *baseQueueClient[V]) startOperation(ctx context.Context, operationType string) (context.Context, *operationRecord) {
+	return bq.session.startOperation(ctx, bq.name, operationType, true)
+}
+
+// end ends the operation with the error, if any. Only the first call has any effect.
+func (op *operationRecord) end(err error) {
+	op.mutex.Lock()
+	if op.ended {
+		op.mutex.Unlock()
+		return
+	}
+	op.ended = true
+	op.result.Err = err
+	result := op.result
+	admitted := op.admitted
+	op.mutex.Unlock()
+
+	if admitted {
+		op.breaker.record(err)
+	}
+
+	op.metrics.ended(time.Since(op.start), result)
+	op.inFlight.Add(-1)
+
+	if op.span != nil {
+		op.span.End(result)
+	}
+}
+
+// allow checks the circuit breaker for the operation, if any, the first time the operation sends a request.
+func (op *operationRecord) allow() error {
+	if op.breaker == nil {
+		return nil
+	}
+
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	if op.admitted {
+		return nil
+	}
+	if err := op.breaker.allow(); err != nil {
+		return err
+	}
+	op.admitted = true
+
+	return nil
+}
+
+// streamedResult is implemented by the results sent on the channels returned by streaming operations.
+type streamedResult interface {
+	streamErr() error
+}
+
+func (e *StreamedEntry[K, V]) streamErr() error {
+	return e.Err
+}
+
+func (k *StreamedKey[K]) streamErr() error {
+	return k.Err
+}
+
+func (v *StreamedValue[V]) streamErr() error {
+	return v.Err
+}
+
+// failedStream returns a channel with the result of a streaming operation which failed before
+// any results were streamed, and ends the operation with the error.
+func failedStream[T streamedResult](op *operationRecord, result T) <-chan T {
+	op.end(result.streamErr())
+	return errorStream(result)
+}
+
+// sendStreamed sends a result of a streaming operation on the channel, recording the first error
+// streamed against the operation being recorded, if any.
+func sendStreamed[T streamedResult](ctx context.Context, ch chan<- T, result T) {
+	if err := result.streamErr(); err != nil {
+		if op, ok := ctx.Value(operationKey{}).(*operationRecord); ok {
+			op.mutex.Lock()
+			if op.streamErr == nil {
+				op.streamErr = err
+			}
+			op.mutex.Unlock()
+		}
+	}
+
+	ch <- result
+}
+
+// endStream is deferred by the goroutine which streams the results of an operation, and returns the function
+// which ends the operation with the first error streamed. The operation is ended when the context is done
+// if that happens first, as the results may no longer be read, so the goroutine may never complete.
+func (op *operationRecord) endStream(ctx context.Context) func() {
+	stop := context.AfterFunc(ctx, func() { op.end(ctx.Err()) })
+
+	return func() {
+		stop()
+
+		op.mutex.Lock()
+		err := op.streamErr
+		op.mutex.Unlock()
+
+		op.end(err)
+	}
+}
+
+// recordRequestSent records the id and size of the request against the operation being recorded, if any.
+func recordRequestSent(ctx context.Context, req *pb1.ProxyRequest) {
+	op, ok := ctx.Value(operationKey{}).(*operationRecord)
+	if !ok {
+		return
+	}
+
+	size := proto.Size(req)
+
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	if op.result.Requests == 0 {
+		op.result.RequestID = req.Id
+	}
+	op.result.Requests++
+	op.result.RequestBytes += size
+}
+
+// recordResponseReceived records the response attributes and the size of the response against the operation being recorded, if any.
+func recordResponseReceived(ctx context.Context, resp responseMessage) {
+	recordResponseAttributes(ctx, resp.attributes)
+
+	op, ok := ctx.Value(operationKey{}).(*operationRecord)
+	if !ok {
+		return
+	}
+
+	var size int
+	if resp.namedCacheResponse != nil {
+		size = proto.Size(resp.namedCacheResponse)
+	} else if resp.namedQueueResponse != nil {
+		size = proto.Size(resp.namedQueueResponse)
+	}
+
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	op.result.ResponseBytes += size
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+)
+
+type testTracer struct {
+	mutex   sync.Mutex
+	started []Operation
+	ended   []OperationResult
+}
+
+func (t *testTracer) Start(ctx context.Context, operation Operation) (context.Context, OperationSpan) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.started = append(t.started, operation)
+	return WithRequestAttributes(ctx, map[string]string{"traceparent": "test"}), t
+}
+
+func (t *testTracer) End(result OperationResult) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.ended = append(t.ended, result)
+}
+
+func TestTracerOperation(t *testing.T) {
+	tracer := &testTracer{}
+	bc := &baseClient[int, string]{
+		session:   &Session{sessOpts: &SessionOptions{Tracer: tracer}},
+		name:      "test-cache",
+		destroyed: true,
+	}
+
+	_, err := executeSize(context.Background(), bc)
+	if !errors.Is(err, ErrDestroyed) {
+		t.Fatalf("expected ErrDestroyed, got %v", err)
+	}
+
+	if len(tracer.started) != 1 || tracer.started[0] != (Operation{Name: "test-cache", Type: "Size"}) {
+		t.Fatalf("unexpected operations started %v", tracer.started)
+	}
+	if len(tracer.ended) != 1 || !errors.Is(tracer.ended[0].Err, ErrDestroyed) || tracer.ended[0].Requests != 0 {
+		t.Fatalf("unexpected operation results %v", tracer.ended)
+	}
+
+	// without a tracer or metrics the operation is still counted as in-flight
+	bc.session.sessOpts.Tracer = nil
+	_, op := bc.startOperation(context.Background(), "Get")
+	if op == nil || op.span != nil || op.metrics != nil || bc.session.inFlight.Load() != 1 {
+		t.Fatalf("expected an in-flight operation without a span or metrics, got %v", op)
+	}
+	op.end(nil)
+	if bc.session.inFlight.Load() != 0 {
+		t.Fatalf("expected no in-flight operations, got %d", bc.session.inFlight.Load())
+	}
+}
+
+func TestTracerRecordsRequestsAndResponses(t *testing.T) {
+	tracer := &testTracer{}
+	session := &Session{sessOpts: &SessionOptions{Tracer: tracer}}
+
+	ctx, op := session.startOperation(context.Background(), "test-queue", "OfferTail", true)
+
+	// the request attributes added by the tracer must be applied to the request
+	req := &pb1.ProxyRequest{Id: 10, Request: &pb1.ProxyRequest_Heartbeat{Heartbeat: &pb1.HeartbeatMessage{}}}
+	session.applyRequestAttributes(ctx, req)
+	if _, ok := req.Context["traceparent"]; !ok {
+		t.Fatalf("expected traceparent in request context, got %v", req.Context)
+	}
+
+	recordRequestSent(ctx, req)
+	recordRequestSent(ctx, &pb1.ProxyRequest{Id: 11})
+	recordResponseReceived(ctx, responseMessage{namedQueueResponse: &pb1.NamedQueueResponse{QueueId: 1}})
+
+	op.end(nil)
+	op.end(errors.New("ignored"))
+
+	if len(tracer.ended) != 1 {
+		t.Fatalf("expected operation to be ended once, got %v", tracer.ended)
+	}
+
+	result := tracer.ended[0]
+	if result.RequestID != 10 || result.Requests != 2 || result.RequestBytes == 0 || result.ResponseBytes == 0 || result.Err != nil {
+		t.Fatalf("unexpected operation result %+v", result)
+	}
+	if !tracer.started[0].Queue {
+		t.Fatalf("expected queue operation, got %v", tracer.started[0])
+	}
+}
+
+func TestEndStream(t *testing.T) {
+	var (
+		tracer  = &testTracer{}
+		session = newTestSession(WithTracer(tracer))
+		ctx, op = session.startOperation(context.Background(), "test-cache", "KeySet", false)
+		ch      = make(chan *StreamedKey[int])
+		errTest = errors.New("test")
+	)
+
+	go func() {
+		defer op.endStream(ctx)()
+		sendStreamed(ctx, ch, &StreamedKey[int]{Key: 1})
+		sendStreamed(ctx, ch, &StreamedKey[int]{Err: errTest})
+		close(ch)
+	}()
+
+	count := 0
+	for range ch {
+		count++
+	}
+
+	if count != 2 {
+		t.Fatalf("expected 2 streamed keys, got %d", count)
+	}
+	if err := session.waitForInFlight(context.Background()); err != nil {
+		t.Fatalf("expected the operation to end, got %v", err)
+	}
+
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+	if len(tracer.ended) != 1 || !errors.Is(tracer.ended[0].Err, errTest) {
+		t.Fatalf("unexpected operation results %v", tracer.ended)
+	}
+}
+
+func TestEndStreamContextCancelled(t *testing.T) {
+	var (
+		tracer         = &testTracer{}
+		session        = newTestSession(WithTracer(tracer))
+		parent, cancel = context.WithCancel(context.Background())
+		ctx, op        = session.startOperation(parent, "test-cache", "KeySet", false)
+		ch             = make(chan *StreamedKey[int])
+		done           = make(chan struct{})
+	)
+
+	// the results are never read, so the goroutine blocks until the channel is drained
+	go func() {
+		defer close(done)
+		defer op.endStream(ctx)()
+		sendStreamed(ctx, ch, &StreamedKey[int]{Key: 1})
+		close(ch)
+	}()
+
+	cancel()
+	if err := session.waitForInFlight(context.Background()); err != nil {
+		t.Fatalf("expected the operation to end, got %v", err)
+	}
+
+	for range ch {
+	}
+	<-done
+
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+	if len(tracer.ended) != 1 || !errors.Is(tracer.ended[0].Err, context.Canceled) {
+		t.Fatalf("unexpected operation results %v", tracer.ended)
+	}
+}
+
+func TestFailedStream(t *testing.T) {
+	var (
+		tracer  = &testTracer{}
+		session = newTestSession(WithTracer(tracer))
+		_, op   = session.startOperation(context.Background(), "test-cache", "KeySet", false)
+		errTest = errors.New("test")
+	)
+
+	ch := failedStream(op, &StreamedKey[int]{Err: errTest})
+	if session.inFlight.Load() != 0 {
+		t.Fatalf("expected the operation to have ended")
+	}
+	if result := <-ch; !errors.Is(result.Err, errTest) {
+		t.Fatalf("expected the error to be streamed, got %v", result.Err)
+	}
+
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+	if len(tracer.ended) != 1 || !errors.Is(tracer.ended[0].Err, errTest) {
+		t.Fatalf("unexpected operation results %v", tracer.ended)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"log"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -36,7 +37,7 @@ var (
 	ALL     logLevel = 5 // all messages
 
 	// current log level
-	currentLogLevel int
+	currentLogLevel atomic.Int32
 )
 
 const (
@@ -56,6 +57,8 @@ type responseMessage struct {
 	namedQueueResponse *pb1.NamedQueueResponse
 	complete           bool
 	err                *string
+	attributes         map[string]string // the string attributes from the context of the response
+	disconnected       bool              // the stream was disconnected before the response was received
 }
 
 func (rm responseMessage) String() string {
@@ -72,33 +75,60 @@ func (rm responseMessage) String() string {
 
 // proxyRequestChannel holds response messages channel.
 type proxyRequestChannel struct {
-	ch chan responseMessage
+	ch        chan responseMessage
+	release   func()        // releases the in-flight slot held by the request, if any
+	abandoned chan struct{} // closed when the stream is disconnected before the response is complete
+	abandon   func()        // closes abandoned, only the first call has any effect
+}
+
+// newProxyRequestChannel returns a new proxyRequestChannel for a request holding the in-flight slot released by release.
+func newProxyRequestChannel(release func()) proxyRequestChannel {
+	abandoned := make(chan struct{})
+	return proxyRequestChannel{
+		ch:        make(chan responseMessage),
+		release:   release,
+		abandoned: abandoned,
+		abandon:   sync.OnceFunc(func() { close(abandoned) }),
+	}
 }
 
 // streamManagerV1 holds the data for a gRPC V1 connection.
 type streamManagerV1 struct {
-	session               *Session
-	mutex                 sync.RWMutex
-	eventStream           *eventStreamV1
-	proxyProtocol         V1ProxyProtocol
-	serializer            Serializer[any]
-	requests              map[int64]proxyRequestChannel
-	serverVersion         string
-	serverProtocolVersion int32
-	serverProxyMemberID   int32
+	session       *Session
+	mutex         sync.RWMutex
+	eventStream   *eventStreamV1
+	proxyProtocol V1ProxyProtocol
+	serializer    Serializer[any]
+	requests      map[int64]proxyRequestChannel
+	cacheIDMap    safeMap[string, int32] // cache ids are only valid for the stream they were ensured on
+	serverInfo    ServerInfo
+	lastResponse  int64                   // time in millis the last response, including heartbeats, was received
+	disconnected  atomic.Bool             // indicates the stream has been disconnected and must be re-created
+	retired       atomic.Bool             // indicates the stream has been replaced and should not be reconnected
+	pingMutex     sync.Mutex              // protects pings
+	pings         map[int64]chan struct{} // channels waiting for a heartbeat response, keyed by request id
 }
 
 type eventStreamV1 struct {
-	grpcStream v1.ProxyService_SubChannelClient
-	cancel     func()
+	grpcStream        v1.ProxyService_SubChannelClient
+	cancel            func()
+	done              chan struct{} // closed when the stream has ended
+	credentialsExpiry *time.Time    // the expiry of the credentials attached when the stream was opened, if any
 }
 
 func newStreamManagerV1(session *Session, proxyProtocol V1ProxyProtocol) (*streamManagerV1, error) {
+	return newStreamManagerV1WithCacheIDs(session, proxyProtocol, session.cacheIDMap)
+}
+
+// newStreamManagerV1WithCacheIDs creates a new stream manager which records the ids of caches ensured on
+// the stream in the specified map.
+func newStreamManagerV1WithCacheIDs(session *Session, proxyProtocol V1ProxyProtocol, cacheIDMap safeMap[string, int32]) (*streamManagerV1, error) {
 	manager := streamManagerV1{
 		session:       session,
 		proxyProtocol: proxyProtocol,
 		requests:      make(map[int64]proxyRequestChannel, 0),
 		serializer:    NewSerializer[any](session.sessOpts.Format),
+		cacheIDMap:    cacheIDMap,
 	}
 
 	_, err := manager.ensureStream()
@@ -118,8 +148,10 @@ func (m *streamManagerV1) ensureStream() (*eventStreamV1, error) {
 
 		ctx, cancel := context.WithCancel(context.Background())
 
+		// record the credentials attached when the stream is opened, so the stream can be re-opened before they expire
+		attached := &attachedCredentials{}
 		proxyClient := pb1.NewProxyServiceClient(m.session.conn)
-		grpcStream, err := proxyClient.SubChannel(ctx)
+		grpcStream, err := proxyClient.SubChannel(context.WithValue(ctx, attachedCredentialsKey{}, attached))
 
 		if err != nil {
 			m.session.debugConnection("error getting SubChannel: %v", err)
@@ -127,7 +159,8 @@ func (m *streamManagerV1) ensureStream() (*eventStreamV1, error) {
 			return nil, err
 		}
 
-		v1EventsStream := eventStreamV1{grpcStream: grpcStream, cancel: cancel}
+		v1EventsStream := eventStreamV1{grpcStream: grpcStream, cancel: cancel, done: make(chan struct{}),
+			credentialsExpiry: attached.expiry.Load()}
 		m.eventStream = &v1EventsStream
 
 		// generate and send init request
@@ -141,8 +174,11 @@ func (m *streamManagerV1) ensureStream() (*eventStreamV1, error) {
 			return nil, err
 		}
 
-		// we must receive a proxy response
+		// we must receive a proxy response, if the connection has silently gone away then this
+		// may never happen, so cancel the stream if we have not received a response within the request timeout
+		initTimer := time.AfterFunc(m.session.sessOpts.RequestTimeout, cancel)
 		proxyResponse, err := m.eventStream.grpcStream.Recv()
+		initTimer.Stop()
 		if err != nil || proxyResponse == nil {
 			m.session.debugConnection("error receiving init response: %v", err)
 			cancel()
@@ -161,12 +197,17 @@ func (m *streamManagerV1) ensureStream() (*eventStreamV1, error) {
 		// save the server information received
 		m.setServerInfo(response)
 		m.session.debugConnection(getInitDescription(response))
+		m.recordResponse()
 
 		// goroutine to handle MapEventResponse instances returned
 		// from event stream
-		go func(m *streamManagerV1) {
+		go func(m *streamManagerV1, stream *eventStreamV1) {
+			defer close(stream.done)
+			defer m.disconnected.Store(true)
+			defer m.abandonRequests()
+
 			for {
-				response1, err1 := m.eventStream.grpcStream.Recv()
+				response1, err1 := stream.grpcStream.Recv()
 				if err1 == io.EOF {
 					m.session.debugConnection("received EOF, closing")
 					cancel()
@@ -177,15 +218,18 @@ func (m *streamManagerV1) ensureStream() (*eventStreamV1, error) {
 					m.session.debugConnection("closing connection: %v", statusLocal)
 					if statusLocal != codes.Canceled {
 						// only log if it's not a cancelled error as this is just the client closing
-						logMessage(WARNING, "event stream recv failed: %s", err1)
+						m.session.log(WARNING, "event stream recv failed: %s", err1)
 					}
 					cancel()
 					return
 				}
 
+				m.recordResponse()
+
 				id := response1.GetId()
 				if h := response1.GetHeartbeat(); h != nil {
 					m.session.debugConnection("received heartbeat: %v", h)
+					m.heartbeatReceived(id)
 				} else {
 					var resp responseMessage
 
@@ -198,16 +242,139 @@ func (m *streamManagerV1) ensureStream() (*eventStreamV1, error) {
 					if msg := response1.GetMessage(); msg != nil {
 						resp.message = msg
 					}
+					resp.attributes = m.session.responseAttributes(id, response1.GetContext())
 
 					m.processResponseMessage(id, &resp)
 				}
 			}
-		}(m)
+		}(m, m.eventStream)
+
+		m.monitorStream(m.eventStream)
 	}
 
 	return m.eventStream, nil
 }
 
+// monitorStream starts a goroutine, if heartbeats are enabled or a [CredentialsProvider] has been set, to send
+// heartbeats on the stream and to cancel the stream if no response has been received for the configured number
+// of heartbeat intervals. As credentials are only attached when the stream is opened, the stream is also cancelled
+// shortly before the credentials expire. When the stream ends while the session is still open, for example because
+// the server rejected the credentials, the session is notified so that it can reconnect, with new credentials.
+func (m *streamManagerV1) monitorStream(stream *eventStreamV1) {
+	var (
+		interval    = m.session.sessOpts.HeartbeatInterval
+		missedLimit = m.session.sessOpts.HeartbeatMissedLimit
+		heartbeats  <-chan time.Time
+		reopen      <-chan time.Time
+		sending     atomic.Bool // indicates a heartbeat is being sent
+	)
+
+	if interval <= 0 && m.session.sessOpts.CredentialsProvider == nil {
+		return
+	}
+
+	go func() {
+		if interval > 0 {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			heartbeats = ticker.C
+		}
+
+		if expiry := stream.credentialsExpiry; expiry != nil && !expiry.IsZero() {
+			timer := time.NewTimer(streamReopenDelay(*expiry))
+			defer timer.Stop()
+			reopen = timer.C
+		}
+
+		for {
+			select {
+			case <-stream.done:
+				// the stream has ended, so if the session is still open this was not requested by the client
+				if !m.session.IsClosed() {
+					m.session.streamDisconnected(m)
+				}
+				return
+			case <-reopen:
+				m.session.log(INFO, "Session [%s] %s stream credentials expire at %v, re-opening stream with new credentials",
+					m.session.sessionID, m.proxyProtocol, *stream.credentialsExpiry)
+				stream.cancel()
+			case <-heartbeats:
+				silence := time.Since(time.UnixMilli(atomic.LoadInt64(&m.lastResponse)))
+				if silence > interval*time.Duration(missedLimit) {
+					m.session.log(WARNING, "Session [%s] %s stream has not received a response for %v, missed %d heartbeats, closing stream",
+						m.session.sessionID, m.proxyProtocol, silence.Truncate(time.Millisecond), missedLimit)
+					stream.cancel()
+					continue
+				}
+
+				// the heartbeat is sent separately, so that a send blocked by flow control against a dead peer
+				// does not stop the stream being cancelled once the missed heartbeat limit has been reached
+				if sending.CompareAndSwap(false, true) {
+					go func() {
+						defer sending.Store(false)
+						if err := m.sendHeartbeat(stream, 0); err != nil {
+							m.session.debugConnection("unable to send heartbeat: %v", err)
+						}
+					}()
+				}
+			}
+		}
+	}()
+}
+
+// abandonRequests notifies the requests waiting for a response that the stream has been disconnected,
+// so that they fail with [ErrStreamDisconnected] rather than waiting for the request timeout.
+func (m *streamManagerV1) abandonRequests() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, r := range m.requests {
+		r.abandon()
+	}
+}
+
+// sendHeartbeat sends a heartbeat with the request id on the stream and requests the server to acknowledge it.
+func (m *streamManagerV1) sendHeartbeat(stream *eventStreamV1, id int64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	req := m.newHeartbeatRequest(id)
+	m.session.debugConnection("sending heartbeat: %v", req)
+
+	return stream.grpcStream.Send(req)
+}
+
+// recordResponse records the time the last response was received on the stream.
+func (m *streamManagerV1) recordResponse() {
+	atomic.StoreInt64(&m.lastResponse, time.Now().UnixMilli())
+}
+
+// retire marks the stream as replaced, so it is not reconnected, and cancels it.
+func (m *streamManagerV1) retire() {
+	m.retired.Store(true)
+	m.disconnect()
+}
+
+// disconnect marks the stream as disconnected and cancels it.
+func (m *streamManagerV1) disconnect() {
+	m.disconnected.Store(true)
+
+	m.mutex.RLock()
+	stream := m.eventStream
+	m.mutex.RUnlock()
+
+	if stream != nil {
+		stream.cancel()
+	}
+}
+
+// isDisconnected returns true if the stream has been disconnected.
+func (m *streamManagerV1) isDisconnected() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.eventStream == nil || m.disconnected.Load()
+}
+
 func (m *streamManagerV1) processResponseMessage(id int64, resp *responseMessage) {
 	if resp.message != nil {
 		// Use TypeUrl to determine the message type and unmarshal accordingly
@@ -215,20 +382,20 @@ func (m *streamManagerV1) processResponseMessage(id int64, resp *responseMessage
 		case namedCacheResponseTypeURL:
 			var namedCacheResponse pb1.NamedCacheResponse
 			if err := resp.message.UnmarshalTo(&namedCacheResponse); err != nil {
-				logMessage(WARNING, "%v", getUnmarshallError("namedCacheResponse", err))
+				m.session.log(WARNING, "%v", getUnmarshallError("namedCacheResponse", err))
 				return
 			}
 			resp.namedCacheResponse = &namedCacheResponse
 		case namedQueueResponseTypeURL:
 			var namedQueueResponse pb1.NamedQueueResponse
 			if err := resp.message.UnmarshalTo(&namedQueueResponse); err != nil {
-				logMessage(WARNING, "%v", getUnmarshallError("namedQueueResponse", err))
+				m.session.log(WARNING, "%v", getUnmarshallError("namedQueueResponse", err))
 				return
 			}
 			resp.namedQueueResponse = &namedQueueResponse
 
 		default:
-			logMessage(WARNING, "Unknown message type: %v", resp.message.TypeUrl)
+			m.session.log(WARNING, "Unknown message type: %v", resp.message.TypeUrl)
 			return
 		}
 	}
@@ -238,7 +405,7 @@ func (m *streamManagerV1) processResponseMessage(id int64, resp *responseMessage
 
 // logMessage logs a message only if the level <= currentLogLevel
 func logMessage(level logLevel, format string, args ...any) {
-	if int(level) <= currentLogLevel {
+	if int32(level) <= currentLogLevel.Load() {
 		log.Println(getLogMessage(level, format, args...))
 	}
 }
@@ -265,13 +432,72 @@ func (l logLevel) String() string {
 }
 
 func (m *streamManagerV1) setServerInfo(r *pb1.InitResponse) {
-	m.serverVersion = r.GetVersion()
-	m.serverProtocolVersion = r.GetProtocolVersion()
-	m.serverProxyMemberID = r.GetProxyMemberId()
+	m.serverInfo = ServerInfo{
+		Version:         r.GetVersion(),
+		EncodedVersion:  r.GetEncodedVersion(),
+		ProtocolVersion: r.GetProtocolVersion(),
+		ProxyMemberID:   r.GetProxyMemberId(),
+		ProxyMemberUUID: r.GetProxyMemberUuid(),
+		ClientUUID:      r.GetUuid(),
+	}
 }
 
 func (m *streamManagerV1) String() string {
-	return fmt.Sprintf("Coherence version: %s, serverProtocolVersion: %d, proxyMemberId: %d", m.serverVersion, m.serverProtocolVersion, m.serverProxyMemberID)
+	return fmt.Sprintf("Coherence version: %s, serverProtocolVersion: %d, proxyMemberId: %d",
+		m.serverInfo.Version, m.serverInfo.ProtocolVersion, m.serverInfo.ProxyMemberID)
+}
+
+// ping sends a heartbeat requesting an acknowledgement and waits for the response,
+// returning the round-trip latency. The heartbeat has its own request id, so that it is only
+// acknowledged by its own response, and not by the responses to other heartbeats.
+func (m *streamManagerV1) ping(ctx context.Context) (time.Duration, error) {
+	stream, err := m.ensureStream()
+	if err != nil {
+		return 0, err
+	}
+
+	id := m.session.NextRequestID()
+	ch := make(chan struct{})
+	m.pingMutex.Lock()
+	if m.pings == nil {
+		m.pings = make(map[int64]chan struct{})
+	}
+	m.pings[id] = ch
+	m.pingMutex.Unlock()
+	defer m.removePing(id)
+
+	start := time.Now()
+	if err = m.sendHeartbeat(stream, id); err != nil {
+		return 0, err
+	}
+
+	select {
+	case <-ch:
+		return time.Since(start), nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-stream.done:
+		return 0, fmt.Errorf("%s stream closed before heartbeat was acknowledged", m.proxyProtocol)
+	}
+}
+
+// heartbeatReceived notifies the ping waiting for the heartbeat response with the request id, if any.
+// Responses to periodic heartbeats, and late responses to pings which are no longer waiting, are ignored.
+func (m *streamManagerV1) heartbeatReceived(id int64) {
+	m.pingMutex.Lock()
+	defer m.pingMutex.Unlock()
+
+	if ch, ok := m.pings[id]; ok {
+		close(ch)
+		delete(m.pings, id)
+	}
+}
+
+// removePing removes a ping that is no longer waiting for a response.
+func (m *streamManagerV1) removePing(id int64) {
+	m.pingMutex.Lock()
+	defer m.pingMutex.Unlock()
+	delete(m.pings, id)
 }
 
 func (m *streamManagerV1) processResponse(reqID int64, resp *responseMessage) {
@@ -283,13 +509,13 @@ func (m *streamManagerV1) processResponse(reqID int64, resp *responseMessage) {
 		// we can send the event to the right place
 		cacheID := resp.namedCacheResponse.CacheId
 		if cacheID == 0 {
-			logMessage(WARNING, "received an event %v with cacheID = 0", resp.namedCacheResponse.Type)
+			m.session.log(WARNING, "received an event %v with cacheID = 0", resp.namedCacheResponse.Type)
 			return
 		}
 
-		cacheName := m.session.getCacheNameFromCacheID(cacheID)
+		cacheName := m.cacheIDMap.KeyFromValue(cacheID)
 		if cacheName == nil {
-			logMessage(WARNING, "unable to find cache name from cache id %v it may have been released or destroyed", cacheID)
+			m.session.log(WARNING, "unable to find cache name from cache id %v it may have been released or destroyed", cacheID)
 			return
 		}
 
@@ -301,6 +527,13 @@ func (m *streamManagerV1) processResponse(reqID int64, resp *responseMessage) {
 			client = m.session.getNamedMapClient(*cacheName)
 		}
 
+		// lifecycle events are sent on every stream the cache has been ensured on, so only
+		// dispatch those received on the stream used for the cache's listeners
+		isLifecycleEvent := resp.namedCacheResponse.Type == pb1.ResponseType_Destroyed || resp.namedCacheResponse.Type == pb1.ResponseType_Truncated
+		if isLifecycleEvent && m != m.session.cacheListenerStream(*cacheName) {
+			return
+		}
+
 		if eventSubmitter, ok := client.(EventSubmitter); ok {
 			switch resp.namedCacheResponse.Type {
 			case pb1.ResponseType_Destroyed:
@@ -312,9 +545,9 @@ func (m *streamManagerV1) processResponse(reqID int64, resp *responseMessage) {
 			case pb1.ResponseType_MapEvent:
 				mapEventMessage, err1 := unwrapMapEvent(resp.namedCacheResponse)
 				if err1 != nil {
-					logMessage(WARNING, "unable to unwrap MapEvent: %v", err1)
+					m.session.logAttrs(WARNING, cacheAttrs(*cacheName), "unable to unwrap MapEvent: %v", err1)
 				} else {
-					m.session.debugConnection("received mapEvent: %v", mapEventMessage)
+					m.session.logAttrs(ALL, cacheAttrs(*cacheName), "received mapEvent: %v", mapEventMessage)
 					eventSubmitter.generateMapEvent(client, mapEventMessage)
 				}
 			}
@@ -360,26 +593,47 @@ func (m *streamManagerV1) processResponse(reqID int64, resp *responseMessage) {
 }
 
 // submitRequest submits a request to the stream manager and returns named cache request.
-func (m *streamManagerV1) submitRequest(req *pb1.ProxyRequest, requestType pb1.NamedCacheRequestType) (proxyRequestChannel, error) {
+func (m *streamManagerV1) submitRequest(ctx context.Context, req *pb1.ProxyRequest, requestType pb1.NamedCacheRequestType) (proxyRequestChannel, error) {
+	// faults are injected before the stream is locked, so a delayed request does not delay the others
+	if err := m.session.injectRequestFaults(ctx); err != nil {
+		return proxyRequestChannel{}, err
+	}
+
+	release, err := admitRequest(ctx)
+	if err != nil {
+		return proxyRequestChannel{}, err
+	}
+
+	m.session.applyRequestAttributes(ctx, req)
+	recordRequestSent(ctx, req)
+
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
 	// create a channel for the response
-	ch := make(chan responseMessage)
-
-	r := proxyRequestChannel{ch: ch}
+	r := newProxyRequestChannel(release)
 
 	// save the request in the map keyed by request id
 	m.requests[req.Id] = r
 
-	m.session.debugConnection("id: %v submit request: %v %v", req.Id, requestType, req)
+	m.session.logAttrs(DEBUG, requestAttrs(req.Id), "id: %v submit request: %v", req.Id, requestType)
+	m.session.logAttrs(ALL, requestAttrs(req.Id), "id: %v request: %v", req.Id, req)
 
-	return r, m.eventStream.grpcStream.Send(req)
+	return r, r.sent(m.eventStream.grpcStream.Send(req))
+}
+
+// sent releases the in-flight slot held by the request if it could not be sent, as the
+// request will not be cleaned up, and returns the error.
+func (r proxyRequestChannel) sent(err error) error {
+	if err != nil && r.release != nil {
+		r.release()
+	}
+	return err
 }
 
 // ensureCache issues the ensure cache request. This must be done before any requests to access caches can be issued.
 func (m *streamManagerV1) ensureCache(ctx context.Context, cache string) (*int32, error) {
-	return m.ensure(ctx, cache, m.session.cacheIDMap /** -1 signifies cache **/, -1)
+	return m.ensure(ctx, cache, m.cacheIDMap /** -1 signifies cache **/, -1)
 }
 
 // ensure ensures a queue or cache.
@@ -404,9 +658,9 @@ func (m *streamManagerV1) ensure(ctx context.Context, name string, IDMap safeMap
 	}
 
 	if isQueue {
-		requestType, err = m.submitQueueRequest(req, pb1.NamedQueueRequestType_EnsureQueue)
+		requestType, err = m.submitQueueRequest(ctx, req, pb1.NamedQueueRequestType_EnsureQueue)
 	} else {
-		requestType, err = m.submitRequest(req, pb1.NamedCacheRequestType_EnsureCache)
+		requestType, err = m.submitRequest(ctx, req, pb1.NamedCacheRequestType_EnsureCache)
 	}
 
 	if err != nil {
@@ -420,7 +674,7 @@ func (m *streamManagerV1) ensure(ctx context.Context, name string, IDMap safeMap
 
 	defer m.cleanupRequest(req.Id)
 
-	result, err1 := waitForResponse(newCtx, requestType.ch, true)
+	result, err1 := waitForResponse(newCtx, requestType, true)
 	if err1 != nil {
 		return nil, err1
 	}
@@ -456,8 +710,8 @@ func (m *streamManagerV1) destroyCache(ctx context.Context, cache string) error
 		return err
 	}
 
-	// remove the cache from the map
-	m.session.cacheIDMap.Remove(cache)
+	// remove the cache from the maps for all streams
+	m.session.removeCacheID(cache)
 
 	return nil
 }
@@ -472,7 +726,7 @@ func (m *streamManagerV1) genericCacheRequest(ctx context.Context, reqType pb1.N
 		return err
 	}
 
-	requestType, err := m.submitRequest(req, reqType)
+	requestType, err := m.submitRequest(ctx, req, reqType)
 	if err != nil {
 		return err
 	}
@@ -491,7 +745,7 @@ func (m *streamManagerV1) genericCacheRequest(ctx context.Context, reqType pb1.N
 		return nil
 	}
 
-	_, err1 := waitForResponse(newCtx, requestType.ch)
+	_, err1 := waitForResponse(newCtx, requestType)
 	if err1 != nil {
 		return err1
 	}
@@ -506,7 +760,7 @@ func (m *streamManagerV1) size(ctx context.Context, cache string) (int32, error)
 		return 0, err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_Size)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_Size)
 	if err != nil {
 		return 0, err
 	}
@@ -519,7 +773,7 @@ func (m *streamManagerV1) size(ctx context.Context, cache string) (int32, error)
 	// remove the entry from the channel
 	defer m.cleanupRequest(req.Id)
 
-	return m.returnSizeRequest(newCtx, requestType.ch)
+	return m.returnSizeRequest(newCtx, requestType)
 }
 
 func (m *streamManagerV1) isEmpty(ctx context.Context, cache string) (bool, error) {
@@ -537,7 +791,7 @@ func (m *streamManagerV1) genericBoolValue(ctx context.Context, reqType pb1.Name
 		return false, err
 	}
 
-	requestType, err := m.submitRequest(req, reqType)
+	requestType, err := m.submitRequest(ctx, req, reqType)
 	if err != nil {
 		return false, err
 	}
@@ -549,7 +803,7 @@ func (m *streamManagerV1) genericBoolValue(ctx context.Context, reqType pb1.Name
 
 	defer m.cleanupRequest(req.Id)
 
-	result, err1 := waitForResponse(newCtx, requestType.ch)
+	result, err1 := waitForResponse(newCtx, requestType)
 	if err1 != nil {
 		return false, err1
 	}
@@ -559,12 +813,15 @@ func (m *streamManagerV1) genericBoolValue(ctx context.Context, reqType pb1.Name
 
 // get issues a get request for a given key and returns the bytes value which could be nil.
 func (m *streamManagerV1) get(ctx context.Context, cache string, key []byte) (*[]byte, error) {
-	req, err := m.newGetRequest(cache, key)
+	buffer := getBuffer()
+	defer buffer.release()
+
+	req, err := m.newGetRequest(buffer, cache, key)
 	if err != nil {
 		return nil, err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_Get)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_Get)
 	if err != nil {
 		return nil, err
 	}
@@ -577,7 +834,7 @@ func (m *streamManagerV1) get(ctx context.Context, cache string, key []byte) (*[
 	// remove the entry from the channel
 	defer m.cleanupRequest(req.Id)
 
-	result, err1 := waitForResponse(newCtx, requestType.ch)
+	result, err1 := waitForResponse(newCtx, requestType)
 	if err1 != nil {
 		return nil, err1
 	}
@@ -604,7 +861,7 @@ func (m *streamManagerV1) mapListenerRequest(ctx context.Context, cache string,
 		return err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_MapListener)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_MapListener)
 	if err != nil {
 		return err
 	}
@@ -617,7 +874,7 @@ func (m *streamManagerV1) mapListenerRequest(ctx context.Context, cache string,
 	// remove the entry from the channel
 	defer m.cleanupRequest(req.Id)
 
-	_, err1 := waitForResponse(newCtx, requestType.ch)
+	_, err1 := waitForResponse(newCtx, requestType)
 	if err1 != nil {
 		return err1
 	}
@@ -631,7 +888,7 @@ func (m *streamManagerV1) replaceMapping(ctx context.Context, cache string, key
 		return false, err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_ReplaceMapping)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_ReplaceMapping)
 	if err != nil {
 		return false, err
 	}
@@ -644,7 +901,7 @@ func (m *streamManagerV1) replaceMapping(ctx context.Context, cache string, key
 	// remove the entry from the channel
 	defer m.cleanupRequest(req.Id)
 
-	result, err1 := waitForResponse(newCtx, requestType.ch)
+	result, err1 := waitForResponse(newCtx, requestType)
 	if err1 != nil {
 		return false, err1
 	}
@@ -663,7 +920,7 @@ func (m *streamManagerV1) remove(ctx context.Context, cache string, key []byte)
 }
 
 func (m *streamManagerV1) genericRequest(ctx context.Context, req *pb1.ProxyRequest, namedCacheReqType pb1.NamedCacheRequestType) (*[]byte, error) {
-	requestType, err := m.submitRequest(req, namedCacheReqType)
+	requestType, err := m.submitRequest(ctx, req, namedCacheReqType)
 	if err != nil {
 		return nil, err
 	}
@@ -676,7 +933,7 @@ func (m *streamManagerV1) genericRequest(ctx context.Context, req *pb1.ProxyRequ
 	// remove the entry from the channel
 	defer m.cleanupRequest(req.Id)
 
-	result, err1 := waitForResponse(newCtx, requestType.ch)
+	result, err1 := waitForResponse(newCtx, requestType)
 	if err1 != nil {
 		return nil, err1
 	}
@@ -708,7 +965,7 @@ func (m *streamManagerV1) removeMapping(ctx context.Context, cache string, key [
 		return false, err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_RemoveMapping)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_RemoveMapping)
 	if err != nil {
 		return false, err
 	}
@@ -721,7 +978,7 @@ func (m *streamManagerV1) removeMapping(ctx context.Context, cache string, key [
 	// remove the entry from the channel
 	defer m.cleanupRequest(req.Id)
 
-	result, err1 := waitForResponse(newCtx, requestType.ch)
+	result, err1 := waitForResponse(newCtx, requestType)
 	if err1 != nil {
 		return false, err1
 	}
@@ -757,7 +1014,7 @@ func (m *streamManagerV1) containsKey(ctx context.Context, cache string, key []b
 
 // containsRequest creates containKeys or containsValue requests.
 func (m *streamManagerV1) containsRequest(ctx context.Context, reqType pb1.NamedCacheRequestType, req *pb1.ProxyRequest) (bool, error) {
-	requestType, err := m.submitRequest(req, reqType)
+	requestType, err := m.submitRequest(ctx, req, reqType)
 	if err != nil {
 		return false, err
 	}
@@ -770,7 +1027,7 @@ func (m *streamManagerV1) containsRequest(ctx context.Context, reqType pb1.Named
 	// remove the entry from the channel
 	defer m.cleanupRequest(req.Id)
 
-	result, err1 := waitForResponse(newCtx, requestType.ch)
+	result, err1 := waitForResponse(newCtx, requestType)
 	if err1 != nil {
 		return false, err1
 	}
@@ -788,7 +1045,7 @@ func (m *streamManagerV1) putAll(ctx context.Context, cache string, entries []*p
 		return err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_PutAll)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_PutAll)
 	if err != nil {
 		return err
 	}
@@ -800,7 +1057,7 @@ func (m *streamManagerV1) putAll(ctx context.Context, cache string, entries []*p
 
 	defer m.cleanupRequest(req.Id)
 
-	_, err = waitForResponse(newCtx, requestType.ch)
+	_, err = waitForResponse(newCtx, requestType)
 	return err
 }
 
@@ -818,7 +1075,7 @@ func (m *streamManagerV1) index(ctx context.Context, cache string, add bool, bin
 		return err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_Index)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_Index)
 	if err != nil {
 		return err
 	}
@@ -830,7 +1087,7 @@ func (m *streamManagerV1) index(ctx context.Context, cache string, add bool, bin
 
 	defer m.cleanupRequest(req.Id)
 
-	_, err = waitForResponse(newCtx, requestType.ch)
+	_, err = waitForResponse(newCtx, requestType)
 	return err
 }
 
@@ -840,12 +1097,15 @@ func (m *streamManagerV1) putIfAbsent(ctx context.Context, cache string, key []b
 
 // putGenericRequest created a generic put requests, used by put and putIfAbsent.
 func (m *streamManagerV1) putGenericRequest(ctx context.Context, reqType pb1.NamedCacheRequestType, cache string, key []byte, value []byte, ttl time.Duration) (*[]byte, error) {
-	req, err := m.newPutRequest(reqType, cache, key, value, ttl)
+	buffer := getBuffer()
+	defer buffer.release()
+
+	req, err := m.newPutRequest(buffer, reqType, cache, key, value, ttl)
 	if err != nil {
 		return nil, err
 	}
 
-	requestType, err := m.submitRequest(req, reqType)
+	requestType, err := m.submitRequest(ctx, req, reqType)
 	if err != nil {
 		return nil, err
 	}
@@ -857,7 +1117,7 @@ func (m *streamManagerV1) putGenericRequest(ctx context.Context, reqType pb1.Nam
 
 	defer m.cleanupRequest(req.Id)
 
-	result, err1 := waitForResponse(newCtx, requestType.ch)
+	result, err1 := waitForResponse(newCtx, requestType)
 	if err1 != nil {
 		return nil, err1
 	}
@@ -887,13 +1147,15 @@ type BinaryValue struct {
 	Cookie []byte
 }
 
-func (m *streamManagerV1) getAll(ctx context.Context, cache string, keys [][]byte) (<-chan BinaryKeyAndValue, error) {
-	req, err := m.newGetAllRequest(cache, keys)
+// getAll issues a get all request, which is marshaled into the buffer, so the buffer must not be released
+// until all the entries have been received.
+func (m *streamManagerV1) getAll(ctx context.Context, cache string, keys [][]byte, buffer *pooledBuffer) (<-chan BinaryKeyAndValue, error) {
+	req, err := m.newGetAllRequest(buffer, cache, keys)
 	if err != nil {
 		return nil, err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_GetAll)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_GetAll)
 	if err != nil {
 		return nil, err
 	}
@@ -907,7 +1169,7 @@ func (m *streamManagerV1) keyAndValuePage(ctx context.Context, cache string, coo
 		return nil, err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_PageOfEntries)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_PageOfEntries)
 	if err != nil {
 		return nil, err
 	}
@@ -921,7 +1183,7 @@ func (m *streamManagerV1) keyPage(ctx context.Context, cache string, cookie []by
 		return nil, err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_PageOfKeys)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_PageOfKeys)
 	if err != nil {
 		return nil, err
 	}
@@ -935,7 +1197,7 @@ func (m *streamManagerV1) invoke(ctx context.Context, cache string, agent []byte
 		return nil, err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_Invoke)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_Invoke)
 	if err != nil {
 		return nil, err
 	}
@@ -949,7 +1211,7 @@ func (m *streamManagerV1) entrySetFilter(ctx context.Context, cache string, filt
 		return nil, err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_QueryEntries)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_QueryEntries)
 	if err != nil {
 		return nil, err
 	}
@@ -963,7 +1225,7 @@ func (m *streamManagerV1) keySetFilter(ctx context.Context, cache string, filter
 		return nil, err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_QueryKeys)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_QueryKeys)
 	if err != nil {
 		return nil, err
 	}
@@ -977,7 +1239,7 @@ func (m *streamManagerV1) valuesFilter(ctx context.Context, cache string, filter
 		return nil, err
 	}
 
-	requestType, err := m.submitRequest(req, pb1.NamedCacheRequestType_QueryValues)
+	requestType, err := m.submitRequest(ctx, req, pb1.NamedCacheRequestType_QueryValues)
 	if err != nil {
 		return nil, err
 	}
@@ -998,7 +1260,7 @@ func (m *streamManagerV1) getStreamingResponseKeyAndValue(ctx context.Context, r
 	newCtx, cancel := m.session.ensureContext(ctx)
 
 	// this channel will receive entries back from the stream
-	respChannel := waitForStreamingResponse(newCtx, requestType.ch)
+	respChannel := waitForStreamingResponse(newCtx, requestType)
 
 	go func() {
 		isFirst := true
@@ -1012,6 +1274,12 @@ func (m *streamManagerV1) getStreamingResponseKeyAndValue(ctx context.Context, r
 		for {
 			select {
 			case resp := <-respChannel:
+				if resp.disconnected {
+					ch <- BinaryKeyAndValue{Err: ErrStreamDisconnected}
+					close(ch)
+					return
+				}
+
 				// received a streamed response of type pb1.BinaryKeyAndValue
 				var response = BinaryKeyAndValue{}
 				if resp.err != nil {
@@ -1073,7 +1341,7 @@ func (m *streamManagerV1) getStreamingResponseValue(ctx context.Context, request
 	newCtx, cancel := m.session.ensureContext(ctx)
 
 	// this channel will receive entries back from the stream
-	respChannel := waitForStreamingResponse(newCtx, requestType.ch)
+	respChannel := waitForStreamingResponse(newCtx, requestType)
 
 	go func() {
 		isFirst := true
@@ -1087,6 +1355,12 @@ func (m *streamManagerV1) getStreamingResponseValue(ctx context.Context, request
 		for {
 			select {
 			case resp := <-respChannel:
+				if resp.disconnected {
+					ch <- BinaryValue{Err: ErrStreamDisconnected}
+					close(ch)
+					return
+				}
+
 				// received a streamed response of type pb1.BinaryValue
 				var response = BinaryValue{}
 				if resp.err != nil {
@@ -1146,7 +1420,7 @@ func (m *streamManagerV1) getStreamingResponseKey(ctx context.Context, requestTy
 	newCtx, cancel := m.session.ensureContext(ctx)
 
 	// this channel will receive entries back from the stream
-	respChannel := waitForStreamingResponse(newCtx, requestType.ch)
+	respChannel := waitForStreamingResponse(newCtx, requestType)
 
 	go func() {
 		isFirst := true
@@ -1160,6 +1434,12 @@ func (m *streamManagerV1) getStreamingResponseKey(ctx context.Context, requestTy
 		for {
 			select {
 			case resp := <-respChannel:
+				if resp.disconnected {
+					ch <- BinaryKey{Err: ErrStreamDisconnected}
+					close(ch)
+					return
+				}
+
 				// received a streamed response of type pb1.BytesValue
 				var response = BinaryKey{}
 				if resp.err != nil {
@@ -1214,6 +1494,9 @@ func (m *streamManagerV1) cleanupRequest(reqID int64) {
 	requestType := m.requests[reqID]
 	delete(m.requests, reqID)
 	close(requestType.ch)
+	if requestType.release != nil {
+		requestType.release()
+	}
 }
 
 // defaultFunction returns the default named cache message
@@ -1228,7 +1511,7 @@ var defaultFunction = func(resp responseMessage) *anypb.Any {
 }
 
 // waitForResponse waits for a response to a request and returns the proto message and any error.
-func waitForResponse(newCtx context.Context, ch chan responseMessage, ensure ...bool) (*anypb.Any, error) {
+func waitForResponse(newCtx context.Context, r proxyRequestChannel, ensure ...bool) (*anypb.Any, error) {
 	var (
 		err      error
 		result   *anypb.Any
@@ -1240,7 +1523,8 @@ func waitForResponse(newCtx context.Context, ch chan responseMessage, ensure ...
 	for {
 		// wait on the channel
 		select {
-		case resp := <-ch:
+		case resp := <-r.ch:
+			recordResponseReceived(newCtx, resp)
 			if resp.err != nil {
 				err = fmt.Errorf(errorFormat, *resp.err)
 				// force complete on error
@@ -1265,6 +1549,8 @@ func waitForResponse(newCtx context.Context, ch chan responseMessage, ensure ...
 			if resp.complete {
 				return result, err
 			}
+		case <-r.abandoned:
+			return nil, ErrStreamDisconnected
 		case <-newCtx.Done():
 			errDone := newCtx.Err()
 			if !errors.Is(errDone, context.Canceled) {
@@ -1276,7 +1562,7 @@ func waitForResponse(newCtx context.Context, ch chan responseMessage, ensure ...
 }
 
 // waitForStreamingResponse
-func waitForStreamingResponse(newCtx context.Context, ch chan responseMessage) <-chan responseMessage {
+func waitForStreamingResponse(newCtx context.Context, r proxyRequestChannel) <-chan responseMessage {
 	var chMessage = make(chan responseMessage) // channel to send back to caller
 
 	go func() {
@@ -1284,7 +1570,8 @@ func waitForStreamingResponse(newCtx context.Context, ch chan responseMessage) <
 		for {
 			// wait on the channel
 			select {
-			case resp := <-ch:
+			case resp := <-r.ch:
+				recordResponseReceived(newCtx, resp)
 				var response = responseMessage{}
 				if resp.err != nil {
 					response.err = resp.err
@@ -1300,6 +1587,10 @@ func waitForStreamingResponse(newCtx context.Context, ch chan responseMessage) <
 				if resp.complete {
 					return
 				}
+			case <-r.abandoned:
+				message := ErrStreamDisconnected.Error()
+				chMessage <- responseMessage{err: &message, complete: true, disconnected: true}
+				return
 			case <-newCtx.Done(): // timeout or cancel
 				errDone := newCtx.Err()
 				if !errors.Is(errDone, context.Canceled) {
@@ -103,7 +103,7 @@ func addKeyListenerInternalV1[K comparable, V any](ctx context.Context, bc *base
 
 	group, lPresent := bc.keyListenersV1[key]
 	if !lPresent {
-		groupInner, err := makeKeyListenerGroupV1(bc.session.v1StreamManagerCache, bc, key)
+		groupInner, err := makeKeyListenerGroupV1(bc.session.cacheListenerStream(bc.name), bc, key)
 		if err != nil {
 			return err
 		}
@@ -126,7 +126,7 @@ func addFilterListenerInternalV1[K comparable, V any](ctx context.Context, bc *b
 
 	group, lPresent := bc.filterListenersV1[filterLocal]
 	if !lPresent {
-		groupInner, err := makeFilterListenerGroupV1(bc.session.v1StreamManagerCache, bc, filterLocal)
+		groupInner, err := makeFilterListenerGroupV1(bc.session.cacheListenerStream(bc.name), bc, filterLocal)
 		if err != nil {
 			return err
 		}
@@ -84,7 +84,7 @@ func (it *streamedKeyIteratorV1[K, V]) getNextPage() error {
 		defer cancel()
 	}
 
-	ch, err := it.bc.session.v1StreamManagerCache.keyPage(newCtx, it.bc.name, it.cookie)
+	ch, err := it.bc.session.cacheListenerStream(it.bc.name).keyPage(newCtx, it.bc.name, it.cookie)
 	if err != nil {
 		return err
 	}
@@ -186,7 +186,7 @@ func (it *streamedEntryIteratorV1[K, V]) getNextPage() error {
 	// reset the data
 	it.dataList = list.New()
 
-	ch, err := it.bc.session.v1StreamManagerCache.keyAndValuePage(newCtx, it.bc.name, it.cookie)
+	ch, err := it.bc.session.cacheListenerStream(it.bc.name).keyAndValuePage(newCtx, it.bc.name, it.cookie)
 	if err != nil {
 		return err
 	}
@@ -21,30 +21,49 @@ func (m *streamManagerV1) ensureQueue(ctx context.Context, queue string, queueTy
 }
 
 // submitRequest submits a request to the stream manager and returns named queue request.
-func (m *streamManagerV1) submitQueueRequest(req *pb1.ProxyRequest, requestType pb1.NamedQueueRequestType) (proxyRequestChannel, error) {
+func (m *streamManagerV1) submitQueueRequest(ctx context.Context, req *pb1.ProxyRequest, requestType pb1.NamedQueueRequestType) (proxyRequestChannel, error) {
+	// faults are injected before the stream is locked, so a delayed request does not delay the others
+	if err := m.session.injectRequestFaults(ctx); err != nil {
+		return proxyRequestChannel{}, err
+	}
+
+	release, err := admitRequest(ctx)
+	if err != nil {
+		return proxyRequestChannel{}, err
+	}
+
+	m.session.applyRequestAttributes(ctx, req)
+	recordRequestSent(ctx, req)
+
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
 	// create a channel for the response
-	ch := make(chan responseMessage)
-
-	r := proxyRequestChannel{ch: ch}
+	r := newProxyRequestChannel(release)
 
 	// save the request in the map keyed by request id
 	m.requests[req.Id] = r
-	m.session.debugConnection("id: %v submit queue request: %v %v", req.Id, requestType, req)
+	m.session.logAttrs(DEBUG, requestAttrs(req.Id), "id: %v submit queue request: %v", req.Id, requestType)
+	m.session.logAttrs(ALL, requestAttrs(req.Id), "id: %v queue request: %v", req.Id, req)
 
-	return r, m.eventStream.grpcStream.Send(req)
+	return r, r.sent(m.eventStream.grpcStream.Send(req))
 }
 
 // genericCacheRequest issues a generic request that is further defined by the reqType.
-func (m *streamManagerV1) genericQueueRequest(ctx context.Context, reqType pb1.NamedQueueRequestType, queue string) error {
+func (m *streamManagerV1) genericQueueRequest(ctx context.Context, reqType pb1.NamedQueueRequestType, queue string) (err error) {
+	ctx, op := m.session.startOperation(ctx, queue, reqType.String(), true)
+	defer func() { op.end(err) }()
+
+	if m.session.shuttingDown.Load() {
+		return ErrShuttingDown
+	}
+
 	req, err := m.newGenericNamedQueueRequest(queue, reqType)
 	if err != nil {
 		return err
 	}
 
-	requestType, err := m.submitQueueRequest(req, reqType)
+	requestType, err := m.submitQueueRequest(ctx, req, reqType)
 	if err != nil {
 		return err
 	}
@@ -63,7 +82,7 @@ func (m *streamManagerV1) genericQueueRequest(ctx context.Context, reqType pb1.N
 		return nil
 	}
 
-	_, err1 := waitForResponse(newCtx, requestType.ch)
+	_, err1 := waitForResponse(newCtx, requestType)
 	if err1 != nil {
 		return err1
 	}
@@ -72,13 +91,20 @@ func (m *streamManagerV1) genericQueueRequest(ctx context.Context, reqType pb1.N
 }
 
 // size returns the size of a cache
-func (m *streamManagerV1) sizeQueue(ctx context.Context, queue string) (int32, error) {
+func (m *streamManagerV1) sizeQueue(ctx context.Context, queue string) (_ int32, err error) {
+	ctx, op := m.session.startOperation(ctx, queue, pb1.NamedQueueRequestType_Size.String(), true)
+	defer func() { op.end(err) }()
+
+	if m.session.shuttingDown.Load() {
+		return 0, ErrShuttingDown
+	}
+
 	req, err := m.newGenericNamedQueueRequest(queue, pb1.NamedQueueRequestType_Size)
 	if err != nil {
 		return 0, err
 	}
 
-	requestType, err := m.submitQueueRequest(req, pb1.NamedQueueRequestType_Size)
+	requestType, err := m.submitQueueRequest(ctx, req, pb1.NamedQueueRequestType_Size)
 	if err != nil {
 		return 0, err
 	}
@@ -91,7 +117,7 @@ func (m *streamManagerV1) sizeQueue(ctx context.Context, queue string) (int32, e
 	// remove the entry from the channel
 	defer m.cleanupRequest(req.Id)
 
-	return m.returnSizeRequest(newCtx, requestType.ch)
+	return m.returnSizeRequest(newCtx, requestType)
 }
 
 func (m *streamManagerV1) newOfferTail(reqType pb1.NamedQueueRequestType, queue string, value []byte) (*pb1.ProxyRequest, error) {
@@ -105,8 +131,8 @@ func (m *streamManagerV1) newOfferTail(reqType pb1.NamedQueueRequestType, queue
 	return m.newWrapperProxyQueueRequest(queue, reqType, anyReq)
 }
 
-func (m *streamManagerV1) returnSizeRequest(newCtx context.Context, ch chan responseMessage) (int32, error) {
-	result, err := waitForResponse(newCtx, ch)
+func (m *streamManagerV1) returnSizeRequest(newCtx context.Context, r proxyRequestChannel) (int32, error) {
+	result, err := waitForResponse(newCtx, r)
 	if err != nil {
 		return 0, err
 	}
@@ -121,13 +147,20 @@ func (m *streamManagerV1) returnSizeRequest(newCtx context.Context, ch chan resp
 }
 
 // genericBoolValue returns a boolean value based upon a request type.
-func (m *streamManagerV1) genericBoolValueQueue(ctx context.Context, reqType pb1.NamedQueueRequestType, cache string) (bool, error) {
+func (m *streamManagerV1) genericBoolValueQueue(ctx context.Context, reqType pb1.NamedQueueRequestType, cache string) (_ bool, err error) {
+	ctx, op := m.session.startOperation(ctx, cache, reqType.String(), true)
+	defer func() { op.end(err) }()
+
+	if m.session.shuttingDown.Load() {
+		return false, ErrShuttingDown
+	}
+
 	req, err := m.newGenericNamedQueueRequest(cache, reqType)
 	if err != nil {
 		return false, err
 	}
 
-	requestType, err := m.submitQueueRequest(req, reqType)
+	requestType, err := m.submitQueueRequest(ctx, req, reqType)
 	if err != nil {
 		return false, err
 	}
@@ -139,7 +172,7 @@ func (m *streamManagerV1) genericBoolValueQueue(ctx context.Context, reqType pb1
 
 	defer m.cleanupRequest(req.Id)
 
-	result, err1 := waitForResponse(newCtx, requestType.ch)
+	result, err1 := waitForResponse(newCtx, requestType)
 	if err1 != nil {
 		return false, err1
 	}
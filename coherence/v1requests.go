@@ -8,6 +8,7 @@ package coherence
 
 import (
 	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	"time"
@@ -15,6 +16,12 @@ import (
 
 var (
 	emptyByte = make([]byte, 0)
+
+	// the type URLs of the messages marshaled by newPooledRequest, as created by anypb.New
+	bytesValueURL        = anyTypeURL(&wrapperspb.BytesValue{})
+	putRequestURL        = anyTypeURL(&pb1.PutRequest{})
+	bytesValuesURL       = anyTypeURL(&pb1.CollectionOfBytesValues{})
+	namedCacheRequestURL = anyTypeURL(&pb1.NamedCacheRequest{})
 )
 
 func (m *streamManagerV1) newInitRequest() *pb1.ProxyRequest {
@@ -27,6 +34,12 @@ func (m *streamManagerV1) newInitRequest() *pb1.ProxyRequest {
 		Protocol:                 string(m.proxyProtocol),
 	}
 
+	// request the server to send heartbeats at the same interval as the client
+	if interval := m.session.sessOpts.HeartbeatInterval; interval > 0 {
+		millis := interval.Milliseconds()
+		req.Heartbeat = &millis
+	}
+
 	pr := pb1.ProxyRequest{
 		Id: m.session.NextRequestID(),
 		Request: &pb1.ProxyRequest_Init{
@@ -37,6 +50,20 @@ func (m *streamManagerV1) newInitRequest() *pb1.ProxyRequest {
 	return &pr
 }
 
+// newHeartbeatRequest creates a heartbeat request with the specified id, which is echoed in the heartbeat response.
+// Periodic heartbeats use an id of zero, whereas a ping uses a request id so that it can match its response.
+func (m *streamManagerV1) newHeartbeatRequest(id int64) *pb1.ProxyRequest {
+	return &pb1.ProxyRequest{
+		Id: id,
+		Request: &pb1.ProxyRequest_Heartbeat{
+			Heartbeat: &pb1.HeartbeatMessage{
+				Uuid: m.session.sessionID[:],
+				Ack:  true,
+			},
+		},
+	}
+}
+
 func (m *streamManagerV1) newEnsureCacheRequest(cache string) (*pb1.ProxyRequest, error) {
 	req := &pb1.EnsureCacheRequest{
 		Cache: cache,
@@ -82,8 +109,9 @@ func (m *streamManagerV1) newGenericNamedQueueRequest(cache string, requestType
 	return m.newWrapperProxyQueueRequest(cache, requestType, nil)
 }
 
-func (m *streamManagerV1) newGetRequest(cache string, key []byte) (*pb1.ProxyRequest, error) {
-	return m.newSingleValueBasedRequest(pb1.NamedCacheRequestType_Get, cache, key)
+// newGetRequest creates a get request marshaled into the buffer.
+func (m *streamManagerV1) newGetRequest(buffer *pooledBuffer, cache string, key []byte) (*pb1.ProxyRequest, error) {
+	return m.newPooledRequest(buffer, cache, pb1.NamedCacheRequestType_Get, bytesValueURL, wrapperspb.Bytes(key))
 }
 
 func (m *streamManagerV1) newRemoveRequest(cache string, key []byte) (*pb1.ProxyRequest, error) {
@@ -157,7 +185,8 @@ func (m *streamManagerV1) newPageOfKeysRequest(cache string, cookie []byte) (*pb
 	return m.newWrapperProxyRequest(cache, pb1.NamedCacheRequestType_PageOfKeys, anyReq)
 }
 
-func (m *streamManagerV1) newPutRequest(reqType pb1.NamedCacheRequestType, cache string, key []byte, value []byte, ttl time.Duration) (*pb1.ProxyRequest, error) {
+// newPutRequest creates a put request marshaled into the buffer.
+func (m *streamManagerV1) newPutRequest(buffer *pooledBuffer, reqType pb1.NamedCacheRequestType, cache string, key []byte, value []byte, ttl time.Duration) (*pb1.ProxyRequest, error) {
 	millis := ttl.Milliseconds()
 	putRequest := &pb1.PutRequest{
 		Key:   key,
@@ -165,12 +194,7 @@ func (m *streamManagerV1) newPutRequest(reqType pb1.NamedCacheRequestType, cache
 		Ttl:   &millis,
 	}
 
-	anyReq, err := anypb.New(putRequest)
-	if err != nil {
-		return nil, err
-	}
-
-	return m.newWrapperProxyRequest(cache, reqType, anyReq)
+	return m.newPooledRequest(buffer, cache, reqType, putRequestURL, putRequest)
 }
 
 func (m *streamManagerV1) newMapListenerRequest(cache string, subscribe bool, keyOrFilter *pb1.KeyOrFilter, filterID int64,
@@ -238,17 +262,13 @@ func (m *streamManagerV1) newPutAllRequest(cache string, entries []*pb1.BinaryKe
 	return m.newWrapperProxyRequest(cache, pb1.NamedCacheRequestType_PutAll, anyReq)
 }
 
-func (m *streamManagerV1) newGetAllRequest(cache string, keys [][]byte) (*pb1.ProxyRequest, error) {
+// newGetAllRequest creates a get all request marshaled into the buffer.
+func (m *streamManagerV1) newGetAllRequest(buffer *pooledBuffer, cache string, keys [][]byte) (*pb1.ProxyRequest, error) {
 	getAllRequest := &pb1.CollectionOfBytesValues{
 		Values: keys,
 	}
 
-	anyReq, err := anypb.New(getAllRequest)
-	if err != nil {
-		return nil, err
-	}
-
-	return m.newWrapperProxyRequest(cache, pb1.NamedCacheRequestType_GetAll, anyReq)
+	return m.newPooledRequest(buffer, cache, pb1.NamedCacheRequestType_GetAll, bytesValuesURL, getAllRequest)
 }
 
 func (m *streamManagerV1) newInvokeRequest(cache string, agent []byte, keysOrFilter *pb1.KeysOrFilter) (*pb1.ProxyRequest, error) {
@@ -363,7 +383,7 @@ func (m *streamManagerV1) newWrapperProxyRequest(cache string, requestType pb1.N
 
 	// validate the cache ID if it is not an ensure cache request
 	if cache != "" {
-		cacheID = m.session.getCacheID(cache)
+		cacheID = m.cacheIDMap.Get(cache)
 		if cacheID == nil {
 			return nil, getCacheIDMessage(cache)
 		}
@@ -397,3 +417,46 @@ func (m *streamManagerV1) newWrapperProxyQueueRequest(queue string, requestType
 
 	return m.newProxyRequest(ncRequest), nil
 }
+
+// newPooledRequest creates a request for a cache where the message and the named cache request are marshaled
+// into the buffer rather than allocated, for frequently issued requests. As the request references the buffer,
+// the buffer must not be released until the request is complete.
+func (m *streamManagerV1) newPooledRequest(buffer *pooledBuffer, cache string, requestType pb1.NamedCacheRequestType,
+	typeURL string, message proto.Message) (*pb1.ProxyRequest, error) {
+	cacheID := m.cacheIDMap.Get(cache)
+	if cacheID == nil {
+		return nil, getCacheIDMessage(cache)
+	}
+
+	anyReq, err := marshalAny(buffer, typeURL, message)
+	if err != nil {
+		return nil, err
+	}
+
+	ncRequest, err := marshalAny(buffer, namedCacheRequestURL, &pb1.NamedCacheRequest{
+		Type:    requestType,
+		CacheId: cacheID,
+		Message: anyReq,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.newProxyRequest(ncRequest), nil
+}
+
+// marshalAny appends the marshaled message to the buffer and returns an [anypb.Any] containing it.
+func marshalAny(buffer *pooledBuffer, typeURL string, message proto.Message) (*anypb.Any, error) {
+	start := len(buffer.data)
+	data, err := proto.MarshalOptions{}.MarshalAppend(buffer.data, message)
+	if err != nil {
+		return nil, err
+	}
+	buffer.data = data
+	return &anypb.Any{TypeUrl: typeURL, Value: data[start:len(data):len(data)]}, nil
+}
+
+// anyTypeURL returns the type URL used by anypb.New for the message.
+func anyTypeURL(message proto.Message) string {
+	return "type.googleapis.com/" + string(message.ProtoReflect().Descriptor().FullName())
+}
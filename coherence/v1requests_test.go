@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package coherence
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	pb1 "github.com/oracle/coherence-go-client/v2/proto/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func newRequestTestManager() *streamManagerV1 {
+	s := &Session{sessOpts: &SessionOptions{}}
+	m := &streamManagerV1{session: s, cacheIDMap: newSafeIDMap()}
+	m.cacheIDMap.Add("cache", 1)
+	return m
+}
+
+func TestPooledRequest(t *testing.T) {
+	var (
+		m      = newRequestTestManager()
+		buffer = getBuffer()
+		key    = []byte("key")
+		value  = []byte("value")
+	)
+	defer buffer.release()
+
+	req, err := m.newPutRequest(buffer, pb1.NamedCacheRequestType_Put, "cache", key, value, time.Second)
+	if err != nil {
+		t.Fatalf("unable to create request: %v", err)
+	}
+
+	// the request must be the same as one created using anypb.New
+	millis := time.Second.Milliseconds()
+	message, _ := anypb.New(&pb1.PutRequest{Key: key, Value: value, Ttl: &millis})
+	expected, _ := m.newWrapperProxyRequest("cache", pb1.NamedCacheRequestType_Put, message)
+	expected.Id = req.Id
+	if !proto.Equal(req, expected) {
+		t.Fatalf("expected %v, got %v", expected, req)
+	}
+
+	// the messages are marshaled into the buffer rather than allocated
+	if !bytes.Contains(buffer.data, key) || !bytes.Contains(buffer.data, value) {
+		t.Fatalf("expected the request to be marshaled into the buffer")
+	}
+
+	if _, err = m.newGetRequest(buffer, "unknown", key); err == nil {
+		t.Fatalf("expected an error for a cache which has not been ensured")
+	}
+}
+
+func BenchmarkPutRequest(b *testing.B) {
+	var (
+		m     = newRequestTestManager()
+		key   = []byte("key")
+		value = bytes.Repeat([]byte("value"), 20)
+	)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buffer := getBuffer()
+		if _, err := m.newPutRequest(buffer, pb1.NamedCacheRequestType_Put, "cache", key, value, 0); err != nil {
+			b.Fatal(err)
+		}
+		buffer.release()
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package zstdcompression
+
+import (
+	"github.com/klauspost/compress/zstd"
+	"github.com/oracle/coherence-go-client/v2/coherence"
+)
+
+const (
+	// BestSpeed is the zstd compression level with the fastest compression.
+	BestSpeed = 1
+
+	// DefaultLevel is the default zstd compression level.
+	DefaultLevel = 3
+
+	// BestCompression is the zstd compression level with the best compression.
+	BestCompression = 19
+)
+
+var _ coherence.Compressor = &Compressor{}
+
+// Compressor is a [coherence.Compressor] which uses zstd. It is safe for concurrent use.
+type Compressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+// New returns a [Compressor] which uses the specified zstd compression level, such as [BestSpeed]
+// or [DefaultLevel]. The level is mapped to the nearest level supported by the encoder.
+func New(level int) (*Compressor, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	if err != nil {
+		return nil, err
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		_ = encoder.Close()
+		return nil, err
+	}
+
+	return &Compressor{encoder: encoder, decoder: decoder}, nil
+}
+
+// ID returns [coherence.CompressionZstd].
+func (c *Compressor) ID() byte {
+	return coherence.CompressionZstd
+}
+
+// Compress returns the zstd compressed data.
+func (c *Compressor) Compress(data []byte) ([]byte, error) {
+	return c.encoder.EncodeAll(data, nil), nil
+}
+
+// Decompress returns the data decompressed from zstd.
+func (c *Compressor) Decompress(data []byte) ([]byte, error) {
+	return c.decoder.DecodeAll(data, nil)
+}
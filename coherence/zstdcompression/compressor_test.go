@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+package zstdcompression
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/oracle/coherence-go-client/v2/coherence"
+)
+
+func TestCompressor(t *testing.T) {
+	compressor, err := New(DefaultLevel)
+	if err != nil {
+		t.Fatalf("unable to create compressor: %v", err)
+	}
+	if compressor.ID() != coherence.CompressionZstd {
+		t.Fatalf("expected id %d, got %d", coherence.CompressionZstd, compressor.ID())
+	}
+
+	data := []byte(strings.Repeat("coherence ", 1000))
+	compressed, err := compressor.Compress(data)
+	if err != nil || len(compressed) >= len(data) {
+		t.Fatalf("expected the data to be compressed, got %d bytes, %v", len(compressed), err)
+	}
+
+	decompressed, err := compressor.Decompress(compressed)
+	if err != nil || !bytes.Equal(decompressed, data) {
+		t.Fatalf("expected the data to be decompressed, got %d bytes, %v", len(decompressed), err)
+	}
+
+	if _, err = compressor.Decompress([]byte("not zstd")); err == nil {
+		t.Fatalf("expected an error decompressing invalid data")
+	}
+}
+
+func TestValueCompression(t *testing.T) {
+	ctx := context.Background()
+
+	compressor, err := New(BestSpeed)
+	if err != nil {
+		t.Fatalf("unable to create compressor: %v", err)
+	}
+
+	namedMap, err := coherence.NewLocalNamedMap[int, string]("documents", coherence.WithValueCompression(compressor, 100))
+	if err != nil {
+		t.Fatalf("unable to create map: %v", err)
+	}
+
+	value := strings.Repeat("coherence ", 100)
+	if _, err = namedMap.Put(ctx, 1, value); err != nil {
+		t.Fatalf("unable to put: %v", err)
+	}
+	if v, err := namedMap.Get(ctx, 1); err != nil || v == nil || *v != value {
+		t.Fatalf("expected the value to be read, got %v", err)
+	}
+}
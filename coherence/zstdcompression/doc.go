@@ -0,0 +1,25 @@
+/*
+ * Copyright (c) 2025 Oracle and/or its affiliates.
+ * Licensed under the Universal Permissive License v 1.0 as shown at
+ * https://oss.oracle.com/licenses/upl.
+ */
+
+/*
+Package zstdcompression provides a [coherence.Compressor] which compresses values using zstd, for use with
+[coherence.WithValueCompression]. It is a separate module so that the zstd dependency, github.com/klauspost/compress,
+is only required by applications which use it.
+
+Example:
+
+	compressor, err := zstdcompression.New(zstdcompression.DefaultLevel)
+	if err != nil {
+	    log.Fatal(err)
+	}
+
+	namedMap, err := coherence.GetNamedMap[string, Document](session, "documents",
+	    coherence.WithValueCompression(compressor, 4096))
+
+Values are stored with [coherence.CompressionZstd] as the compressor id, so every client reading the cache must
+be configured with a zstd [coherence.Compressor].
+*/
+package zstdcompression